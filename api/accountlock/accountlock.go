@@ -6,24 +6,35 @@
 package accountlock
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/chain"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/script/accountlock"
+	"github.com/dfinlab/meter/state"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
 
 type AccountLock struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
 }
 
-func New() *AccountLock {
-	return &AccountLock{}
+func New(chain *chain.Chain, stateCreator *state.Creator) *AccountLock {
+	return &AccountLock{chain: chain, stateCreator: stateCreator}
 }
 
 func (a *AccountLock) handleGetAccountLockProfile(w http.ResponseWriter, req *http.Request) error {
-	list, err := accountlock.GetLatestProfileList()
+	h, err := a.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	list, err := accountlock.GetProfileListByHeader(h)
 	if err != nil {
 		return err
 	}
@@ -32,21 +43,96 @@ func (a *AccountLock) handleGetAccountLockProfile(w http.ResponseWriter, req *ht
 }
 
 func (a *AccountLock) handleGetProfileByID(w http.ResponseWriter, req *http.Request) error {
-	list, err := accountlock.GetLatestProfileList()
+	addr, err := meter.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	h, err := a.handleRevision(req.URL.Query().Get("revision"))
 	if err != nil {
 		return err
 	}
-	id := mux.Vars(req)["address"]
-	bytes, err := meter.ParseAddress(id)
+	list, err := accountlock.GetProfileListByHeader(h)
 	if err != nil {
-		return utils.BadRequest(errors.WithMessage(err, "address"))
+		return err
+	}
+	s := list.Get(addr)
+	if s == nil {
+		return utils.HTTPError(errors.New("profile not found"), http.StatusNotFound)
 	}
-	s := list.Get(bytes)
 	profile := convertProfile(s)
 	return utils.WriteJSON(w, profile)
 }
 
+func (a *AccountLock) handleCheckAccount(w http.ResponseWriter, req *http.Request) error {
+	addr, err := meter.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	h, err := a.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	blk, err := a.chain.GetBlock(h.ID())
+	if err != nil {
+		return err
+	}
+	list, err := accountlock.GetProfileListByHeader(h)
+	if err != nil {
+		return err
+	}
+	state, err := a.stateCreator.NewState(h.StateRoot())
+	if err != nil {
+		return err
+	}
+	balance := state.GetBalance(addr)
+	boundedBalance := state.GetBoundedBalance(addr)
+	energy := state.GetEnergy(addr)
+	if err := state.Err(); err != nil {
+		return err
+	}
+
+	check := convertAccountCheck(addr, uint32(blk.GetBlockEpoch()), list.Get(addr), balance, boundedBalance, energy)
+	return utils.WriteJSON(w, check)
+}
+
+func (a *AccountLock) handleRevision(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return a.chain.BestBlock().Header(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		blockID, err := meter.ParseBytes32(revision)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		h, err := a.chain.GetBlockHeader(blockID)
+		if err != nil {
+			if a.chain.IsNotFound(err) {
+				return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+			}
+			return nil, err
+		}
+		return h, nil
+	}
+	n, err := strconv.ParseUint(revision, 0, 0)
+	if err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	if n > math.MaxUint32 {
+		return nil, utils.BadRequest(errors.WithMessage(errors.New("block number out of max uint32"), "revision"))
+	}
+	h, err := a.chain.GetTrunkBlockHeader(uint32(n))
+	if err != nil {
+		if a.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
 func (a *AccountLock) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 	sub.Path("/profiles").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(a.handleGetAccountLockProfile))
+	sub.Path("/profiles/{address}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(a.handleGetProfileByID))
+	sub.Path("/check/{address}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(a.handleCheckAccount))
 }
@@ -6,6 +6,7 @@
 package accountlock
 
 import (
+	"math/big"
 	"sort"
 
 	"github.com/dfinlab/meter/meter"
@@ -21,6 +22,18 @@ type AccountLockProfile struct {
 	MeterGovAmount string        `json:"meterGov"`
 }
 
+type AccountCheck struct {
+	Addr              meter.Address `json:"address"`
+	Epoch             uint32        `json:"epoch"`
+	Locked            bool          `json:"locked"`
+	ReleaseEpoch      uint32        `json:"releaseEpoch"`
+	LockedMeterGov    string        `json:"lockedMeterGov"`
+	MeterGovBalance   string        `json:"meterGovBalance"`
+	BoundedMeterGov   string        `json:"boundedMeterGov"`
+	SpendableMeterGov string        `json:"spendableMeterGov"`
+	SpendableMeter    string        `json:"spendableMeter"`
+}
+
 func convertProfileList(list *accountlock.ProfileList) []*AccountLockProfile {
 	profileList := make([]*AccountLockProfile, 0)
 	for _, s := range list.ToList() {
@@ -44,3 +57,36 @@ func convertProfile(a *accountlock.Profile) *AccountLockProfile {
 		MeterGovAmount: a.MeterGovAmount.String(),
 	}
 }
+
+// convertAccountCheck follows the rule enforced on transfers: an account under lock may only
+// spend the MTRG exceeding the locked amount, counting bounded MTRG as part of its holdings.
+// MTR is never restricted by account lock.
+func convertAccountCheck(addr meter.Address, epoch uint32, p *accountlock.Profile, balance, boundedBalance, energy *big.Int) *AccountCheck {
+	check := &AccountCheck{
+		Addr:              addr,
+		Epoch:             epoch,
+		LockedMeterGov:    "0",
+		MeterGovBalance:   balance.String(),
+		BoundedMeterGov:   boundedBalance.String(),
+		SpendableMeterGov: balance.String(),
+		SpendableMeter:    energy.String(),
+	}
+	if p == nil || !p.IsLocked(epoch) {
+		return check
+	}
+
+	check.Locked = true
+	check.ReleaseEpoch = p.ReleaseEpoch
+	check.LockedMeterGov = p.MeterGovAmount.String()
+
+	spendable := new(big.Int).Add(balance, boundedBalance)
+	spendable.Sub(spendable, p.MeterGovAmount)
+	if spendable.Cmp(balance) > 0 {
+		spendable = new(big.Int).Set(balance)
+	}
+	if spendable.Sign() < 0 {
+		spendable = big.NewInt(0)
+	}
+	check.SpendableMeterGov = spendable.String()
+	return check
+}
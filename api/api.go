@@ -8,6 +8,7 @@ package api
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/dfinlab/meter/api/accountlock"
 	"github.com/dfinlab/meter/api/accounts"
@@ -17,14 +18,18 @@ import (
 	"github.com/dfinlab/meter/api/doc"
 	"github.com/dfinlab/meter/api/events"
 	"github.com/dfinlab/meter/api/eventslegacy"
+	"github.com/dfinlab/meter/api/governance"
 	"github.com/dfinlab/meter/api/node"
+	"github.com/dfinlab/meter/api/params"
 	"github.com/dfinlab/meter/api/peers"
 	"github.com/dfinlab/meter/api/slashing"
 	"github.com/dfinlab/meter/api/staking"
 	"github.com/dfinlab/meter/api/subscriptions"
+	"github.com/dfinlab/meter/api/tokentransfers"
 	"github.com/dfinlab/meter/api/transactions"
 	"github.com/dfinlab/meter/api/transfers"
 	"github.com/dfinlab/meter/api/transferslegacy"
+	"github.com/dfinlab/meter/api/txs"
 	"github.com/dfinlab/meter/chain"
 	"github.com/dfinlab/meter/logdb"
 	"github.com/dfinlab/meter/p2psrv"
@@ -36,7 +41,7 @@ import (
 )
 
 //New return api router
-func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, nw node.Network, allowedOrigins string, backtraceLimit uint32, callGasLimit uint64, p2pServer *p2psrv.Server, pubKey string) (http.HandlerFunc, func()) {
+func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, nw node.Network, allowedOrigins string, backtraceLimit uint32, callGasLimit uint64, p2pServer *p2psrv.Server, pubKey string, allowCustomTracer bool, tracerStepLimit uint64, tracerTimeout time.Duration) (http.HandlerFunc, func()) {
 	origins := strings.Split(strings.TrimSpace(allowedOrigins), ",")
 	for i, o := range origins {
 		origins[i] = strings.ToLower(strings.TrimSpace(o))
@@ -72,25 +77,33 @@ func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool,
 		Mount(router, "/logs/transfers")
 	transfers.New(logDB).
 		Mount(router, "/logs/transfer")
+	txs.New(logDB).
+		Mount(router, "/logs/tx")
+	tokentransfers.New(logDB).
+		Mount(router, "/logs/token-transfer")
 	blocks.New(chain).
 		Mount(router, "/blocks")
 	transactions.New(chain, txPool).
 		Mount(router, "/transactions")
-	debug.New(chain, stateCreator).
+	debug.New(chain, stateCreator, callGasLimit, allowCustomTracer, tracerStepLimit, tracerTimeout).
 		Mount(router, "/debug")
 	node.New(nw, pubKey).
 		Mount(router, "/node")
-	peers.New(p2pServer).Mount(router, "/peers")
+	peers.New(p2pServer, nw).Mount(router, "/peers")
 	subs := subscriptions.New(chain, origins, backtraceLimit)
 	subs.Mount(router, "/subscriptions")
 	staking.New(chain, stateCreator).
 		Mount(router, "/staking")
-	slashing.New().
+	slashing.New(chain, stateCreator, logDB).
 		Mount(router, "/slashing")
-	auction.New(chain, stateCreator).
+	auction.New(chain, stateCreator, logDB).
 		Mount(router, "/auction")
-	accountlock.New().
+	accountlock.New(chain, stateCreator).
 		Mount(router, "/accountlock")
+	governance.New(chain, stateCreator, logDB).
+		Mount(router, "/governance")
+	params.New(chain, stateCreator, logDB).
+		Mount(router, "/params")
 
 	return handlers.CORS(
 			handlers.AllowedOrigins(origins),
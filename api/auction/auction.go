@@ -13,6 +13,7 @@ import (
 	"github.com/dfinlab/meter/api/utils"
 	"github.com/dfinlab/meter/block"
 	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/logdb"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/script/auction"
 	"github.com/dfinlab/meter/state"
@@ -23,11 +24,12 @@ import (
 type Auction struct {
 	chain        *chain.Chain
 	stateCreator *state.Creator
+	logDB        *logdb.LogDB
 }
 
 func New(chain *chain.Chain,
-	stateCreator *state.Creator) *Auction {
-	return &Auction{chain: chain, stateCreator: stateCreator}
+	stateCreator *state.Creator, logDB *logdb.LogDB) *Auction {
+	return &Auction{chain: chain, stateCreator: stateCreator, logDB: logDB}
 }
 
 func (at *Auction) handleGetAuctionSummary(w http.ResponseWriter, req *http.Request) error {
@@ -74,18 +76,67 @@ func (at *Auction) handleGetAuctionDigest(w http.ResponseWriter, req *http.Reque
 }
 
 func (at *Auction) handleGetSummaryByID(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+	auctionID, err := meter.ParseBytes32(id)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "id"))
+	}
 	list, err := auction.GetAuctionSummaryList()
 	if err != nil {
 		return err
 	}
-	id := mux.Vars(req)["auctionID"]
-	bytes, err := meter.ParseBytes32(id)
+	if s := list.Get(auctionID); s != nil {
+		return utils.WriteJSON(w, convertSummary(s))
+	}
+
+	// older summaries are only kept in logdb
+	summaries, err := at.logDB.FilterAuctionSummaries(req.Context(), &logdb.AuctionSummaryFilter{AuctionID: &auctionID})
+	if err != nil {
+		return err
+	}
+	if len(summaries) == 0 {
+		return utils.HTTPError(errors.New("auction not found"), http.StatusNotFound)
+	}
+	return utils.WriteJSON(w, convertLogSummary(summaries[len(summaries)-1]))
+}
+
+func (at *Auction) handleGetAuctionHistory(w http.ResponseWriter, req *http.Request) error {
+	options, order, err := parseOptions(req)
+	if err != nil {
+		return err
+	}
+	summaries, err := at.logDB.FilterAuctionSummaries(req.Context(), &logdb.AuctionSummaryFilter{
+		Options: options,
+		Order:   order,
+	})
+	if err != nil {
+		return err
+	}
+	summaryList := make([]*AuctionSummary, 0, len(summaries))
+	for _, s := range summaries {
+		summaryList = append(summaryList, convertLogSummary(s))
+	}
+	return utils.WriteJSON(w, summaryList)
+}
+
+func (at *Auction) handleGetBidderHistory(w http.ResponseWriter, req *http.Request) error {
+	addr, err := meter.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	options, order, err := parseOptions(req)
+	if err != nil {
+		return err
+	}
+	txs, err := at.logDB.FilterAuctionTxs(req.Context(), &logdb.AuctionTxFilter{
+		Bidder:  &addr,
+		Options: options,
+		Order:   order,
+	})
 	if err != nil {
 		return err
 	}
-	s := list.Get(bytes)
-	summary := convertSummary(s)
-	return utils.WriteJSON(w, summary)
+	return utils.WriteJSON(w, convertBidderHistory(addr, txs))
 }
 
 func (at *Auction) handleGetAuctionCB(w http.ResponseWriter, req *http.Request) error {
@@ -144,11 +195,43 @@ func (at *Auction) handleRevision(revision string) (*block.Header, error) {
 	return h, nil
 }
 
+func parseOptions(req *http.Request) (*logdb.Options, logdb.Order, error) {
+	query := req.URL.Query()
+	order := logdb.ASC
+	if o := query.Get("order"); o != "" {
+		order = logdb.Order(o)
+		if order != logdb.ASC && order != logdb.DESC {
+			return nil, order, utils.BadRequest(errors.New("order: should be asc or desc"))
+		}
+	}
+	if query.Get("offset") == "" && query.Get("limit") == "" {
+		return nil, order, nil
+	}
+	options := &logdb.Options{Offset: 0, Limit: math.MaxInt64}
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.ParseUint(v, 0, 0)
+		if err != nil {
+			return nil, order, utils.BadRequest(errors.WithMessage(err, "offset"))
+		}
+		options.Offset = offset
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 0, 0)
+		if err != nil {
+			return nil, order, utils.BadRequest(errors.WithMessage(err, "limit"))
+		}
+		options.Limit = limit
+	}
+	return options, order, nil
+}
+
 func (at *Auction) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 	sub.Path("/summaries").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(at.handleGetAuctionSummary))
 	sub.Path("/summaries/{id}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(at.handleGetSummaryByID))
 	sub.Path("/last/summary").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(at.handleGetLastAuctionSummary))
 	sub.Path("/present").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(at.handleGetAuctionCB))
+	sub.Path("/history").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(at.handleGetAuctionHistory))
+	sub.Path("/bidders/{address}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(at.handleGetBidderHistory))
 	//sub.Path("/auctioncb/{address}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(st.handleGetAuctionTxByAddress))
 }
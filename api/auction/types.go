@@ -11,6 +11,8 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/script/auction"
 )
 
@@ -71,6 +73,25 @@ type AuctionTx struct {
 	Nonce        uint64 `json:"nonce"`
 }
 
+type BidderAuctionTx struct {
+	AuctionID    string `json:"auctionID"`
+	BlockNumber  uint32 `json:"blockNumber"`
+	TxID         string `json:"txid"`
+	Amount       string `json:"amount"`
+	Type         string `json:"type"`
+	Timestamp    uint64 `json:"timestamp"`
+	TimestampStr string `json:"timestampStr"`
+	Nonce        uint64 `json:"nonce"`
+	DistMTRG     string `json:"distMTRG"`
+}
+
+type BidderHistory struct {
+	Address       string             `json:"address"`
+	TotalBid      string             `json:"totalBid"`
+	TotalDistMTRG string             `json:"totalDistMTRG"`
+	Bids          []*BidderAuctionTx `json:"bids"`
+}
+
 type AuctionCB struct {
 	AuctionID   string       `json:"auctionID"`
 	StartHeight uint64       `json:"startHeight"`
@@ -186,18 +207,11 @@ func convertSummary(s *auction.AuctionSummary) *AuctionSummary {
 }
 
 func convertAuctionTx(t *auction.AuctionTx) *AuctionTx {
-	var bidType string
-	if t.Type == auction.USER_BID {
-		bidType = "userbid"
-	} else {
-		bidType = "autobid"
-	}
-
 	return &AuctionTx{
 		TxID:         t.TxID.String(),
 		Address:      t.Address.String(),
 		Amount:       t.Amount.String(),
-		Type:         bidType,
+		Type:         bidTypeName(t.Type),
 		TimestampStr: fmt.Sprintln(time.Unix(int64(t.Timestamp), 0)),
 		Timestamp:    t.Timestamp,
 		Nonce:        t.Nonce,
@@ -229,3 +243,75 @@ func convertAuctionCB(cb *auction.AuctionCB) *AuctionCB {
 		AuctionTxs:  txs,
 	}
 }
+
+func bidTypeName(t uint32) string {
+	if t == auction.USER_BID {
+		return "userbid"
+	}
+	return "autobid"
+}
+
+func convertLogSummary(s *logdb.AuctionSummary) *AuctionSummary {
+	dists := make([]*DistMtrg, 0)
+	txs := make([]*AuctionTx, 0)
+	for _, t := range s.Txs {
+		dists = append(dists, &DistMtrg{
+			Addr:   t.Bidder.String(),
+			Amount: t.DistMTRG.String(),
+		})
+		txs = append(txs, &AuctionTx{
+			TxID:         t.TxID.String(),
+			Address:      t.Bidder.String(),
+			Amount:       t.Amount.String(),
+			Type:         bidTypeName(t.Type),
+			TimestampStr: fmt.Sprintln(time.Unix(int64(t.Timestamp), 0)),
+			Timestamp:    t.Timestamp,
+			Nonce:        t.Nonce,
+		})
+	}
+	return &AuctionSummary{
+		AuctionID:    s.AuctionID.String(),
+		StartHeight:  s.StartHeight,
+		StartEpoch:   s.StartEpoch,
+		EndHeight:    s.EndHeight,
+		EndEpoch:     s.EndEpoch,
+		Sequence:     s.Sequence,
+		RlsdMTRG:     s.RlsdMTRG.String(),
+		RsvdMTRG:     s.RsvdMTRG.String(),
+		RsvdPrice:    s.RsvdPrice.String(),
+		Timestamp:    fmt.Sprintln(time.Unix(int64(s.CreateTime), 0)),
+		CreateTime:   s.CreateTime,
+		RcvdMTR:      s.RcvdMTR.String(),
+		ActualPrice:  s.ActualPrice.String(),
+		LeftoverMTRG: s.LeftoverMTRG.String(),
+		AuctionTxs:   txs,
+		DistMTRG:     dists,
+	}
+}
+
+func convertBidderHistory(addr meter.Address, txs []*logdb.AuctionTx) *BidderHistory {
+	totalBid := big.NewInt(0)
+	totalDist := big.NewInt(0)
+	bids := make([]*BidderAuctionTx, 0, len(txs))
+	for _, t := range txs {
+		totalBid.Add(totalBid, t.Amount)
+		totalDist.Add(totalDist, t.DistMTRG)
+		bids = append(bids, &BidderAuctionTx{
+			AuctionID:    t.AuctionID.String(),
+			BlockNumber:  t.BlockNumber,
+			TxID:         t.TxID.String(),
+			Amount:       t.Amount.String(),
+			Type:         bidTypeName(t.Type),
+			Timestamp:    t.Timestamp,
+			TimestampStr: fmt.Sprintln(time.Unix(int64(t.Timestamp), 0)),
+			Nonce:        t.Nonce,
+			DistMTRG:     t.DistMTRG.String(),
+		})
+	}
+	return &BidderHistory{
+		Address:       addr.String(),
+		TotalBid:      totalBid.String(),
+		TotalDistMTRG: totalDist.String(),
+		Bids:          bids,
+	}
+}
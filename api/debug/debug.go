@@ -8,22 +8,29 @@ package debug
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/block"
 	"github.com/dfinlab/meter/chain"
 	"github.com/dfinlab/meter/consensus"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/runtime"
+	"github.com/dfinlab/meter/signer"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/tracers"
 	"github.com/dfinlab/meter/trie"
+	"github.com/dfinlab/meter/tx"
 	"github.com/dfinlab/meter/types"
 	"github.com/dfinlab/meter/vm"
+	"github.com/dfinlab/meter/xenv"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/mux"
@@ -31,27 +38,58 @@ import (
 )
 
 type Debug struct {
-	chain  *chain.Chain
-	stateC *state.Creator
+	chain             *chain.Chain
+	stateC            *state.Creator
+	callGasLimit      uint64
+	allowCustomTracer bool
+	tracerStepLimit   uint64
+	tracerTimeout     time.Duration
 }
 
 var (
 	Magic = [4]byte{0x00, 0x00, 0x00, 0x00}
 )
 
-func New(chain *chain.Chain, stateC *state.Creator) *Debug {
+func New(chain *chain.Chain, stateC *state.Creator, callGasLimit uint64, allowCustomTracer bool, tracerStepLimit uint64, tracerTimeout time.Duration) *Debug {
 	return &Debug{
 		chain,
 		stateC,
+		callGasLimit,
+		allowCustomTracer,
+		tracerStepLimit,
+		tracerTimeout,
 	}
 }
 
-func (d *Debug) handleTxEnv(ctx context.Context, blockID meter.Bytes32, txIndex uint64, clauseIndex uint64) (*runtime.Runtime, *runtime.TransactionExecutor, error) {
+func (d *Debug) getBlock(blockID meter.Bytes32) (*block.Block, error) {
 	block, err := d.chain.GetBlock(blockID)
 	if err != nil {
 		if d.chain.IsNotFound(err) {
-			return nil, nil, utils.Forbidden(errors.New("block not found"))
+			return nil, utils.Forbidden(errors.New("block not found"))
 		}
+		return nil, err
+	}
+	return block, nil
+}
+
+// newReplayRuntime creates the runtime to replay txs of the block.
+func (d *Debug) newReplayRuntime(block *block.Block) (*runtime.Runtime, error) {
+	// XXX TODO: make sure this won't change anything
+	// The reason why we have these lines is interface change of NewConsensusReactor( with signer added)
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, utils.Forbidden(errors.New("can not generate private/public key"))
+	}
+
+	blsCommon := consensus.NewBlsCommon()
+
+	localSigner := signer.NewLocal(privKey, blsCommon.PrivKey, blsCommon.PubKey, *blsCommon.GetSystem(), nil)
+	return consensus.NewConsensusReactor(nil, d.chain, d.stateC, localSigner, Magic, blsCommon, make([]*types.Delegate /* FIXME: this is an empty input */, 0)).NewRuntimeForReplay(block.Header())
+}
+
+func (d *Debug) handleTxEnv(ctx context.Context, blockID meter.Bytes32, txIndex uint64, clauseIndex uint64) (*runtime.Runtime, *runtime.TransactionExecutor, error) {
+	block, err := d.getBlock(blockID)
+	if err != nil {
 		return nil, nil, err
 	}
 	txs := block.Transactions()
@@ -62,16 +100,7 @@ func (d *Debug) handleTxEnv(ctx context.Context, blockID meter.Bytes32, txIndex
 		return nil, nil, utils.Forbidden(errors.New("clause index out of range"))
 	}
 
-	// XXX TODO: make sure this won't change anything
-	// The reason why we have these lines is interface change of NewConsensusReactor( with private and public key added)
-	privKey, err := crypto.GenerateKey()
-	if err != nil {
-		return nil, nil, utils.Forbidden(errors.New("can not generate private/public key"))
-	}
-
-	blsCommon := consensus.NewBlsCommon()
-
-	rt, err := consensus.NewConsensusReactor(nil, d.chain, d.stateC, privKey, &privKey.PublicKey, Magic, blsCommon, make([]*types.Delegate /* FIXME: this is an empty input */, 0)).NewRuntimeForReplay(block.Header())
+	rt, err := d.newReplayRuntime(block)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -116,6 +145,209 @@ func (d *Debug) traceTransaction(ctx context.Context, tracer vm.Tracer, blockID
 	if err != nil {
 		return nil, err
 	}
+	return tracerResult(tracer, gasUsed, output)
+}
+
+// traceBlock replays all txs of the block, and traces each clause with a new tracer.
+func (d *Debug) traceBlock(ctx context.Context, name, code string, blockID meter.Bytes32) ([]*TxTraceResult, error) {
+	block, err := d.getBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+	rt, err := d.newReplayRuntime(block)
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	results := make([]*TxTraceResult, 0, len(txs))
+	for _, tx := range txs {
+		txExec, err := rt.PrepareTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		result := &TxTraceResult{
+			TxID:    tx.ID(),
+			Clauses: make([]*ClauseTraceResult, 0, len(tx.Clauses())),
+		}
+		for txExec.HasNextClause() {
+			tracer, err := d.newTracer(name, code)
+			if err != nil {
+				return nil, err
+			}
+			rt.SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+			gasUsed, output, err := txExec.NextClause()
+			if err != nil {
+				return nil, err
+			}
+			res, err := tracerResult(tracer, gasUsed, output)
+			if err != nil {
+				return nil, err
+			}
+			result.Clauses = append(result.Clauses, &ClauseTraceResult{
+				ClauseIndex: uint32(len(result.Clauses)),
+				Result:      res,
+			})
+		}
+		rt.SetVMConfig(vm.Config{})
+		if _, err := txExec.Finalize(); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+	return results, nil
+}
+
+// traceCall executes the clauses on the state of the given block, and traces each
+// clause with a new tracer. It stops at the first reverted clause.
+func (d *Debug) traceCall(ctx context.Context, opt *TraceCallOption, header *block.Header) ([]*ClauseTraceResult, error) {
+	gas, gasPrice, caller, clauses, err := d.handleTraceCallOption(opt)
+	if err != nil {
+		return nil, err
+	}
+	state, err := d.stateC.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	signer, _ := header.Signer()
+	rt := runtime.New(d.chain.NewSeeker(header.ParentID()), state,
+		&xenv.BlockContext{
+			Beneficiary: header.Beneficiary(),
+			Signer:      signer,
+			Number:      header.Number(),
+			Time:        header.Timestamp(),
+			GasLimit:    header.GasLimit(),
+			TotalScore:  header.TotalScore()})
+	txCtx := &xenv.TransactionContext{
+		Origin:     *caller,
+		GasPrice:   gasPrice,
+		BlockRef:   tx.NewBlockRefFromID(d.chain.BestBlock().Header().ID()),
+		ProvedWork: &big.Int{},
+	}
+
+	results := make([]*ClauseTraceResult, 0, len(clauses))
+	for i, clause := range clauses {
+		tracer, err := d.newTracer(opt.Name, opt.Code)
+		if err != nil {
+			return nil, err
+		}
+		rt.SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+		out := rt.ExecuteClause(clause, uint32(i), gas, txCtx)
+		if err := rt.Seeker().Err(); err != nil {
+			return nil, err
+		}
+		if err := state.Err(); err != nil {
+			return nil, err
+		}
+		res, err := tracerResult(tracer, gas-out.LeftOverGas, out)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &ClauseTraceResult{
+			ClauseIndex: uint32(i),
+			Result:      res,
+		})
+		if out.VMErr != nil {
+			break
+		}
+		gas = out.LeftOverGas
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+	return results, nil
+}
+
+func (d *Debug) handleTraceCallOption(opt *TraceCallOption) (gas uint64, gasPrice *big.Int, caller *meter.Address, clauses []*tx.Clause, err error) {
+	if opt.Gas > d.callGasLimit {
+		return 0, nil, nil, nil, utils.Forbidden(errors.New("gas: exceeds limit"))
+	} else if opt.Gas == 0 {
+		gas = d.callGasLimit
+	} else {
+		gas = opt.Gas
+	}
+	if opt.GasPrice == nil {
+		gasPrice = new(big.Int)
+	} else {
+		gasPrice = (*big.Int)(opt.GasPrice)
+	}
+	if opt.Caller == nil {
+		caller = &meter.Address{}
+	} else {
+		caller = opt.Caller
+	}
+	clauses = make([]*tx.Clause, len(opt.Clauses))
+	for i, c := range opt.Clauses {
+		var value *big.Int
+		if c.Value == nil {
+			value = new(big.Int)
+		} else {
+			value = (*big.Int)(c.Value)
+		}
+		var data []byte
+		if c.Data != "" {
+			data, err = hexutil.Decode(c.Data)
+			if err != nil {
+				err = utils.BadRequest(errors.WithMessage(err, fmt.Sprintf("data[%d]", i)))
+				return
+			}
+		}
+		clauses[i] = tx.NewClause(c.To).WithData(data).WithValue(value).WithToken(c.Token)
+	}
+	return
+}
+
+// checkTracer validates the tracer option without creating the tracer.
+func (d *Debug) checkTracer(name, code string) error {
+	if code != "" {
+		if name != "" {
+			return utils.BadRequest(errors.New("name and code are mutually exclusive"))
+		}
+		if !d.allowCustomTracer {
+			return utils.Forbidden(errors.New("code: custom tracer is disabled"))
+		}
+		return nil
+	}
+	if name != "" {
+		if !strings.HasSuffix(name, "Tracer") {
+			name += "Tracer"
+		}
+		if _, ok := tracers.CodeByName(name); !ok {
+			return utils.BadRequest(errors.New("name: unsupported tracer"))
+		}
+	}
+	return nil
+}
+
+// newTracer creates the tracer by name or from the custom JavaScript code, the struct
+// logger is created if both are empty.
+func (d *Debug) newTracer(name, code string) (vm.Tracer, error) {
+	if err := d.checkTracer(name, code); err != nil {
+		return nil, err
+	}
+	if code != "" {
+		tracer, err := tracers.NewWithLimit(code, d.tracerStepLimit, d.tracerTimeout)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "code"))
+		}
+		return tracer, nil
+	}
+	if name == "" {
+		return vm.NewStructLogger(nil), nil
+	}
+	if !strings.HasSuffix(name, "Tracer") {
+		name += "Tracer"
+	}
+	return tracers.NewByName(name)
+}
+
+func tracerResult(tracer vm.Tracer, gasUsed uint64, output *runtime.Output) (interface{}, error) {
 	switch tr := tracer.(type) {
 	case *vm.StructLogger:
 		return &ExecutionResult{
@@ -124,7 +356,7 @@ func (d *Debug) traceTransaction(ctx context.Context, tracer vm.Tracer, blockID
 			ReturnValue: hexutil.Encode(output.Data),
 			StructLogs:  formatLogs(tr.StructLogs()),
 		}, nil
-	case *tracers.Tracer:
+	case tracers.ResultTracer:
 		return tr.GetResult()
 	default:
 		return nil, fmt.Errorf("bad tracer type %T", tracer)
@@ -139,23 +371,9 @@ func (d *Debug) handleTraceTransaction(w http.ResponseWriter, req *http.Request)
 	if opt == nil {
 		return utils.BadRequest(errors.New("body: empty body"))
 	}
-	var tracer vm.Tracer
-	if opt.Name == "" {
-		tracer = vm.NewStructLogger(nil)
-	} else {
-		name := opt.Name
-		if !strings.HasSuffix(name, "Tracer") {
-			name += "Tracer"
-		}
-		code, ok := tracers.CodeByName(name)
-		if !ok {
-			return utils.BadRequest(errors.New("name: unsupported tracer"))
-		}
-		tr, err := tracers.New(code)
-		if err != nil {
-			return err
-		}
-		tracer = tr
+	tracer, err := d.newTracer(opt.Name, opt.Code)
+	if err != nil {
+		return err
 	}
 	blockID, txIndex, clauseIndex, err := d.parseTarget(opt.Target)
 	if err != nil {
@@ -168,6 +386,50 @@ func (d *Debug) handleTraceTransaction(w http.ResponseWriter, req *http.Request)
 	return utils.WriteJSON(w, res)
 }
 
+func (d *Debug) handleTraceBlock(w http.ResponseWriter, req *http.Request) error {
+	var opt *BlockTracerOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if opt == nil {
+		return utils.BadRequest(errors.New("body: empty body"))
+	}
+	if err := d.checkTracer(opt.Name, opt.Code); err != nil {
+		return err
+	}
+	blockID, err := meter.ParseBytes32(opt.BlockID)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "blockID"))
+	}
+	res, err := d.traceBlock(req.Context(), opt.Name, opt.Code, blockID)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (d *Debug) handleTraceCall(w http.ResponseWriter, req *http.Request) error {
+	var opt *TraceCallOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if opt == nil {
+		return utils.BadRequest(errors.New("body: empty body"))
+	}
+	if err := d.checkTracer(opt.Name, opt.Code); err != nil {
+		return err
+	}
+	h, err := d.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	res, err := d.traceCall(req.Context(), opt, h)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
 func (d *Debug) debugStorage(ctx context.Context, contractAddress meter.Address, blockID meter.Bytes32, txIndex uint64, clauseIndex uint64, keyStart []byte, maxResult int) (*StorageRangeResult, error) {
 	rt, _, err := d.handleTxEnv(ctx, blockID, txIndex, clauseIndex)
 	if err != nil {
@@ -177,10 +439,10 @@ func (d *Debug) debugStorage(ctx context.Context, contractAddress meter.Address,
 	if err != nil {
 		return nil, err
 	}
-	return storageRangeAt(storageTrie, keyStart, maxResult)
+	return storageRangeAt(rt.State(), storageTrie, keyStart, maxResult)
 }
 
-func storageRangeAt(t *trie.SecureTrie, start []byte, maxResult int) (*StorageRangeResult, error) {
+func storageRangeAt(st *state.State, t *trie.SecureTrie, start []byte, maxResult int) (*StorageRangeResult, error) {
 	it := trie.NewIterator(t.NodeIterator(start))
 	result := StorageRangeResult{Storage: StorageMap{}}
 	for i := 0; i < maxResult && it.Next(); i++ {
@@ -190,9 +452,14 @@ func storageRangeAt(t *trie.SecureTrie, start []byte, maxResult int) (*StorageRa
 		}
 		v := meter.BytesToBytes32(content)
 		e := StorageEntry{Value: &v}
-		if preimage := t.GetKey(it.Key); preimage != nil {
-			preimage := meter.BytesToBytes32(preimage)
-			e.Key = &preimage
+		preimage := t.GetKey(it.Key)
+		if len(preimage) == 0 {
+			// not changed in this state, try the recorded one
+			preimage = st.GetPreimage(meter.BytesToBytes32(it.Key))
+		}
+		if len(preimage) > 0 {
+			key := meter.BytesToBytes32(preimage)
+			e.Key = &key
 		}
 		result.Storage[meter.BytesToBytes32(it.Key).String()] = e
 	}
@@ -200,9 +467,58 @@ func storageRangeAt(t *trie.SecureTrie, start []byte, maxResult int) (*StorageRa
 		next := meter.BytesToBytes32(it.Key)
 		result.NextKey = &next
 	}
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func accountsRangeAt(st *state.State, start []byte, maxResult int) (*AccountsRangeResult, error) {
+	result := AccountsRangeResult{Accounts: AccountMap{}}
+	if err := st.RangeAccounts(start, func(hash meter.Bytes32, addr *meter.Address, acc *state.Account) bool {
+		if len(result.Accounts) >= maxResult {
+			result.NextKey = &hash
+			return false
+		}
+		result.Accounts[hash.String()] = newAccountEntry(addr, acc)
+		return true
+	}); err != nil {
+		return nil, err
+	}
 	return &result, nil
 }
 
+func (d *Debug) handleAccountsRange(w http.ResponseWriter, req *http.Request) error {
+	var opt *AccountsRangeOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if opt == nil {
+		return utils.BadRequest(errors.New("body: empty body"))
+	}
+	var keyStart []byte
+	if opt.KeyStart != "" {
+		k, err := hexutil.Decode(opt.KeyStart)
+		if err != nil {
+			return utils.BadRequest(errors.New("keyStart: invalid format"))
+		}
+		keyStart = k
+	}
+	h, err := d.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	st, err := d.stateC.NewState(h.StateRoot())
+	if err != nil {
+		return err
+	}
+	res, err := accountsRangeAt(st, keyStart, opt.MaxResult)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
 func (d *Debug) handleDebugStorage(w http.ResponseWriter, req *http.Request) error {
 	var opt *StorageRangeOption
 	if err := utils.ParseJSON(req.Body, &opt); err != nil {
@@ -266,10 +582,48 @@ func (d *Debug) parseTarget(target string) (blockID meter.Bytes32, txIndex uint6
 	return
 }
 
+func (d *Debug) handleRevision(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return d.chain.BestBlock().Header(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		blockID, err := meter.ParseBytes32(revision)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		h, err := d.chain.GetBlockHeader(blockID)
+		if err != nil {
+			if d.chain.IsNotFound(err) {
+				return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+			}
+			return nil, err
+		}
+		return h, nil
+	}
+	n, err := strconv.ParseUint(revision, 0, 0)
+	if err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	if n > math.MaxUint32 {
+		return nil, utils.BadRequest(errors.WithMessage(errors.New("block number out of max uint32"), "revision"))
+	}
+	h, err := d.chain.GetTrunkBlockHeader(uint32(n))
+	if err != nil {
+		if d.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
 func (d *Debug) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
 	sub.Path("/tracers").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleTraceTransaction))
+	sub.Path("/tracers/block").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleTraceBlock))
+	sub.Path("/tracers/call").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleTraceCall))
 	sub.Path("/storage-range").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleDebugStorage))
+	sub.Path("/accounts-range").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleAccountsRange))
 
 }
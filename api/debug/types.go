@@ -3,7 +3,9 @@ package debug
 import (
 	"fmt"
 
+	"github.com/dfinlab/meter/api/accounts"
 	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/state"
 
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/dfinlab/meter/vm"
@@ -11,9 +13,35 @@ import (
 
 type TracerOption struct {
 	Name   string `json:"name"`
+	Code   string `json:"code"`
 	Target string `json:"target"`
 }
 
+type BlockTracerOption struct {
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+	BlockID string `json:"blockID"`
+}
+
+type TraceCallOption struct {
+	Name     string                `json:"name"`
+	Code     string                `json:"code"`
+	Clauses  accounts.Clauses      `json:"clauses"`
+	Gas      uint64                `json:"gas"`
+	GasPrice *math.HexOrDecimal256 `json:"gasPrice"`
+	Caller   *meter.Address        `json:"caller"`
+}
+
+type ClauseTraceResult struct {
+	ClauseIndex uint32      `json:"clauseIndex"`
+	Result      interface{} `json:"result"`
+}
+
+type TxTraceResult struct {
+	TxID    meter.Bytes32        `json:"txID"`
+	Clauses []*ClauseTraceResult `json:"clauses"`
+}
+
 type ExecutionResult struct {
 	Gas         uint64         `json:"gas"`
 	Failed      bool           `json:"failed"`
@@ -88,3 +116,43 @@ type StorageEntry struct {
 	Key   *meter.Bytes32 `json:"key"`
 	Value *meter.Bytes32 `json:"value"`
 }
+
+type AccountsRangeOption struct {
+	KeyStart  string
+	MaxResult int
+}
+
+type AccountsRangeResult struct {
+	Accounts AccountMap     `json:"accounts"`
+	NextKey  *meter.Bytes32 `json:"nextKey"` // nil if Accounts includes the last account in the trie.
+}
+
+type AccountMap map[string]AccountEntry
+
+type AccountEntry struct {
+	Address      *meter.Address        `json:"address"` // nil if the preimage is not recorded
+	Balance      *math.HexOrDecimal256 `json:"balance"`
+	Energy       *math.HexOrDecimal256 `json:"energy"`
+	BoundBalance *math.HexOrDecimal256 `json:"boundBalance"`
+	BoundEnergy  *math.HexOrDecimal256 `json:"boundEnergy"`
+	Master       *meter.Address        `json:"master"`
+	CodeHash     meter.Bytes32         `json:"codeHash"`
+	StorageRoot  meter.Bytes32         `json:"storageRoot"`
+}
+
+func newAccountEntry(addr *meter.Address, acc *state.Account) AccountEntry {
+	e := AccountEntry{
+		Address:      addr,
+		Balance:      (*math.HexOrDecimal256)(acc.Balance),
+		Energy:       (*math.HexOrDecimal256)(acc.Energy),
+		BoundBalance: (*math.HexOrDecimal256)(acc.BoundBalance),
+		BoundEnergy:  (*math.HexOrDecimal256)(acc.BoundEnergy),
+		CodeHash:     meter.BytesToBytes32(acc.CodeHash),
+		StorageRoot:  meter.BytesToBytes32(acc.StorageRoot),
+	}
+	if len(acc.Master) > 0 {
+		master := meter.BytesToAddress(acc.Master)
+		e.Master = &master
+	}
+	return e
+}
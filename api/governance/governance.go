@@ -0,0 +1,298 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package governance
+
+import (
+	"math"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/runtime"
+	"github.com/dfinlab/meter/state"
+	"github.com/dfinlab/meter/tx"
+	"github.com/dfinlab/meter/xenv"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// gas limit for reading executor storage through its getters
+const callGas = uint64(1000000)
+
+type Governance struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB) *Governance {
+	return &Governance{
+		chain,
+		stateCreator,
+		logDB,
+	}
+}
+
+func (g *Governance) handleGetProposals(w http.ResponseWriter, req *http.Request) error {
+	h, err := g.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	status := req.URL.Query().Get("status")
+	proposals, err := g.proposals(req, h, nil)
+	if err != nil {
+		return err
+	}
+	result := make([]*Proposal, 0, len(proposals))
+	for _, p := range proposals {
+		if status == "" || status == p.Status {
+			result = append(result, p)
+		}
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (g *Governance) handleGetProposalByID(w http.ResponseWriter, req *http.Request) error {
+	id, err := meter.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "id"))
+	}
+	h, err := g.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	proposals, err := g.proposals(req, h, &id)
+	if err != nil {
+		return err
+	}
+	if len(proposals) == 0 {
+		return utils.HTTPError(errors.New("proposal not found"), http.StatusNotFound)
+	}
+	return utils.WriteJSON(w, proposals[0])
+}
+
+// proposals collects proposals raised up to the given block from Proposal events
+// of executor, newest first, and fills them with the executor storage at that block.
+func (g *Governance) proposals(req *http.Request, h *block.Header, id *meter.Bytes32) ([]*Proposal, error) {
+	ev, _ := builtin.Executor.ABI.EventByName("Proposal")
+	evID := ev.ID()
+	criteria := &logdb.EventCriteria{Address: &builtin.Executor.Address}
+	criteria.Topics[0] = &evID
+	criteria.Topics[1] = id
+	events, err := g.logDB.FilterEvents(req.Context(), &logdb.EventFilter{
+		CriteriaSet: []*logdb.EventCriteria{criteria},
+		Range:       &logdb.Range{Unit: logdb.Block, From: 0, To: uint64(h.Number())},
+		Order:       logdb.ASC,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := g.newRuntime(h)
+	if err != nil {
+		return nil, err
+	}
+	getter, _ := builtin.Executor.ABI.MethodByName("proposals")
+
+	byID := make(map[meter.Bytes32]*Proposal)
+	proposals := make([]*Proposal, 0)
+	for _, e := range events {
+		if e.Topics[1] == nil {
+			continue
+		}
+		pid := *e.Topics[1]
+		switch eventAction(e.Data) {
+		case "proposed":
+			input, err := getter.EncodeInput(pid)
+			if err != nil {
+				return nil, err
+			}
+			output, err := g.call(rt, input)
+			if err != nil {
+				return nil, err
+			}
+			var p executorProposal
+			if err := getter.DecodeOutput(output, &p); err != nil {
+				return nil, err
+			}
+			proposal := convertProposal(pid, &p, h.Timestamp())
+			proposal.Proposed = convertLogMeta(e)
+			byID[pid] = proposal
+			proposals = append(proposals, proposal)
+		case "approved":
+			if p, ok := byID[pid]; ok {
+				p.Approvals = append(p.Approvals, &Approval{Approver: e.TxOrigin, Meta: *convertLogMeta(e)})
+			}
+		case "executed":
+			if p, ok := byID[pid]; ok {
+				p.Execution = convertLogMeta(e)
+			}
+		}
+	}
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return proposals[i].TimeProposed > proposals[j].TimeProposed
+	})
+	return proposals, nil
+}
+
+func (g *Governance) newRuntime(h *block.Header) (*runtime.Runtime, error) {
+	state, err := g.stateCreator.NewState(h.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	signer, _ := h.Signer()
+	return runtime.New(g.chain.NewSeeker(h.ParentID()), state,
+		&xenv.BlockContext{
+			Beneficiary: h.Beneficiary(),
+			Signer:      signer,
+			Number:      h.Number(),
+			Time:        h.Timestamp(),
+			GasLimit:    h.GasLimit(),
+			TotalScore:  h.TotalScore()}), nil
+}
+
+func (g *Governance) call(rt *runtime.Runtime, input []byte) ([]byte, error) {
+	clause := tx.NewClause(&builtin.Executor.Address).WithData(input)
+	out := rt.ExecuteClause(clause, 0, callGas, &xenv.TransactionContext{
+		GasPrice:   &big.Int{},
+		ProvedWork: &big.Int{}})
+	if out.VMErr != nil {
+		return nil, errors.WithMessage(out.VMErr, "call executor")
+	}
+	return out.Data, nil
+}
+
+func (g *Governance) handleGetParamsHistory(w http.ResponseWriter, req *http.Request) error {
+	var key *meter.Bytes32
+	if v := req.URL.Query().Get("key"); v != "" {
+		k, err := meter.ParseParamKey(v)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "key"))
+		}
+		key = &k
+	}
+	events, err := FilterParamChanges(req, g.logDB, key)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertParamChanges(events))
+}
+
+// FilterParamChanges queries Set events of params contract, optionally of the given key.
+// The block range and paging are taken from query parameters from, to, offset, limit and order.
+func FilterParamChanges(req *http.Request, logDB *logdb.LogDB, key *meter.Bytes32) ([]*logdb.Event, error) {
+	query := req.URL.Query()
+	rng := &logdb.Range{Unit: logdb.Block, From: 0, To: math.MaxUint32}
+	if v := query.Get("from"); v != "" {
+		from, err := strconv.ParseUint(v, 0, 32)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "from"))
+		}
+		rng.From = from
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := strconv.ParseUint(v, 0, 32)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "to"))
+		}
+		rng.To = to
+	}
+	options, order, err := parseOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ev, _ := builtin.Params.ABI.EventByName("Set")
+	evID := ev.ID()
+	criteria := &logdb.EventCriteria{Address: &builtin.Params.Address}
+	criteria.Topics[0] = &evID
+	criteria.Topics[1] = key
+	return logDB.FilterEvents(req.Context(), &logdb.EventFilter{
+		CriteriaSet: []*logdb.EventCriteria{criteria},
+		Range:       rng,
+		Options:     options,
+		Order:       order,
+	})
+}
+
+func parseOptions(req *http.Request) (*logdb.Options, logdb.Order, error) {
+	query := req.URL.Query()
+	order := logdb.ASC
+	if o := query.Get("order"); o != "" {
+		order = logdb.Order(o)
+		if order != logdb.ASC && order != logdb.DESC {
+			return nil, order, utils.BadRequest(errors.New("order: should be asc or desc"))
+		}
+	}
+	if query.Get("offset") == "" && query.Get("limit") == "" {
+		return nil, order, nil
+	}
+	options := &logdb.Options{Offset: 0, Limit: math.MaxInt64}
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.ParseUint(v, 0, 0)
+		if err != nil {
+			return nil, order, utils.BadRequest(errors.WithMessage(err, "offset"))
+		}
+		options.Offset = offset
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 0, 0)
+		if err != nil {
+			return nil, order, utils.BadRequest(errors.WithMessage(err, "limit"))
+		}
+		options.Limit = limit
+	}
+	return options, order, nil
+}
+
+func (g *Governance) handleRevision(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return g.chain.BestBlock().Header(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		blockID, err := meter.ParseBytes32(revision)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		h, err := g.chain.GetBlockHeader(blockID)
+		if err != nil {
+			if g.chain.IsNotFound(err) {
+				return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+			}
+			return nil, err
+		}
+		return h, nil
+	}
+	n, err := strconv.ParseUint(revision, 0, 0)
+	if err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	if n > math.MaxUint32 {
+		return nil, utils.BadRequest(errors.WithMessage(errors.New("block number out of max uint32"), "revision"))
+	}
+	h, err := g.chain.GetTrunkBlockHeader(uint32(n))
+	if err != nil {
+		if g.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
+func (g *Governance) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("/proposals").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(g.handleGetProposals))
+	sub.Path("/proposals/{id}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(g.handleGetProposalByID))
+	sub.Path("/params/history").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(g.handleGetParamsHistory))
+}
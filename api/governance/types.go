@@ -0,0 +1,219 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package governance
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/dfinlab/meter/api/transactions"
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const (
+	// proposals can't be approved or executed after 1 week, see executor.sol
+	proposalLifetime = uint64(7 * 24 * 3600)
+
+	StatusProposed = "proposed"
+	StatusApproved = "approved"
+	StatusExecuted = "executed"
+	StatusExpired  = "expired"
+)
+
+type DecodedCall struct {
+	Contract string                `json:"contract"`
+	Method   string                `json:"method"`
+	Key      *meter.Bytes32        `json:"key,omitempty"`
+	KeyName  string                `json:"keyName,omitempty"`
+	Value    *math.HexOrDecimal256 `json:"value,omitempty"`
+	Address  *meter.Address        `json:"address,omitempty"`
+	Identity *meter.Bytes32        `json:"identity,omitempty"`
+}
+
+type Approval struct {
+	Approver meter.Address        `json:"approver"`
+	Meta     transactions.LogMeta `json:"meta"`
+}
+
+type Proposal struct {
+	ID            meter.Bytes32         `json:"id"`
+	Status        string                `json:"status"`
+	Proposer      meter.Address         `json:"proposer"`
+	TimeProposed  uint64                `json:"timeProposed"`
+	ExpireTime    uint64                `json:"expireTime"`
+	Quorum        uint8                 `json:"quorum"`
+	ApprovalCount uint8                 `json:"approvalCount"`
+	Executed      bool                  `json:"executed"`
+	Target        meter.Address         `json:"target"`
+	Data          string                `json:"data"`
+	Call          *DecodedCall          `json:"call"`
+	Approvals     []*Approval           `json:"approvals"`
+	Proposed      *transactions.LogMeta `json:"proposed"`
+	Execution     *transactions.LogMeta `json:"execution"`
+}
+
+type ParamChange struct {
+	Key     meter.Bytes32         `json:"key"`
+	KeyName string                `json:"keyName"`
+	Value   *math.HexOrDecimal256 `json:"value"`
+	TxID    meter.Bytes32         `json:"txID"`
+}
+
+type BlockParamChanges struct {
+	BlockID        meter.Bytes32  `json:"blockID"`
+	BlockNumber    uint32         `json:"blockNumber"`
+	BlockTimestamp uint64         `json:"blockTimestamp"`
+	Changes        []*ParamChange `json:"changes"`
+}
+
+// executorProposal is the output of the public getter proposals(bytes32) of executor.
+type executorProposal struct {
+	TimeProposed  uint64
+	Proposer      common.Address
+	Quorum        uint8
+	ApprovalCount uint8
+	Executed      bool
+	Target        common.Address
+	Data          []byte
+}
+
+func convertLogMeta(ev *logdb.Event) *transactions.LogMeta {
+	return &transactions.LogMeta{
+		BlockID:        ev.BlockID,
+		BlockNumber:    ev.BlockNumber,
+		BlockTimestamp: ev.BlockTime,
+		TxID:           ev.TxID,
+		TxOrigin:       ev.TxOrigin,
+	}
+}
+
+// eventAction decodes the bytes32 action carried by executor events.
+func eventAction(data []byte) string {
+	if len(data) < 32 {
+		return ""
+	}
+	return string(bytes.TrimRight(data[:32], "\x00"))
+}
+
+func proposalStatus(p *executorProposal, now uint64) string {
+	switch {
+	case p.Executed:
+		return StatusExecuted
+	case now-p.TimeProposed >= proposalLifetime:
+		return StatusExpired
+	case p.ApprovalCount >= p.Quorum:
+		return StatusApproved
+	default:
+		return StatusProposed
+	}
+}
+
+// decodeCall decodes the call data of a proposal against the ABI of its target,
+// returns nil if the target is not a known builtin contract.
+func decodeCall(target meter.Address, data []byte) *DecodedCall {
+	switch target {
+	case builtin.Params.Address:
+		method, err := builtin.Params.ABI.MethodByInput(data)
+		if err != nil {
+			return nil
+		}
+		call := &DecodedCall{Contract: "Params", Method: method.Name()}
+		if method.Name() == "set" {
+			var args struct {
+				Key   common.Hash
+				Value *big.Int
+			}
+			if err := method.DecodeInput(data, &args); err != nil {
+				return nil
+			}
+			key := meter.Bytes32(args.Key)
+			call.Key = &key
+			call.KeyName = meter.ParamKeyName(key)
+			call.Value = (*math.HexOrDecimal256)(args.Value)
+		}
+		return call
+	case builtin.Executor.Address:
+		method, err := builtin.Executor.ABI.MethodByInput(data)
+		if err != nil {
+			return nil
+		}
+		call := &DecodedCall{Contract: "Executor", Method: method.Name()}
+		switch method.Name() {
+		case "addApprover":
+			var args struct {
+				Approver common.Address
+				Identity common.Hash
+			}
+			if err := method.DecodeInput(data, &args); err != nil {
+				return nil
+			}
+			addr := meter.Address(args.Approver)
+			identity := meter.Bytes32(args.Identity)
+			call.Address = &addr
+			call.Identity = &identity
+		case "revokeApprover", "attachVotingContract", "detachVotingContract":
+			var arg common.Address
+			if err := method.DecodeInput(data, &arg); err != nil {
+				return nil
+			}
+			addr := meter.Address(arg)
+			call.Address = &addr
+		}
+		return call
+	}
+	return nil
+}
+
+func convertProposal(id meter.Bytes32, p *executorProposal, now uint64) *Proposal {
+	target := meter.Address(p.Target)
+	return &Proposal{
+		ID:            id,
+		Status:        proposalStatus(p, now),
+		Proposer:      meter.Address(p.Proposer),
+		TimeProposed:  p.TimeProposed,
+		ExpireTime:    p.TimeProposed + proposalLifetime,
+		Quorum:        p.Quorum,
+		ApprovalCount: p.ApprovalCount,
+		Executed:      p.Executed,
+		Target:        target,
+		Data:          hexutil.Encode(p.Data),
+		Call:          decodeCall(target, p.Data),
+		Approvals:     make([]*Approval, 0),
+	}
+}
+
+// convertParamChanges groups Set events of params contract by block.
+func convertParamChanges(events []*logdb.Event) []*BlockParamChanges {
+	changes := make([]*BlockParamChanges, 0)
+	var last *BlockParamChanges
+	for _, ev := range events {
+		if ev.Topics[1] == nil {
+			continue
+		}
+		if last == nil || last.BlockID != ev.BlockID {
+			last = &BlockParamChanges{
+				BlockID:        ev.BlockID,
+				BlockNumber:    ev.BlockNumber,
+				BlockTimestamp: ev.BlockTime,
+				Changes:        make([]*ParamChange, 0),
+			}
+			changes = append(changes, last)
+		}
+		key := *ev.Topics[1]
+		last.Changes = append(last.Changes, &ParamChange{
+			Key:     key,
+			KeyName: meter.ParamKeyName(key),
+			Value:   (*math.HexOrDecimal256)(new(big.Int).SetBytes(ev.Data)),
+			TxID:    ev.TxID,
+		})
+	}
+	return changes
+}
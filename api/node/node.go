@@ -8,6 +8,7 @@ package node
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/dfinlab/meter/api/utils"
 	"github.com/dfinlab/meter/consensus"
@@ -49,6 +50,26 @@ func (n *Node) handleCommittee(w http.ResponseWriter, req *http.Request) error {
 	return utils.WriteJSON(w, committeeList)
 }
 
+func (n *Node) handleCommitteeAudit(w http.ResponseWriter, req *http.Request) error {
+	epoch, err := strconv.ParseUint(mux.Vars(req)["epoch"], 0, 64)
+	if err != nil {
+		return utils.BadRequest(errors.New("epoch: invalid value"))
+	}
+	consensusInst := consensus.GetConsensusGlobInst()
+	if consensusInst == nil {
+		return errors.New("consensus is not initialized...")
+	}
+
+	audit, err := consensusInst.AuditCommittee(epoch)
+	if err != nil {
+		if consensus.IsCommitteeNotFound(err) {
+			return utils.BadRequest(err)
+		}
+		return err
+	}
+	return utils.WriteJSON(w, convertCommitteeAudit(audit))
+}
+
 func (n *Node) handlePubKey(w http.ResponseWriter, req *http.Request) error {
 	w.WriteHeader(http.StatusOK)
 	utils.WriteJSON(w, n.pubKey)
@@ -69,6 +90,7 @@ func (n *Node) Mount(root *mux.Router, pathPrefix string) {
 
 	sub.Path("/network/peers").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(n.handleNetwork))
 	sub.Path("/consensus/committee").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(n.handleCommittee))
+	sub.Path("/consensus/committee/{epoch}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(n.handleCommitteeAudit))
 	sub.Path("/pubkey").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(n.handlePubKey))
 	sub.Path("/coef").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(n.handleCoef))
 }
@@ -6,13 +6,19 @@
 package node
 
 import (
+	"time"
+
 	"github.com/dfinlab/meter/comm"
 	"github.com/dfinlab/meter/consensus"
 	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/p2p/discover"
 )
 
 type Network interface {
 	PeersStats() []*comm.PeerStats
+	BannedPeers() []*comm.BannedPeer
+	BanPeer(id discover.NodeID, duration time.Duration, reason string) error
+	UnbanPeer(id discover.NodeID) (bool, error)
 }
 
 type PeerStats struct {
@@ -23,6 +29,28 @@ type PeerStats struct {
 	NetAddr     string        `json:"netAddr"`
 	Inbound     bool          `json:"inbound"`
 	Duration    uint64        `json:"duration"`
+	Score       *PeerScore    `json:"score"`
+}
+
+type PeerScore struct {
+	Score         int    `json:"score"`
+	InvalidBlocks uint64 `json:"invalidBlocks"`
+	BadTxs        uint64 `json:"badTxs"`
+	Timeouts      uint64 `json:"timeouts"`
+	UsefulData    uint64 `json:"usefulData"`
+}
+
+func ConvertPeerScore(s *comm.PeerScore) *PeerScore {
+	if s == nil {
+		return nil
+	}
+	return &PeerScore{
+		Score:         s.Score,
+		InvalidBlocks: s.InvalidBlocks,
+		BadTxs:        s.BadTxs,
+		Timeouts:      s.Timeouts,
+		UsefulData:    s.UsefulData,
+	}
 }
 
 func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
@@ -39,6 +67,7 @@ func ConvertPeersStats(ss []*comm.PeerStats) []*PeerStats {
 			NetAddr:     peerStats.NetAddr,
 			Inbound:     peerStats.Inbound,
 			Duration:    peerStats.Duration,
+			Score:       ConvertPeerScore(peerStats.Score),
 		}
 	}
 	return peersStats
@@ -76,3 +105,31 @@ func convertCommitteeList(cml []*consensus.ApiCommitteeMember) []*ApiCommitteeMe
 	}
 	return committeeList
 }
+
+type ApiCommitteeAudit struct {
+	Epoch          uint64                `json:"epoch"`
+	KBlockHeight   uint32                `json:"kblockHeight"`
+	KBlockID       meter.Bytes32         `json:"kblockID"`
+	Nonce          uint64                `json:"nonce"`
+	DelegateSource string                `json:"delegateSource"`
+	Delegates      []*ApiCommitteeMember `json:"delegates"`
+	Committee      []*ApiCommitteeMember `json:"committee"`
+	LeaderOrder    []int                 `json:"leaderOrder"`
+	Verified       bool                  `json:"verified"`
+	Mismatches     []string              `json:"mismatches"`
+}
+
+func convertCommitteeAudit(a *consensus.ApiCommitteeAudit) *ApiCommitteeAudit {
+	return &ApiCommitteeAudit{
+		Epoch:          a.Epoch,
+		KBlockHeight:   a.KBlockHeight,
+		KBlockID:       a.KBlockID,
+		Nonce:          a.Nonce,
+		DelegateSource: a.DelegateSource,
+		Delegates:      convertCommitteeList(a.Delegates),
+		Committee:      convertCommitteeList(a.Committee),
+		LeaderOrder:    a.LeaderOrder,
+		Verified:       a.Verified,
+		Mismatches:     a.Mismatches,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package params
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/dfinlab/meter/api/governance"
+	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/state"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+type Params struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB) *Params {
+	return &Params{
+		chain,
+		stateCreator,
+		logDB,
+	}
+}
+
+func (p *Params) handleGetParams(w http.ResponseWriter, req *http.Request) error {
+	h, err := p.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	state, err := p.stateCreator.NewState(h.StateRoot())
+	if err != nil {
+		return err
+	}
+	native := builtin.Params.Native(state)
+	params := make([]*Param, 0, len(meter.ParamKeys))
+	for _, k := range meter.ParamKeys {
+		params = append(params, convertParam(k.Key, native.Get(k.Key)))
+	}
+	if err := state.Err(); err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, params)
+}
+
+func (p *Params) handleGetParam(w http.ResponseWriter, req *http.Request) error {
+	key, err := meter.ParseParamKey(mux.Vars(req)["key"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "key"))
+	}
+	h, err := p.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	state, err := p.stateCreator.NewState(h.StateRoot())
+	if err != nil {
+		return err
+	}
+	value := builtin.Params.Native(state).Get(key)
+	if err := state.Err(); err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertParam(key, value))
+}
+
+// handleGetParamHistory returns the genesis value of a param and its changes made
+// through the params contract. Values set natively (e.g. by a fork) are not tracked.
+func (p *Params) handleGetParamHistory(w http.ResponseWriter, req *http.Request) error {
+	key, err := meter.ParseParamKey(mux.Vars(req)["key"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "key"))
+	}
+	state, err := p.stateCreator.NewState(p.chain.GenesisBlock().Header().StateRoot())
+	if err != nil {
+		return err
+	}
+	genesis := builtin.Params.Native(state).Get(key)
+	if err := state.Err(); err != nil {
+		return err
+	}
+	events, err := governance.FilterParamChanges(req, p.logDB, &key)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertParamHistory(key, genesis, events))
+}
+
+func (p *Params) handleRevision(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return p.chain.BestBlock().Header(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		blockID, err := meter.ParseBytes32(revision)
+		if err != nil {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		h, err := p.chain.GetBlockHeader(blockID)
+		if err != nil {
+			if p.chain.IsNotFound(err) {
+				return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+			}
+			return nil, err
+		}
+		return h, nil
+	}
+	n, err := strconv.ParseUint(revision, 0, 0)
+	if err != nil {
+		return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+	}
+	if n > math.MaxUint32 {
+		return nil, utils.BadRequest(errors.WithMessage(errors.New("block number out of max uint32"), "revision"))
+	}
+	h, err := p.chain.GetTrunkBlockHeader(uint32(n))
+	if err != nil {
+		if p.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.WithMessage(err, "revision"))
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
+func (p *Params) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Path("").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(p.handleGetParams))
+	sub.Path("/{key}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(p.handleGetParam))
+	sub.Path("/{key}/history").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(p.handleGetParamHistory))
+}
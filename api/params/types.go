@@ -0,0 +1,63 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package params
+
+import (
+	"math/big"
+
+	"github.com/dfinlab/meter/api/transactions"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+type Param struct {
+	Name  string                `json:"name"`
+	Key   meter.Bytes32         `json:"key"`
+	Value *math.HexOrDecimal256 `json:"value"`
+}
+
+type ParamChange struct {
+	Value *math.HexOrDecimal256 `json:"value"`
+	Meta  transactions.LogMeta  `json:"meta"`
+}
+
+type ParamHistory struct {
+	Name    string                `json:"name"`
+	Key     meter.Bytes32         `json:"key"`
+	Genesis *math.HexOrDecimal256 `json:"genesis"`
+	Changes []*ParamChange        `json:"changes"`
+}
+
+func convertParam(key meter.Bytes32, value *big.Int) *Param {
+	return &Param{
+		Name:  meter.ParamKeyName(key),
+		Key:   key,
+		Value: (*math.HexOrDecimal256)(value),
+	}
+}
+
+func convertParamHistory(key meter.Bytes32, genesis *big.Int, events []*logdb.Event) *ParamHistory {
+	history := &ParamHistory{
+		Name:    meter.ParamKeyName(key),
+		Key:     key,
+		Genesis: (*math.HexOrDecimal256)(genesis),
+		Changes: make([]*ParamChange, 0, len(events)),
+	}
+	for _, ev := range events {
+		history.Changes = append(history.Changes, &ParamChange{
+			Value: (*math.HexOrDecimal256)(new(big.Int).SetBytes(ev.Data)),
+			Meta: transactions.LogMeta{
+				BlockID:        ev.BlockID,
+				BlockNumber:    ev.BlockNumber,
+				BlockTimestamp: ev.BlockTime,
+				TxID:           ev.TxID,
+				TxOrigin:       ev.TxOrigin,
+			},
+		})
+	}
+	return history
+}
@@ -6,34 +6,115 @@
 package peers
 
 import (
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/dfinlab/meter/api/node"
 	"github.com/dfinlab/meter/api/utils"
 	"github.com/dfinlab/meter/p2psrv"
+	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 )
 
+const defaultBanDuration = uint64(3600) // in seconds
+
 type Peers struct {
 	p2pServer *p2psrv.Server
+	nw        node.Network
 }
 
-func New(p2pServer *p2psrv.Server) *Peers {
+func New(p2pServer *p2psrv.Server, nw node.Network) *Peers {
 	return &Peers{
 		p2pServer,
+		nw,
 	}
 }
 
 func (p *Peers) handleGetPeers(w http.ResponseWriter, req *http.Request) error {
+	stats := make(map[string]*node.PeerStats)
+	for _, s := range node.ConvertPeersStats(p.nw.PeersStats()) {
+		stats[s.PeerID] = s
+	}
 	nodes := p.p2pServer.GetDiscoveredNodes()
 	result := make([]*Peer, 0)
 	for _, n := range nodes {
 		peer := convertNode(n)
+		if s, ok := stats[peer.EnodeID]; ok {
+			peer.Connected = true
+			peer.Score = s.Score
+		}
 		result = append(result, peer)
 	}
 	return utils.WriteJSON(w, result)
 }
 
+func (p *Peers) handleGetBanned(w http.ResponseWriter, req *http.Request) error {
+	banned := p.nw.BannedPeers()
+	result := make([]*BannedPeer, 0, len(banned))
+	for _, b := range banned {
+		result = append(result, convertBannedPeer(b))
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (p *Peers) handleBan(w http.ResponseWriter, req *http.Request) error {
+	if err := checkLocal(req); err != nil {
+		return err
+	}
+	var ban BanRequest
+	if err := utils.ParseJSON(req.Body, &ban); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	id, err := discover.HexID(ban.NodeID)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "nodeID"))
+	}
+	duration := ban.Duration
+	if duration == 0 {
+		duration = defaultBanDuration
+	}
+	if err := p.nw.BanPeer(id, time.Duration(duration)*time.Second, ban.Reason); err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, map[string]interface{}{"banned": true})
+}
+
+func (p *Peers) handleUnban(w http.ResponseWriter, req *http.Request) error {
+	if err := checkLocal(req); err != nil {
+		return err
+	}
+	id, err := discover.HexID(mux.Vars(req)["id"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "id"))
+	}
+	ok, err := p.nw.UnbanPeer(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return utils.HTTPError(errors.New("peer not banned"), http.StatusNotFound)
+	}
+	return utils.WriteJSON(w, map[string]interface{}{"banned": false})
+}
+
+// checkLocal only allows requests from loopback addresses, for admin endpoints.
+func checkLocal(req *http.Request) error {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return utils.Forbidden(errors.New("admin endpoint is only available from localhost"))
+	}
+	return nil
+}
+
 func (b *Peers) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 	sub.Path("").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(b.handleGetPeers))
+	sub.Path("/banned").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(b.handleGetBanned))
+	sub.Path("/banned").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(b.handleBan))
+	sub.Path("/banned/{id}").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(b.handleUnban))
 }
@@ -6,14 +6,30 @@
 package peers
 
 import (
+	"github.com/dfinlab/meter/api/node"
+	"github.com/dfinlab/meter/comm"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 )
 
 //Block block
 type Peer struct {
-	EnodeID string `json:"enodeID"`
-	IP      string `json:"ip"`
-	Port    uint32 `json:"port"`
+	EnodeID   string          `json:"enodeID"`
+	IP        string          `json:"ip"`
+	Port      uint32          `json:"port"`
+	Connected bool            `json:"connected"`
+	Score     *node.PeerScore `json:"score"`
+}
+
+type BannedPeer struct {
+	NodeID string `json:"nodeID"`
+	Until  uint64 `json:"until"`
+	Reason string `json:"reason"`
+}
+
+type BanRequest struct {
+	NodeID   string `json:"nodeID"`
+	Duration uint64 `json:"duration"` // in seconds
+	Reason   string `json:"reason"`
 }
 
 func convertNode(n *discover.Node) *Peer {
@@ -22,3 +38,11 @@ func convertNode(n *discover.Node) *Peer {
 		IP:      n.IP.String(),
 	}
 }
+
+func convertBannedPeer(b *comm.BannedPeer) *BannedPeer {
+	return &BannedPeer{
+		NodeID: b.ID.String(),
+		Until:  b.Until,
+		Reason: b.Reason,
+	}
+}
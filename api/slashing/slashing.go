@@ -9,15 +9,23 @@ import (
 	"net/http"
 
 	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/state"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 )
 
 type Slashing struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
 }
 
-func New() *Slashing {
-	return &Slashing{}
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB) *Slashing {
+	return &Slashing{chain: chain, stateCreator: stateCreator, logDB: logDB}
 }
 
 func (sl *Slashing) handleGetDelegateJailedList(w http.ResponseWriter, req *http.Request) error {
@@ -38,9 +46,75 @@ func (sl *Slashing) handleGetDelegateStatsList(w http.ResponseWriter, req *http.
 	return utils.WriteJSON(w, statsList)
 }
 
+// handleGetDelegateStatsByAddress returns the infractions of the delegate grouped by epoch, with the epochs
+// their points are halved and wiped out, the jail history, and whether the delegate can exit jail now.
+func (sl *Slashing) handleGetDelegateStatsByAddress(w http.ResponseWriter, req *http.Request) error {
+	addr, err := meter.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+
+	best := sl.chain.BestBlock()
+	state, err := sl.stateCreator.NewState(best.Header().StateRoot())
+	if err != nil {
+		return err
+	}
+	stakingInst := staking.GetStakingGlobInst()
+	if stakingInst == nil {
+		return errors.New("staking is not initialized...")
+	}
+	params := staking.GetSlashingParams(state)
+	jailed := stakingInst.GetInJailList(state).Get(addr)
+
+	detail := &DelegateStatisticsDetail{
+		Address:         addr,
+		Epoch:           stakingInst.GetStatisticsEpoch(state),
+		PhaseOutStopped: jailed != nil,
+		Infractions:     make([]*EpochInfraction, 0),
+		ExitJail: &ExitJail{
+			InJail:     jailed != nil,
+			BailAmount: "0",
+			Balance:    state.GetBalance(addr).String(),
+		},
+	}
+	if stats := stakingInst.GetStatisticsList(state).Get(addr); stats != nil {
+		detail.Name = string(stats.Name)
+		detail.PubKey = string(stats.PubKey)
+		detail.TotalPoints = stats.TotalPts
+		detail.Infractions = convertEpochInfractions(&stats.Infractions, params)
+	} else if jailed != nil {
+		detail.Name = string(jailed.Name)
+		detail.PubKey = string(jailed.PubKey)
+		detail.TotalPoints = jailed.TotalPts
+		detail.Infractions = convertEpochInfractions(&jailed.Infractions, params)
+	}
+	if jailed != nil {
+		// same check as the exit jail handler
+		detail.ExitJail.BailAmount = jailed.BailAmount.String()
+		detail.ExitJail.Eligible = state.GetBalance(addr).Cmp(jailed.BailAmount) >= 0
+	}
+
+	records, err := sl.logDB.FilterJailRecords(req.Context(), &logdb.JailFilter{Address: &addr, Order: logdb.DESC})
+	if err != nil {
+		return err
+	}
+	detail.JailHistory = convertJailRecords(records)
+	return utils.WriteJSON(w, detail)
+}
+
+func (sl *Slashing) handleGetSlashingParams(w http.ResponseWriter, req *http.Request) error {
+	params, err := staking.GetLatestSlashingParams()
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertSlashingParams(params))
+}
+
 func (sl *Slashing) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 	sub.Path("/injail").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(sl.handleGetDelegateJailedList))
 	sub.Path("/statistics").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(sl.handleGetDelegateStatsList))
+	sub.Path("/statistics/{address}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(sl.handleGetDelegateStatsByAddress))
+	sub.Path("/params").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(sl.handleGetSlashingParams))
 
 }
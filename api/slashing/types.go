@@ -8,6 +8,7 @@ package slashing
 import (
 	"sort"
 
+	"github.com/dfinlab/meter/logdb"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/script/staking"
 )
@@ -67,7 +68,7 @@ type Infraction struct {
 	MissingLeader   MissingLeader   `json:"missingLeader"`
 	MissingProposer MissingProposer `json:"missingProposer"`
 	MissingVoter    MissingVoter    `json:"missingVoter"`
-	DoubleSigner    DoubleSigner    `json:"doubleSigner`
+	DoubleSigner    DoubleSigner    `json:"doubleSigner"`
 }
 type DelegateStatistics struct {
 	Address     meter.Address `json:"address"`
@@ -77,6 +78,87 @@ type DelegateStatistics struct {
 	Infractions Infraction    `json:"infractions"`
 }
 
+// EpochInfraction is the infractions in one epoch. Their points are halved from PhaseOutEpoch and
+// no longer count from WipeOutEpoch, as long as the delegate is not in jail.
+type EpochInfraction struct {
+	Epoch           uint32                 `json:"epoch"`
+	PhaseOutEpoch   uint32                 `json:"phaseOutEpoch"`
+	WipeOutEpoch    uint32                 `json:"wipeOutEpoch"`
+	MissingLeader   []*MissingLeaderInfo   `json:"missingLeader"`
+	MissingProposer []*MissingProposerInfo `json:"missingProposer"`
+	MissingVoter    []*MissingVoterInfo    `json:"missingVoter"`
+	DoubleSigner    []*DoubleSignerInfo    `json:"doubleSigner"`
+}
+
+type JailRecord struct {
+	BlockID     meter.Bytes32 `json:"blockID"`
+	BlockNumber uint32        `json:"blockNumber"`
+	BlockTime   uint64        `json:"blockTime"`
+	Action      string        `json:"action"`
+	TotalPoints uint64        `json:"totalPoints"`
+	BailAmount  string        `json:"bailAmount"`
+	JailedTime  uint64        `json:"jailedTime"`
+}
+
+type ExitJail struct {
+	InJail     bool   `json:"inJail"`
+	Eligible   bool   `json:"eligible"`
+	BailAmount string `json:"bailAmount"`
+	Balance    string `json:"balance"`
+}
+
+type DelegateStatisticsDetail struct {
+	Address         meter.Address      `json:"address"`
+	Name            string             `json:"name"`
+	PubKey          string             `json:"pubKey"`
+	TotalPoints     uint64             `json:"totalPoints"`
+	Epoch           uint32             `json:"epoch"` // the last epoch of phase out
+	PhaseOutStopped bool               `json:"phaseOutStopped"`
+	Infractions     []*EpochInfraction `json:"infractions"`
+	JailHistory     []*JailRecord      `json:"jailHistory"`
+	ExitJail        *ExitJail          `json:"exitJail"`
+}
+
+type SlashingParams struct {
+	DoubleSignPoints             uint64 `json:"doubleSignPoints"`
+	MissingLeaderPoints          uint64 `json:"missingLeaderPoints"`
+	MissingProposerPoints        uint64 `json:"missingProposerPoints"`
+	MissingVoterPoints           uint64 `json:"missingVoterPoints"`
+	PhaseOutEpochCount           uint32 `json:"phaseOutEpochCount"`
+	WipeOutEpochCount            uint32 `json:"wipeOutEpochCount"`
+	ObservationEpochCount        uint32 `json:"observationEpochCount"`
+	MaxMissingProposerPerEpoch   int    `json:"maxMissingProposerPerEpoch"`
+	MaxMissingLeaderPerEpoch     int    `json:"maxMissingLeaderPerEpoch"`
+	MaxDoubleSignPerEpoch        int    `json:"maxDoubleSignPerEpoch"`
+	JailMissingProposerViolation int    `json:"jailMissingProposerViolation"`
+	JailMissingLeaderViolation   int    `json:"jailMissingLeaderViolation"`
+	JailDoubleSignViolation      int    `json:"jailDoubleSignViolation"`
+	BailForExitJail              string `json:"bailForExitJail"`
+	DoubleSignSlashRatio         string `json:"doubleSignSlashRatio"`
+	DoubleSignReporterRatio      string `json:"doubleSignReporterRatio"`
+}
+
+func convertSlashingParams(p *staking.SlashingParams) *SlashingParams {
+	return &SlashingParams{
+		DoubleSignPoints:             p.DoubleSignPts,
+		MissingLeaderPoints:          p.MissingLeaderPts,
+		MissingProposerPoints:        p.MissingProposerPts,
+		MissingVoterPoints:           p.MissingVoterPts,
+		PhaseOutEpochCount:           p.PhaseOutEpochCount,
+		WipeOutEpochCount:            p.WipeOutEpochCount(),
+		ObservationEpochCount:        p.NObservationEpochs,
+		MaxMissingProposerPerEpoch:   p.MaxMissingProposerPerEpoch,
+		MaxMissingLeaderPerEpoch:     p.MaxMissingLeaderPerEpoch,
+		MaxDoubleSignPerEpoch:        p.MaxDoubleSignPerEpoch,
+		JailMissingProposerViolation: p.JailMissingProposerViolation,
+		JailMissingLeaderViolation:   p.JailMissingLeaderViolation,
+		JailDoubleSignViolation:      p.JailDoubleSignViolation,
+		BailForExitJail:              p.BailForExitJail.String(),
+		DoubleSignSlashRatio:         p.DoubleSignSlashRatio.String(),
+		DoubleSignReporterRatio:      p.DoubleSignReporterRatio.String(),
+	}
+}
+
 func convertJailedList(list *staking.DelegateInJailList) []*DelegateJailed {
 	jailedList := make([]*DelegateJailed, 0)
 	for _, j := range list.ToList() {
@@ -181,3 +263,69 @@ func convertDelegateStatistics(d *staking.DelegateStatistics) *DelegateStatistic
 		Infractions: infs,
 	}
 }
+
+// convertEpochInfractions groups the infractions by epoch, in ascending order.
+func convertEpochInfractions(inf *staking.Infraction, params *staking.SlashingParams) []*EpochInfraction {
+	epochs := make(map[uint32]*EpochInfraction)
+	get := func(epoch uint32) *EpochInfraction {
+		e, ok := epochs[epoch]
+		if !ok {
+			e = &EpochInfraction{
+				Epoch:           epoch,
+				PhaseOutEpoch:   epoch + params.PhaseOutEpochCount + 1,
+				WipeOutEpoch:    epoch + params.WipeOutEpochCount() + 1,
+				MissingLeader:   make([]*MissingLeaderInfo, 0),
+				MissingProposer: make([]*MissingProposerInfo, 0),
+				MissingVoter:    make([]*MissingVoterInfo, 0),
+				DoubleSigner:    make([]*DoubleSignerInfo, 0),
+			}
+			epochs[epoch] = e
+		}
+		return e
+	}
+	for _, m := range convertMissingLeaderInfo(inf.MissingLeaders.Info) {
+		e := get(m.Epoch)
+		e.MissingLeader = append(e.MissingLeader, m)
+	}
+	for _, m := range convertMissingProposerInfo(inf.MissingProposers.Info) {
+		e := get(m.Epoch)
+		e.MissingProposer = append(e.MissingProposer, m)
+	}
+	for _, m := range convertMissingVoterInfo(inf.MissingVoters.Info) {
+		e := get(m.Epoch)
+		e.MissingVoter = append(e.MissingVoter, m)
+	}
+	for _, m := range convertDoubleSignerInfo(inf.DoubleSigners.Info) {
+		e := get(m.Epoch)
+		e.DoubleSigner = append(e.DoubleSigner, m)
+	}
+
+	result := make([]*EpochInfraction, 0, len(epochs))
+	for _, e := range epochs {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Epoch < result[j].Epoch
+	})
+	return result
+}
+
+func convertJailRecords(records []*logdb.JailRecord) []*JailRecord {
+	result := make([]*JailRecord, 0, len(records))
+	for _, r := range records {
+		action := "jail"
+		if r.Action == logdb.JailOut {
+			action = "exit"
+		}
+		result = append(result, &JailRecord{
+			BlockID:     r.BlockID,
+			BlockNumber: r.BlockNumber,
+			BlockTime:   r.BlockTime,
+			Action:      action,
+			TotalPoints: r.TotalPoints,
+			BailAmount:  r.BailAmount.String(),
+			JailedTime:  r.JailedTime,
+		})
+	}
+	return result
+}
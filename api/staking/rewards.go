@@ -0,0 +1,300 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package staking
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	b64 "encoding/base64"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/reward"
+	"github.com/dfinlab/meter/script"
+	"github.com/dfinlab/meter/script/auction"
+	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+const maxRewardHistoryEpochs = 1024
+
+// EpochReward is the reward received by an address in one epoch, over all its buckets.
+type EpochReward struct {
+	Epoch       uint32        `json:"epoch"`
+	BlockNumber uint32        `json:"blockNumber"`
+	BlockID     meter.Bytes32 `json:"blockID"`
+	Timestamp   uint64        `json:"timestamp"`
+	Distributed string        `json:"distributed"`
+	Autobid     string        `json:"autobid"`
+	Total       string        `json:"total"`
+}
+
+type RewardHistory struct {
+	Address     meter.Address  `json:"address"`
+	Distributed string         `json:"distributed"`
+	Autobid     string         `json:"autobid"`
+	Total       string         `json:"total"`
+	Rewards     []*EpochReward `json:"rewards"`
+}
+
+type RewardEstimate struct {
+	Candidate        meter.Address `json:"candidate"`
+	Amount           string        `json:"amount"`
+	Option           uint32        `json:"option"`
+	BonusRate        uint8         `json:"bonusRate"`
+	BonusVotes       string        `json:"bonusVotes"`
+	EpochBaseReward  string        `json:"epochBaseReward"`
+	EpochTotalReward string        `json:"epochTotalReward"`
+	EpochReward      string        `json:"epochReward"`
+	DailyReward      string        `json:"dailyReward"`
+	AnnualReward     string        `json:"annualReward"`
+	AnnualYield      float64       `json:"annualYield"`
+}
+
+// handleGetRewardsByAddress returns the rewards received by the address in the recent epochs. The rewards are
+// read from the governing and autobid transactions of the kblocks, which close each epoch.
+func (st *Staking) handleGetRewardsByAddress(w http.ResponseWriter, req *http.Request) error {
+	addr, err := meter.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	limit := uint64(staking.STAKING_MAX_VALIDATOR_REWARDS)
+	if s := req.URL.Query().Get("limit"); s != "" {
+		limit, err = strconv.ParseUint(s, 0, 32)
+		if err != nil || limit == 0 {
+			return utils.BadRequest(errors.New("limit: invalid value"))
+		}
+		if limit > maxRewardHistoryEpochs {
+			limit = maxRewardHistoryEpochs
+		}
+	}
+	h, err := st.handleRevision(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+
+	history := &RewardHistory{Address: addr, Rewards: make([]*EpochReward, 0)}
+	distSum, autobidSum := new(big.Int), new(big.Int)
+
+	num := h.LastKBlockHeight()
+	if h.BlockType() == block.BLOCK_TYPE_K_BLOCK {
+		num = h.Number()
+	}
+	for n := uint64(0); n < limit; n++ {
+		blk, err := st.chain.GetTrunkBlock(num)
+		if err != nil {
+			return err
+		}
+		r, err := st.epochReward(blk, addr)
+		if err != nil {
+			return err
+		}
+		if r != nil {
+			history.Rewards = append(history.Rewards, r)
+			dist, _ := new(big.Int).SetString(r.Distributed, 10)
+			autobid, _ := new(big.Int).SetString(r.Autobid, 10)
+			distSum.Add(distSum, dist)
+			autobidSum.Add(autobidSum, autobid)
+		}
+		if num == 0 {
+			break
+		}
+		num = blk.Header().LastKBlockHeight()
+	}
+	history.Distributed = distSum.String()
+	history.Autobid = autobidSum.String()
+	history.Total = new(big.Int).Add(distSum, autobidSum).String()
+	return utils.WriteJSON(w, history)
+}
+
+// epochReward decodes the rewards of the address in the kblock, it returns nil if there is no governing transaction.
+func (st *Staking) epochReward(blk *block.Block, addr meter.Address) (*EpochReward, error) {
+	receipts, err := st.chain.GetBlockReceipts(blk.Header().ID())
+	if err != nil {
+		return nil, err
+	}
+
+	governed := false
+	var epoch uint32
+	dist, autobid := new(big.Int), new(big.Int)
+	for i, tx := range blk.Transactions() {
+		if i < len(receipts) && receipts[i].Reverted {
+			continue
+		}
+		for _, clause := range tx.Clauses() {
+			s, err := decodeScript(clause.Data())
+			if err != nil {
+				continue
+			}
+			switch s.Header.ModID {
+			case script.STAKING_MODULE_ID:
+				sb, err := staking.StakingDecodeFromBytes(s.Payload)
+				if err != nil || sb.Opcode != staking.OP_GOVERNING {
+					continue
+				}
+				rinfo := []*staking.RewardInfo{}
+				if err := rlp.DecodeBytes(sb.ExtraData, &rinfo); err != nil {
+					continue
+				}
+				governed = true
+				epoch = sb.Version // epoch is stored in version
+				for _, r := range rinfo {
+					if r.Address == addr {
+						dist.Add(dist, r.Amount)
+					}
+				}
+			case script.AUCTION_MODULE_ID:
+				ab, err := auction.AuctionDecodeFromBytes(s.Payload)
+				if err != nil || ab.Opcode != auction.OP_BID || ab.Option != auction.AUTO_BID {
+					continue
+				}
+				if ab.Bidder == addr {
+					autobid.Add(autobid, ab.Amount)
+				}
+			}
+		}
+	}
+	if !governed || (dist.Sign() == 0 && autobid.Sign() == 0) {
+		return nil, nil
+	}
+	return &EpochReward{
+		Epoch:       epoch,
+		BlockNumber: blk.Header().Number(),
+		BlockID:     blk.Header().ID(),
+		Timestamp:   blk.Header().Timestamp(),
+		Distributed: dist.String(),
+		Autobid:     autobid.String(),
+		Total:       new(big.Int).Add(dist, autobid).String(),
+	}, nil
+}
+
+// decodeScript decodes the clause data of the script engine, which is 4 bytes prefix, the pattern and the script.
+func decodeScript(data []byte) (*script.Script, error) {
+	prefix := 4 + len(script.ScriptPattern)
+	if len(data) <= prefix || !bytes.Equal(data[4:prefix], script.ScriptPattern[:]) {
+		return nil, errors.New("not script data")
+	}
+	return script.ScriptDecodeFromBytes(data[prefix:])
+}
+
+// handleEstimateReward simulates the reward of staking amount to the candidate with the bound option, with
+// the current delegates, committee and epoch rewards. The bonus votes of the option accumulate over time,
+// the ones after half a year are counted to average the first year. It's an estimate, the committee changes
+// every epoch and the candidate is not rewarded in the epochs out of it.
+func (st *Staking) handleEstimateReward(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	candidate, err := meter.ParseAddress(query.Get("candidate"))
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "candidate"))
+	}
+	amount, ok := new(big.Int).SetString(query.Get("amount"), 0)
+	if !ok || amount.Sign() <= 0 {
+		return utils.BadRequest(errors.New("amount: invalid value"))
+	}
+	var option uint64
+	if s := query.Get("option"); s != "" {
+		if option, err = strconv.ParseUint(s, 0, 32); err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "option"))
+		}
+	}
+	opt, rate, _ := staking.GetBoundLockOption(uint32(option))
+	bucket := staking.NewBucket(meter.Address{}, candidate, new(big.Int).Set(amount), meter.MTRG, opt, rate, 0, 0, 0)
+	bonus := staking.TouchBucketBonus(3600*24*365/2, bucket)
+
+	best := st.chain.BestBlock()
+	state, err := st.stateCreator.NewState(best.Header().StateRoot())
+	if err != nil {
+		return err
+	}
+	interns, err := staking.GetInternalDelegateList()
+	if err != nil {
+		return err
+	}
+	delegates := make([]*types.Delegate, 0, len(interns))
+	for _, in := range interns {
+		pubKey, err := delegatePubKey(in.PubKey)
+		if err != nil {
+			continue
+		}
+		delegates = append(delegates, &types.Delegate{
+			Name:        in.Name,
+			Address:     in.Address,
+			PubKey:      *pubKey,
+			VotingPower: in.VotingPower,
+			Commission:  in.Commission,
+			DistList:    in.DistList,
+		})
+	}
+	committee, err := st.getCommittee(best)
+	if err != nil {
+		return err
+	}
+
+	epochBaseReward := reward.ComputeEpochBaseReward(reward.GetValidatorBaseRewards(state))
+	epochTotalReward, err := reward.ComputeEpochTotalReward(reward.GetValidatorBenefitRatio(state), meter.NDaysV2, meter.NAuctionPerDay)
+	if err != nil {
+		return err
+	}
+	dist, autobid, err := reward.EstimateReward(epochBaseReward, epochTotalReward, delegates, committee, candidate, bucket.TotalVotes, 0)
+	if err != nil {
+		return utils.BadRequest(err)
+	}
+
+	epochReward := new(big.Int).Add(dist, autobid)
+	dailyReward := new(big.Int).Mul(epochReward, big.NewInt(int64(meter.NEpochPerDay)))
+	annualReward := new(big.Int).Mul(dailyReward, big.NewInt(365))
+	annualYield, _ := new(big.Float).Quo(new(big.Float).SetInt(annualReward), new(big.Float).SetInt(amount)).Float64()
+
+	return utils.WriteJSON(w, &RewardEstimate{
+		Candidate:        candidate,
+		Amount:           amount.String(),
+		Option:           opt,
+		BonusRate:        rate,
+		BonusVotes:       bonus.String(),
+		EpochBaseReward:  epochBaseReward.String(),
+		EpochTotalReward: epochTotalReward.String(),
+		EpochReward:      epochReward.String(),
+		DailyReward:      dailyReward.String(),
+		AnnualReward:     annualReward.String(),
+		AnnualYield:      annualYield,
+	})
+}
+
+// getCommittee returns the committee of the epoch, which is recorded in the first block after the kblock.
+func (st *Staking) getCommittee(best *block.Block) ([]*types.Validator, error) {
+	blk, err := st.chain.GetTrunkBlock(best.Header().LastKBlockHeight() + 1)
+	if err != nil {
+		return nil, err
+	}
+	committee := make([]*types.Validator, 0, len(blk.CommitteeInfos.CommitteeInfo))
+	for _, info := range blk.CommitteeInfos.CommitteeInfo {
+		pubKey, err := crypto.UnmarshalPubkey(info.PubKey)
+		if err != nil {
+			continue
+		}
+		committee = append(committee, &types.Validator{Name: info.Name, PubKey: *pubKey, NetAddr: info.NetAddr})
+	}
+	return committee, nil
+}
+
+// delegatePubKey decodes the ECDSA public key from the combo public key of the delegate.
+func delegatePubKey(comboPubKey []byte) (*ecdsa.PublicKey, error) {
+	split := strings.Split(string(comboPubKey), ":::")
+	pubKeyBytes, err := b64.StdEncoding.DecodeString(split[0])
+	if err != nil {
+		return nil, err
+	}
+	return crypto.UnmarshalPubkey(pubKeyBytes)
+}
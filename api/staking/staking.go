@@ -187,4 +187,6 @@ func (st *Staking) Mount(root *mux.Router, pathPrefix string) {
 	sub.Path("/delegates").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(st.handleGetDelegateList))
 	sub.Path("/validator-rewards").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(st.handleGetValidatorRewardList))
 	sub.Path("/last/rewards").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(st.handleGetLastValidatorReward))
+	sub.Path("/rewards/estimate").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(st.handleEstimateReward))
+	sub.Path("/rewards/{address}").Methods("Get").HandlerFunc(utils.WrapHandlerFunc(st.handleGetRewardsByAddress))
 }
@@ -0,0 +1,77 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tokentransfers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+type TokenTransfers struct {
+	db *logdb.LogDB
+}
+
+func New(db *logdb.LogDB) *TokenTransfers {
+	return &TokenTransfers{
+		db,
+	}
+}
+
+//Filter query token transfers with option
+func (t *TokenTransfers) filter(ctx context.Context, filter *logdb.TokenTransferFilter) ([]*FilteredTokenTransfer, error) {
+	transfers, err := t.db.FilterTokenTransfers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tLogs := make([]*FilteredTokenTransfer, len(transfers))
+	for i, trans := range transfers {
+		tLogs[i] = convertTokenTransfer(trans)
+	}
+	return tLogs, nil
+}
+
+func (t *TokenTransfers) handleFilterTokenTransferLogs(w http.ResponseWriter, req *http.Request) error {
+	var filter logdb.TokenTransferFilter
+	if err := utils.ParseJSON(req.Body, &filter); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	tLogs, err := t.filter(req.Context(), &filter)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, tLogs)
+}
+
+func (t *TokenTransfers) handleBalanceChanges(w http.ResponseWriter, req *http.Request) error {
+	var filter BalanceChangeFilter
+	if err := utils.ParseJSON(req.Body, &filter); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if filter.Holder == nil {
+		return utils.BadRequest(errors.New("body: holder required"))
+	}
+	changes, err := t.db.TokenBalanceChanges(req.Context(), *filter.Holder, filter.Token, filter.Range, filter.Options)
+	if err != nil {
+		return err
+	}
+	result := make([]*BalanceChange, len(changes))
+	for i, change := range changes {
+		result[i] = convertBalanceChange(change)
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (t *TokenTransfers) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleFilterTokenTransferLogs))
+	sub.Path("/balance-change").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleBalanceChanges))
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tokentransfers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dfinlab/meter/api/tokentransfers"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/tx"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ts     *httptest.Server
+	token  = meter.BytesToAddress([]byte("token"))
+	holder = meter.BytesToAddress([]byte("holder"))
+	other  = meter.BytesToAddress([]byte("other"))
+)
+
+func TestTokenTransfers(t *testing.T) {
+	initLogServer(t)
+	defer ts.Close()
+	getTokenTransfers(t)
+	getBalanceChanges(t)
+}
+
+func getTokenTransfers(t *testing.T) {
+	limit := 5
+	filter := &logdb.TokenTransferFilter{
+		CriteriaSet: []*logdb.TokenTransferCriteria{{Holder: &holder}},
+		Range: &logdb.Range{
+			Unit: logdb.Block,
+			From: 0,
+			To:   1000,
+		},
+		Options: &logdb.Options{
+			Offset: 0,
+			Limit:  uint64(limit),
+		},
+		Order: logdb.DESC,
+	}
+	res := httpPost(t, ts.URL+"/logs/token-transfer", filter)
+	var tLogs []*tokentransfers.FilteredTokenTransfer
+	if err := json.Unmarshal(res, &tLogs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, limit, len(tLogs), "should be `limit` transfers")
+	for _, tLog := range tLogs {
+		assert.Equal(t, token, tLog.Token)
+		assert.True(t, tLog.Sender == holder || tLog.Recipient == holder)
+	}
+}
+
+func getBalanceChanges(t *testing.T) {
+	res := httpPost(t, ts.URL+"/logs/token-transfer/balance-change", &tokentransfers.BalanceChangeFilter{Holder: &holder})
+	var changes []*tokentransfers.BalanceChange
+	if err := json.Unmarshal(res, &changes); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, token, changes[0].Token)
+	assert.Equal(t, big.NewInt(500), (*big.Int)(changes[0].Received))
+	assert.Equal(t, big.NewInt(500), (*big.Int)(changes[0].Sent))
+	assert.Equal(t, "0x0", changes[0].Change)
+
+	res = httpPost(t, ts.URL+"/logs/token-transfer/balance-change", &tokentransfers.BalanceChangeFilter{
+		Holder:  &holder,
+		Options: &logdb.Options{Offset: 1, Limit: 1},
+	})
+	if err := json.Unmarshal(res, &changes); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, changes, "only one token")
+}
+
+func initLogServer(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transferEventID := meter.Bytes32(crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")))
+	header := new(block.Builder).Build().Header()
+	count := 100
+	for i := 0; i < count; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		from, to := holder, other
+		if i%2 == 0 {
+			from, to = other, holder
+		}
+		event := &tx.Event{
+			Address: token,
+			Topics:  []meter.Bytes32{transferEventID, meter.BytesToBytes32(from.Bytes()), meter.BytesToBytes32(to.Bytes())},
+			Data:    meter.BytesToBytes32(big.NewInt(10).Bytes()).Bytes(),
+		}
+		if err := db.Prepare(header).ForTransaction(meter.Bytes32{}, from).Insert(tx.Events{event}, nil).
+			Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := mux.NewRouter()
+	tokentransfers.New(db).Mount(router, "/logs/token-transfer")
+	ts = httptest.NewServer(router)
+}
+
+func httpPost(t *testing.T, url string, obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tokentransfers
+
+import (
+	"math/big"
+
+	"github.com/dfinlab/meter/api/transactions"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+type FilteredTokenTransfer struct {
+	Token     meter.Address         `json:"token"`
+	Standard  logdb.TokenStandard   `json:"standard"`
+	Sender    meter.Address         `json:"sender"`
+	Recipient meter.Address         `json:"recipient"`
+	Amount    *math.HexOrDecimal256 `json:"amount"`
+	Meta      transactions.LogMeta  `json:"meta"`
+}
+
+func convertTokenTransfer(transfer *logdb.TokenTransfer) *FilteredTokenTransfer {
+	v := math.HexOrDecimal256(*transfer.Amount)
+	return &FilteredTokenTransfer{
+		Token:     transfer.Token,
+		Standard:  transfer.Standard,
+		Sender:    transfer.Sender,
+		Recipient: transfer.Recipient,
+		Amount:    &v,
+		Meta: transactions.LogMeta{
+			BlockID:        transfer.BlockID,
+			BlockNumber:    transfer.BlockNumber,
+			BlockTimestamp: transfer.BlockTime,
+			TxID:           transfer.TxID,
+			TxOrigin:       transfer.TxOrigin,
+		},
+	}
+}
+
+type BalanceChangeFilter struct {
+	Holder  *meter.Address `json:"holder"`
+	Token   *meter.Address `json:"token"`
+	Range   *logdb.Range   `json:"range"`
+	Options *logdb.Options `json:"options"` // pages the token contracts
+}
+
+type BalanceChange struct {
+	Token    meter.Address         `json:"token"`
+	Standard logdb.TokenStandard   `json:"standard"`
+	Received *math.HexOrDecimal256 `json:"received"`
+	Sent     *math.HexOrDecimal256 `json:"sent"`
+	Change   string                `json:"change"` // signed, hex encoded
+}
+
+func convertBalanceChange(change *logdb.TokenBalanceChange) *BalanceChange {
+	received := math.HexOrDecimal256(*change.Received)
+	sent := math.HexOrDecimal256(*change.Sent)
+	return &BalanceChange{
+		Token:    change.Token,
+		Standard: change.Standard,
+		Received: &received,
+		Sent:     &sent,
+		Change:   hexutil.EncodeBig(new(big.Int).Sub(change.Received, change.Sent)),
+	}
+}
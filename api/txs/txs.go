@@ -0,0 +1,57 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txs
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dfinlab/meter/api/utils"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+type Txs struct {
+	db *logdb.LogDB
+}
+
+func New(db *logdb.LogDB) *Txs {
+	return &Txs{
+		db,
+	}
+}
+
+//Filter query tx clauses with option
+func (t *Txs) filter(ctx context.Context, filter *logdb.TxFilter) ([]*FilteredTx, error) {
+	records, err := t.db.FilterTxs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*FilteredTx, len(records))
+	for i, record := range records {
+		txs[i] = convertTxRecord(record)
+	}
+	return txs, nil
+}
+
+func (t *Txs) handleFilterTxLogs(w http.ResponseWriter, req *http.Request) error {
+	var filter logdb.TxFilter
+	if err := utils.ParseJSON(req.Body, &filter); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	txs, err := t.filter(req.Context(), &filter)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, txs)
+}
+
+func (t *Txs) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleFilterTxLogs))
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dfinlab/meter/api/txs"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/tx"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+var ts *httptest.Server
+
+func TestTxs(t *testing.T) {
+	initLogServer(t)
+	defer ts.Close()
+	getTxs(t)
+}
+
+func getTxs(t *testing.T) {
+	limit := 5
+	account := meter.BytesToAddress([]byte("account"))
+	filter := &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{
+			{TxOrigin: &account},
+			{To: &account},
+		},
+		Range: &logdb.Range{
+			Unit: logdb.Block,
+			From: 0,
+			To:   1000,
+		},
+		Options: &logdb.Options{
+			Offset: 0,
+			Limit:  uint64(limit),
+		},
+		Order: logdb.DESC,
+	}
+	res := httpPost(t, ts.URL+"/logs/tx", filter)
+	var filtered []*txs.FilteredTx
+	if err := json.Unmarshal(res, &filtered); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, limit, len(filtered), "should be `limit` txs")
+	for _, f := range filtered {
+		assert.True(t, f.Meta.TxOrigin == account || (f.To != nil && *f.To == account))
+	}
+}
+
+func initLogServer(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account := meter.BytesToAddress([]byte("account"))
+	other := meter.BytesToAddress([]byte("other"))
+	header := new(block.Builder).Build().Header()
+	count := 100
+	for i := 0; i < count; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		origin, to := account, other
+		if i%2 == 0 {
+			origin, to = other, account
+		}
+		trx := new(tx.Builder).Clause(tx.NewClause(&to)).Nonce(uint64(i)).Build()
+		if err := db.Prepare(header).InsertTx(trx, origin, &tx.Receipt{Outputs: []*tx.Output{{}}}, nil).
+			Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := mux.NewRouter()
+	txs.New(db).Mount(router, "/logs/tx")
+	ts = httptest.NewServer(router)
+}
+
+func httpPost(t *testing.T, url string, obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
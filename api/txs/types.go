@@ -0,0 +1,36 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txs
+
+import (
+	"github.com/dfinlab/meter/api/transactions"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+)
+
+type FilteredTx struct {
+	ClauseIndex uint32               `json:"clauseIndex"`
+	To          *meter.Address       `json:"to"`
+	Created     []meter.Address      `json:"created"`
+	Reverted    bool                 `json:"reverted"`
+	Meta        transactions.LogMeta `json:"meta"`
+}
+
+func convertTxRecord(record *logdb.TxRecord) *FilteredTx {
+	return &FilteredTx{
+		ClauseIndex: record.ClauseIndex,
+		To:          record.To,
+		Created:     record.Created,
+		Reverted:    record.Reverted,
+		Meta: transactions.LogMeta{
+			BlockID:        record.BlockID,
+			BlockNumber:    record.BlockNumber,
+			BlockTimestamp: record.BlockTime,
+			TxID:           record.TxID,
+			TxOrigin:       record.TxOrigin,
+		},
+	}
+}
@@ -35,7 +35,20 @@ func (p *Params) Get(key meter.Bytes32) (value *big.Int) {
 	return
 }
 
-// Set native way to set param.
+// Lookup native way to get param, and whether it's set.
+func (p *Params) Lookup(key meter.Bytes32) (value *big.Int, ok bool) {
+	p.state.DecodeStorage(p.addr, key, func(raw []byte) error {
+		if len(raw) == 0 {
+			value = &big.Int{}
+			return nil
+		}
+		ok = true
+		return rlp.DecodeBytes(raw, &value)
+	})
+	return
+}
+
+// Set native way to set param. Zero value clears the param.
 func (p *Params) Set(key meter.Bytes32, value *big.Int) {
 	p.state.EncodeStorage(p.addr, key, func() ([]byte, error) {
 		if value.Sign() == 0 {
@@ -45,6 +58,13 @@ func (p *Params) Set(key meter.Bytes32, value *big.Int) {
 	})
 }
 
+// SetKeepZero native way to set param. Zero value is kept, Lookup tells it from unset.
+func (p *Params) SetKeepZero(key meter.Bytes32, value *big.Int) {
+	p.state.EncodeStorage(p.addr, key, func() ([]byte, error) {
+		return rlp.EncodeToBytes(value)
+	})
+}
+
 // Get native way to get param.
 func (p *Params) GetAddress(key meter.Bytes32) (addr meter.Address) {
 	addr = meter.BytesToAddress(p.Get(key).Bytes())
@@ -28,3 +28,28 @@ func TestParamsGetSet(t *testing.T) {
 
 	assert.Nil(t, st.Err())
 }
+
+func TestParamsLookup(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := state.New(meter.Bytes32{}, kv)
+	key := meter.BytesToBytes32([]byte("key"))
+	p := New(meter.BytesToAddress([]byte("par")), st)
+
+	v, ok := p.Lookup(key)
+	assert.False(t, ok)
+	assert.Equal(t, 0, v.Sign())
+
+	// zero clears the param
+	p.Set(key, big.NewInt(10))
+	p.Set(key, big.NewInt(0))
+	_, ok = p.Lookup(key)
+	assert.False(t, ok)
+
+	p.SetKeepZero(key, big.NewInt(0))
+	v, ok = p.Lookup(key)
+	assert.True(t, ok)
+	assert.Equal(t, 0, v.Sign())
+	assert.Equal(t, 0, p.Get(key).Sign())
+
+	assert.Nil(t, st.Err())
+}
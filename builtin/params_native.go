@@ -39,7 +39,12 @@ func init() {
 			env.ParseArgs(&args)
 
 			env.UseGas(meter.SstoreSetGas)
-			Params.Native(env.State()).Set(meter.Bytes32(args.Key), args.Value)
+			// zero is kept since tesla fork4, so params defaulting to non-zero can be set to zero
+			if meter.BlockChainConfig.IsTeslaFork4(env.BlockContext().Number) {
+				Params.Native(env.State()).SetKeepZero(meter.Bytes32(args.Key), args.Value)
+			} else {
+				Params.Native(env.State()).Set(meter.Bytes32(args.Key), args.Value)
+			}
 			return nil
 		}},
 	}
@@ -0,0 +1,164 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/kv"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/trie"
+	"github.com/dfinlab/meter/tx"
+	"github.com/pkg/errors"
+)
+
+// Checker verifies the trunk data persisted in kv.
+// Unlike Chain, it doesn't load the best block, so it works even if the best block is corrupted.
+type Checker struct {
+	kv           kv.GetPutter
+	ancestorTrie *ancestorTrie
+	bestID       meter.Bytes32
+}
+
+// NewChecker creates a checker on trunk ended with the stored best block.
+func NewChecker(kv kv.GetPutter) (*Checker, error) {
+	bestID, err := loadBestBlockID(kv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "load best block id")
+	}
+	return &Checker{
+		kv:           kv,
+		ancestorTrie: newAncestorTrie(kv),
+		bestID:       bestID,
+	}, nil
+}
+
+// BestBlockID returns the stored best block id.
+func (c *Checker) BestBlockID() meter.Bytes32 {
+	return c.bestID
+}
+
+// GetTrunkBlockID returns id of the trunk block with given number.
+func (c *Checker) GetTrunkBlockID(num uint32) (meter.Bytes32, error) {
+	return c.ancestorTrie.GetAncestor(c.bestID, num)
+}
+
+// CheckBlock verifies the raw data, index trie root, tx metas, receipts and state root of the trunk block.
+// The decoded block and its receipts are returned if everything is consistent.
+func (c *Checker) CheckBlock(id meter.Bytes32, parentID meter.Bytes32) (*block.Block, tx.Receipts, error) {
+	raw, err := loadBlockRaw(c.kv, id)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "load block")
+	}
+	blk, err := (&rawBlock{raw: raw}).Block()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "decode block")
+	}
+	header := blk.Header()
+	if header.ID() != id {
+		return nil, nil, fmt.Errorf("block id mismatch: want %v, got %v", id, header.ID())
+	}
+	if header.Number() > 0 && header.ParentID() != parentID {
+		return nil, nil, fmt.Errorf("parent id mismatch: want %v, got %v", parentID, header.ParentID())
+	}
+	if root := blk.Transactions().RootHash(); root != header.TxsRoot() {
+		return nil, nil, fmt.Errorf("txs root mismatch: want %v, got %v", header.TxsRoot(), root)
+	}
+	if _, err := loadBlockNumberIndexTrieRoot(c.kv, id); err != nil {
+		return nil, nil, errors.WithMessage(err, "load index trie root")
+	}
+	if _, err := trie.New(header.StateRoot(), c.kv); err != nil {
+		return nil, nil, errors.WithMessage(err, "load state root")
+	}
+
+	// genesis block has no receipts saved
+	if header.Number() == 0 {
+		return blk, nil, nil
+	}
+	receipts, err := loadBlockReceipts(c.kv, id)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "load receipts")
+	}
+	if len(receipts) != len(blk.Transactions()) {
+		return nil, nil, fmt.Errorf("receipts count mismatch: want %v, got %v", len(blk.Transactions()), len(receipts))
+	}
+	if root := receipts.RootHash(); root != header.ReceiptsRoot() {
+		return nil, nil, fmt.Errorf("receipts root mismatch: want %v, got %v", header.ReceiptsRoot(), root)
+	}
+	for i, t := range blk.Transactions() {
+		metas, err := loadTxMeta(c.kv, t.ID())
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, fmt.Sprintf("load tx meta of %v", t.ID()))
+		}
+		found := false
+		for _, m := range metas {
+			if m.BlockID == id {
+				if m.Index != uint64(i) || m.Reverted != receipts[i].Reverted {
+					return nil, nil, fmt.Errorf("tx meta mismatch of %v", t.ID())
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("tx meta missing of %v", t.ID())
+		}
+	}
+	return blk, receipts, nil
+}
+
+// Rewind resets the best and leaf block to the given trunk block, and removes trunk blocks after it.
+// Tx metas of blocks that can't be decoded are left behind, they are ignored by trunk lookups.
+func (c *Checker) Rewind(blk *block.Block) error {
+	num := blk.Header().Number()
+	if id, err := c.GetTrunkBlockID(num); err != nil {
+		return err
+	} else if id != blk.Header().ID() {
+		return errors.New("not a trunk block")
+	}
+
+	batch := c.kv.NewBatch()
+	for n := block.Number(c.bestID); n > num; n-- {
+		id, err := c.GetTrunkBlockID(n)
+		if err != nil {
+			return err
+		}
+		if raw, err := loadBlockRaw(c.kv, id); err == nil {
+			if blk, err := (&rawBlock{raw: raw}).Block(); err == nil {
+				for _, t := range blk.Transactions() {
+					if err := deleteTxMeta(batch, t.ID()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if err := deleteBlockReceipts(batch, id); err != nil {
+			return err
+		}
+		if err := deleteBlockRaw(batch, id); err != nil {
+			return err
+		}
+	}
+	if err := saveBestBlockID(batch, blk.Header().ID()); err != nil {
+		return err
+	}
+	if err := saveLeafBlockID(batch, blk.Header().ID()); err != nil {
+		return err
+	}
+	qc := blk.QC
+	if num == 0 || qc == nil {
+		qc = block.GenesisQC()
+	}
+	if err := saveRLP(batch, bestQCKey, qc); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	c.bestID = blk.Header().ID()
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// meter-sim simulates the validator rewards and auctions of the coming epochs from a snapshot of
+// the staking state, to evaluate parameter changes before proposing them.
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	version   string
+	gitCommit string
+	gitTag    string
+
+	snapshotFlag = cli.StringFlag{
+		Name:  "snapshot",
+		Usage: "JSON file of the staking state to start from, the node database is read if not set",
+	}
+	networkFlag = cli.StringFlag{
+		Name:  "network",
+		Usage: "the network of the node database (main|test|warringstakes)",
+	}
+	dataDirFlag = cli.StringFlag{
+		Name:  "data-dir",
+		Usage: "directory for block-chain databases of the node",
+	}
+	revisionFlag = cli.StringFlag{
+		Name:  "revision",
+		Value: "best",
+		Usage: "block revision of the node database, can be best, block number or block id",
+	}
+	saveSnapshotFlag = cli.StringFlag{
+		Name:  "save-snapshot",
+		Usage: "save the staking state read from the node database to the JSON file",
+	}
+	epochsFlag = cli.IntFlag{
+		Name:  "epochs",
+		Value: 720,
+		Usage: "number of epochs to simulate",
+	}
+	delegateSizeFlag = cli.IntFlag{
+		Name:  "delegate-size",
+		Value: 100,
+		Usage: "delegate max size",
+	}
+	committeeSizeFlag = cli.IntFlag{
+		Name:  "committee-size",
+		Value: 50,
+		Usage: "committee max size",
+	}
+	participationFlag = cli.Float64Flag{
+		Name:  "participation",
+		Value: 1,
+		Usage: "ratio of the committee members online to get rewards (0, 1]",
+	}
+	autobidFlag = cli.IntFlag{
+		Name:  "autobid",
+		Value: -1,
+		Usage: "autobid percentile of all distributors [0, 100], keep the ones of the snapshot if negative",
+	}
+	userBidFlag = cli.StringFlag{
+		Name:  "user-bid",
+		Usage: "MTR in wei bid by users in each auction, average of the recent auctions if not set",
+	}
+	bidGrowthFlag = cli.Float64Flag{
+		Name:  "bid-growth",
+		Usage: "growth rate of the user bids per auction, e.g. 0.01 for 1%",
+	}
+	seedFlag = cli.Int64Flag{
+		Name:  "seed",
+		Value: 1,
+		Usage: "random seed of the committee selection",
+	}
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Value: "meter-sim.csv",
+		Usage: "CSV file of the results, amounts are in wei",
+	}
+
+	flags = []cli.Flag{
+		snapshotFlag,
+		networkFlag,
+		dataDirFlag,
+		revisionFlag,
+		saveSnapshotFlag,
+		epochsFlag,
+		delegateSizeFlag,
+		committeeSizeFlag,
+		participationFlag,
+		autobidFlag,
+		userBidFlag,
+		bidGrowthFlag,
+		seedFlag,
+		outFlag,
+	}
+)
+
+func parseOptions(ctx *cli.Context) (*simOptions, error) {
+	opts := &simOptions{
+		Epochs:        ctx.Int(epochsFlag.Name),
+		DelegateSize:  ctx.Int(delegateSizeFlag.Name),
+		CommitteeSize: ctx.Int(committeeSizeFlag.Name),
+		Participation: ctx.Float64(participationFlag.Name),
+		Autobid:       ctx.Int(autobidFlag.Name),
+		BidGrowth:     ctx.Float64(bidGrowthFlag.Name),
+		Seed:          ctx.Int64(seedFlag.Name),
+	}
+	if opts.Epochs < 0 {
+		return nil, errors.New("-epochs must not be negative")
+	}
+	if opts.DelegateSize <= 0 || opts.CommitteeSize <= 0 {
+		return nil, errors.New("-delegate-size and -committee-size must be positive")
+	}
+	if opts.Participation <= 0 || opts.Participation > 1 {
+		return nil, errors.New("-participation must be in (0, 1]")
+	}
+	if opts.Autobid > 100 {
+		return nil, errors.New("-autobid must not be greater than 100")
+	}
+	if opts.BidGrowth <= -1 {
+		return nil, errors.New("-bid-growth must be greater than -1")
+	}
+	if s := ctx.String(userBidFlag.Name); s != "" {
+		bid, ok := new(big.Int).SetString(s, 10)
+		if !ok || bid.Sign() < 0 {
+			return nil, fmt.Errorf("invalid -user-bid: %v", s)
+		}
+		opts.UserBid = bid
+	}
+	return opts, nil
+}
+
+func run(ctx *cli.Context) error {
+	opts, err := parseOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var snap *Snapshot
+	if path := ctx.String(snapshotFlag.Name); path != "" {
+		if snap, err = loadSnapshotFile(path); err != nil {
+			return err
+		}
+	} else {
+		if ctx.String(dataDirFlag.Name) == "" {
+			return errors.New("either -snapshot or -data-dir is required")
+		}
+		if snap, err = loadSnapshotFromDB(ctx); err != nil {
+			return err
+		}
+		if path := ctx.String(saveSnapshotFlag.Name); path != "" {
+			if err := saveSnapshotFile(path, snap); err != nil {
+				return err
+			}
+		}
+	}
+
+	sim, err := newSimulator(snap, opts)
+	if err != nil {
+		return err
+	}
+
+	// the results go to a file, the reward functions print to stdout
+	file, err := os.Create(ctx.String(outFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := 0; i < opts.Epochs; i++ {
+		r, err := sim.step()
+		if err != nil {
+			return err
+		}
+		if err := w.Write(r.row()); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func main() {
+	versionMeta := "release"
+	if gitTag == "" {
+		versionMeta = "dev"
+	}
+	app := cli.App{
+		Version:   fmt.Sprintf("%s-%s-%s", version, gitCommit, versionMeta),
+		Name:      "Meter-sim",
+		Usage:     "Meter.io validator reward and auction simulator",
+		Copyright: "2018 Meter Foundation <https://meter.io/>",
+		Flags:     flags,
+		Action:    run,
+	}
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	b64 "encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	api_staking "github.com/dfinlab/meter/api/staking"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/reward"
+	"github.com/dfinlab/meter/script/auction"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// simOptions are the assumptions of the simulation.
+type simOptions struct {
+	Epochs        int
+	DelegateSize  int
+	CommitteeSize int
+	Participation float64  // ratio of the committee members online to get rewards
+	Autobid       int      // autobid percentile of all distributors, negative to keep the snapshot's
+	UserBid       *big.Int // MTR bid by users in each auction, nil for the average of the recent auctions
+	BidGrowth     float64  // growth rate of the user bids per auction
+	Seed          int64
+}
+
+var csvHeader = []string{
+	"epoch",
+	"committeeSize",
+	"epochBaseReward",
+	"epochTotalReward",
+	"distributed",
+	"autobid",
+	"auctionSequence",
+	"auctionReleasedMTRG",
+	"auctionReceivedMTR",
+	"closedSequence",
+	"closedReceivedMTR",
+	"closedActualPrice",
+	"closedLeftoverMTRG",
+	"validatorBenefit",
+}
+
+// epochResult is a line of the csv output, amounts are in wei.
+type epochResult struct {
+	Epoch         uint64
+	CommitteeSize int
+	BaseReward    *big.Int
+	TotalReward   *big.Int
+	Distributed   *big.Int
+	Autobid       *big.Int
+	Sequence      uint64 // the active auction after the epoch starts
+	Released      *big.Int
+	Received      *big.Int
+	Closed        *auction.AuctionSummary // the auction cleared in the epoch, if any
+	Benefit       *big.Int
+}
+
+func (r *epochResult) row() []string {
+	row := []string{
+		strconv.FormatUint(r.Epoch, 10),
+		strconv.Itoa(r.CommitteeSize),
+		r.BaseReward.String(),
+		r.TotalReward.String(),
+		r.Distributed.String(),
+		r.Autobid.String(),
+		"", "", "", "", "", "", "", "",
+	}
+	if r.Released != nil {
+		row[6] = strconv.FormatUint(r.Sequence, 10)
+		row[7] = r.Released.String()
+		row[8] = r.Received.String()
+	}
+	if r.Closed != nil {
+		row[9] = strconv.FormatUint(r.Closed.Sequence, 10)
+		row[10] = r.Closed.RcvdMTR.String()
+		row[11] = r.Closed.ActualPrice.String()
+		row[12] = r.Closed.LeftoverMTRG.String()
+		row[13] = r.Benefit.String()
+	}
+	return row
+}
+
+// simulator replays what the kblock of each epoch does to the staking state: the validator
+// rewards and autobids, and the auctions started and cleared by the auction control tx.
+type simulator struct {
+	opts          *simOptions
+	epoch         uint64
+	baseReward    *big.Int
+	benefitRatio  *big.Int
+	reservedPrice *big.Int
+	delegates     []*types.Delegate
+	cb            *auction.AuctionCB
+	summaries     []*auction.AuctionSummary
+	userBid       *big.Int
+	rand          *rand.Rand
+}
+
+func newSimulator(snap *Snapshot, opts *simOptions) (*simulator, error) {
+	var err error
+	s := &simulator{
+		opts:      opts,
+		epoch:     snap.Epoch,
+		delegates: make([]*types.Delegate, 0),
+		cb:        &auction.AuctionCB{},
+		summaries: make([]*auction.AuctionSummary, 0),
+		rand:      rand.New(rand.NewSource(opts.Seed)),
+	}
+	if s.baseReward, err = parseAmount("validatorBaseReward", snap.BaseReward); err != nil {
+		return nil, err
+	}
+	if s.benefitRatio, err = parseAmount("validatorBenefitRatio", snap.BenefitRatio); err != nil {
+		return nil, err
+	}
+	if s.reservedPrice, err = parseAmount("auctionReservedPrice", snap.ReservedPrice); err != nil {
+		return nil, err
+	}
+
+	delegates, err := sortDelegates(snap.Delegates)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range delegates {
+		delegate, err := parseDelegate(d.Name, d.Address, d.PubKey, d.VotingPower, d.Commission)
+		if err != nil {
+			return nil, err
+		}
+		for _, dist := range d.DistList {
+			autobid := dist.Autobid
+			if opts.Autobid >= 0 {
+				autobid = uint8(opts.Autobid)
+			}
+			delegate.DistList = append(delegate.DistList, &types.Distributor{Address: dist.Address, Autobid: autobid, Shares: dist.Shares})
+		}
+		s.delegates = append(s.delegates, delegate)
+	}
+	// same as calcCommitteeSize
+	if len(s.delegates) > opts.DelegateSize {
+		s.delegates = s.delegates[:opts.DelegateSize]
+	}
+	if len(s.delegates) == 0 {
+		return nil, errors.New("no delegates in the snapshot")
+	}
+
+	if a := snap.Auction; a != nil {
+		s.cb = &auction.AuctionCB{
+			StartHeight: a.StartHeight,
+			StartEpoch:  a.StartEpoch,
+			EndHeight:   a.EndHeight,
+			EndEpoch:    a.EndEpoch,
+			Sequence:    a.Sequence,
+			CreateTime:  a.CreateTime,
+		}
+		if s.cb.AuctionID, err = meter.ParseBytes32(a.AuctionID); err != nil {
+			return nil, fmt.Errorf("invalid auctionID: %v", a.AuctionID)
+		}
+		if s.cb.RlsdMTRG, err = parseAmount("releasedMTRG", a.RlsdMTRG); err != nil {
+			return nil, err
+		}
+		if s.cb.RsvdMTRG, err = parseAmount("reservedMTRG", a.RsvdMTRG); err != nil {
+			return nil, err
+		}
+		if s.cb.RsvdPrice, err = parseAmount("reservedPrice", a.RsvdPrice); err != nil {
+			return nil, err
+		}
+		if s.cb.RcvdMTR, err = parseAmount("receivedMTR", a.RcvdMTR); err != nil {
+			return nil, err
+		}
+	}
+
+	// user bids of the recent auctions, to guess the coming ones
+	recentBids := make([]*big.Int, 0)
+	for _, a := range snap.Summaries {
+		summary := &auction.AuctionSummary{
+			StartHeight: a.StartHeight,
+			StartEpoch:  a.StartEpoch,
+			EndHeight:   a.EndHeight,
+			EndEpoch:    a.EndEpoch,
+			Sequence:    a.Sequence,
+			CreateTime:  a.CreateTime,
+		}
+		if summary.AuctionID, err = meter.ParseBytes32(a.AuctionID); err != nil {
+			return nil, fmt.Errorf("invalid auctionID: %v", a.AuctionID)
+		}
+		if summary.RlsdMTRG, err = parseAmount("releasedMTRG", a.RlsdMTRG); err != nil {
+			return nil, err
+		}
+		if summary.RsvdMTRG, err = parseAmount("reservedMTRG", a.RsvdMTRG); err != nil {
+			return nil, err
+		}
+		if summary.RsvdPrice, err = parseAmount("reservedPrice", a.RsvdPrice); err != nil {
+			return nil, err
+		}
+		if summary.RcvdMTR, err = parseAmount("receivedMTR", a.RcvdMTR); err != nil {
+			return nil, err
+		}
+		if summary.ActualPrice, err = parseAmount("actualPrice", a.ActualPrice); err != nil {
+			return nil, err
+		}
+		if summary.LeftoverMTRG, err = parseAmount("leftoverMTRG", a.LeftoverMTRG); err != nil {
+			return nil, err
+		}
+		s.summaries = append(s.summaries, summary)
+
+		userBid := big.NewInt(0)
+		for _, tx := range a.AuctionTxs {
+			if tx.Type != "userbid" {
+				continue
+			}
+			amount, err := parseAmount("amount", tx.Amount)
+			if err != nil {
+				return nil, err
+			}
+			userBid.Add(userBid, amount)
+		}
+		recentBids = append(recentBids, userBid)
+	}
+
+	s.userBid = opts.UserBid
+	if s.userBid == nil {
+		// average of the last week
+		s.userBid = big.NewInt(0)
+		n := 7 * meter.NAuctionPerDay
+		if len(recentBids) < n {
+			n = len(recentBids)
+		}
+		for _, bid := range recentBids[len(recentBids)-n:] {
+			s.userBid.Add(s.userBid, bid)
+		}
+		if n > 0 {
+			s.userBid.Div(s.userBid, big.NewInt(int64(n)))
+		}
+	}
+	return s, nil
+}
+
+// sortDelegates drops the delegates without voting power and sorts the rest the same way as the
+// governing handler, so that the top ones are picked as GetConsensusDelegates does. A snapshot put
+// together from the candidates API may not be in order.
+func sortDelegates(delegates []*api_staking.Delegate) ([]*api_staking.Delegate, error) {
+	votingPowers := make(map[*api_staking.Delegate]*big.Int)
+	result := make([]*api_staking.Delegate, 0, len(delegates))
+	for _, d := range delegates {
+		vp, err := parseAmount("votingPower", d.VotingPower)
+		if err != nil {
+			return nil, err
+		}
+		if vp.Sign() <= 0 {
+			continue
+		}
+		votingPowers[d] = vp
+		result = append(result, d)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		vpCmp := votingPowers[result[i]].Cmp(votingPowers[result[j]])
+		if vpCmp > 0 {
+			return true
+		}
+		if vpCmp < 0 {
+			return false
+		}
+
+		return strings.Compare(result[i].PubKey, result[j].PubKey) >= 0
+	})
+	return result, nil
+}
+
+// parseDelegate converts the delegate the same way as GetInternalDelegateList, the bls public key
+// is not needed by the rewards.
+func parseDelegate(name string, addr meter.Address, pubKey string, votingPower string, commission uint64) (*types.Delegate, error) {
+	// first part is ecdsa public key, 2nd part is bls public key
+	split := strings.Split(pubKey, ":::")
+	keyBytes, err := b64.StdEncoding.DecodeString(split[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of delegate %v: %v", name, err)
+	}
+	key, err := crypto.UnmarshalPubkey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of delegate %v: %v", name, err)
+	}
+	vp, err := parseAmount("votingPower", votingPower)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Delegate{
+		Name:        []byte(name),
+		Address:     addr,
+		PubKey:      *key,
+		VotingPower: new(big.Int).Div(vp, big.NewInt(1e12)).Int64(),
+		Commission:  commission,
+		DistList:    make([]*types.Distributor, 0),
+	}, nil
+}
+
+// selectCommittee picks the committee of the epoch randomly out of the delegates, as the nonce
+// of the kblock does, and keeps the participating members only.
+func (s *simulator) selectCommittee() []*types.Validator {
+	size := s.opts.CommitteeSize
+	if size > len(s.delegates) {
+		size = len(s.delegates)
+	}
+	size = int(float64(size)*s.opts.Participation + 0.5)
+	if size < 1 {
+		size = 1
+	}
+
+	committee := make([]*types.Validator, 0, size)
+	for _, i := range s.rand.Perm(len(s.delegates))[:size] {
+		d := s.delegates[i]
+		committee = append(committee, types.NewValidator(string(d.Name), d.Address, d.PubKey, d.BlsPubKey, d.VotingPower))
+	}
+	return committee
+}
+
+// step simulates the kblock which starts the next epoch.
+func (s *simulator) step() (*epochResult, error) {
+	s.epoch++
+	r := &epochResult{Epoch: s.epoch}
+
+	// the rewards are computed on the state before the kblock, see BuildKBlock
+	committee := s.selectCommittee()
+	r.CommitteeSize = len(committee)
+	r.BaseReward = reward.ComputeEpochBaseReward(s.baseReward)
+	r.TotalReward = reward.ComputeEpochTotalRewardBySummaries(s.summaries, s.benefitRatio, meter.NDaysV2, meter.NAuctionPerDay)
+	rewardMap, err := reward.ComputeRewardMapV3(r.BaseReward, r.TotalReward, s.delegates, committee)
+	if err != nil {
+		return nil, err
+	}
+	r.Distributed = big.NewInt(0)
+	r.Autobid = big.NewInt(0)
+	for _, info := range rewardMap {
+		r.Distributed.Add(r.Distributed, info.DistAmount)
+		r.Autobid.Add(r.Autobid, info.AutobidAmount)
+	}
+
+	// the auction control tx goes before the autobid txs
+	var lastEndEpoch, lastSequence uint64
+	if s.cb.IsActive() {
+		lastEndEpoch, lastSequence = s.cb.EndEpoch, s.cb.Sequence
+	} else if size := len(s.summaries); size > 0 {
+		lastEndEpoch, lastSequence = s.summaries[size-1].EndEpoch, s.summaries[size-1].Sequence
+	}
+	// same as shouldAuctionStart
+	if s.epoch > lastEndEpoch && s.epoch-lastEndEpoch >= meter.NEpochPerAuction {
+		// the release is based on the auction being stopped, see buildAuctionStartData
+		release, err := reward.ComputeEpochReleaseWithInflation(lastSequence+1, s.cb)
+		if err != nil {
+			return nil, err
+		}
+		if s.cb.IsActive() {
+			r.Closed, r.Benefit = s.clearAuction()
+		}
+		s.cb = &auction.AuctionCB{
+			StartEpoch: lastEndEpoch + 1,
+			EndEpoch:   s.epoch,
+			Sequence:   lastSequence + 1,
+			RlsdMTRG:   release,
+			RsvdMTRG:   big.NewInt(0),
+			RsvdPrice:  s.reservedPrice,
+			RcvdMTR:    big.NewInt(0),
+		}
+		s.cb.AuctionID = s.cb.ID()
+	}
+
+	if s.cb.IsActive() {
+		s.cb.RcvdMTR.Add(s.cb.RcvdMTR, r.Autobid)
+		r.Sequence = s.cb.Sequence
+		r.Released = new(big.Int).Set(s.cb.RlsdMTRG)
+		r.Received = new(big.Int).Set(s.cb.RcvdMTR)
+	}
+	return r, nil
+}
+
+// clearAuction closes the active auction with the user bids the same way as ClearAuction, and
+// returns its summary along with the validator benefit.
+func (s *simulator) clearAuction() (*auction.AuctionSummary, *big.Int) {
+	cb := s.cb
+	received := new(big.Int).Add(cb.RcvdMTR, s.userBid)
+
+	actualPrice := new(big.Int).Mul(received, big.NewInt(1e18))
+	if cb.RlsdMTRG.Sign() > 0 {
+		actualPrice.Div(actualPrice, cb.RlsdMTRG)
+	} else {
+		actualPrice = cb.RsvdPrice
+	}
+	if actualPrice.Cmp(cb.RsvdPrice) < 0 {
+		actualPrice = cb.RsvdPrice
+	}
+
+	sold := big.NewInt(0)
+	if actualPrice.Sign() > 0 {
+		sold.Mul(received, big.NewInt(1e18))
+		sold.Div(sold, actualPrice)
+	}
+	leftover := new(big.Int).Sub(cb.RlsdMTRG, sold)
+	if leftover.Sign() < 0 {
+		leftover = big.NewInt(0)
+	}
+
+	summary := &auction.AuctionSummary{
+		AuctionID:    cb.AuctionID,
+		StartHeight:  cb.StartHeight,
+		StartEpoch:   cb.StartEpoch,
+		EndHeight:    cb.EndHeight,
+		EndEpoch:     cb.EndEpoch,
+		Sequence:     cb.Sequence,
+		RlsdMTRG:     cb.RlsdMTRG,
+		RsvdMTRG:     cb.RsvdMTRG,
+		RsvdPrice:    cb.RsvdPrice,
+		CreateTime:   cb.CreateTime,
+		RcvdMTR:      received,
+		ActualPrice:  actualPrice,
+		LeftoverMTRG: leftover,
+	}
+	s.summaries = append(s.summaries, summary)
+
+	benefit := new(big.Int).Mul(received, s.benefitRatio)
+	benefit.Div(benefit, big.NewInt(1e18))
+
+	// userBid = userBid * (1 + bidGrowth)
+	s.userBid, _ = new(big.Float).Mul(new(big.Float).SetInt(s.userBid), big.NewFloat(1+s.opts.BidGrowth)).Int(nil)
+	return summary, benefit
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	api_staking "github.com/dfinlab/meter/api/staking"
+	"github.com/dfinlab/meter/builtin"
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/script/auction"
+	"github.com/dfinlab/meter/state"
+	"github.com/dfinlab/meter/types"
+	"github.com/dfinlab/meter/xenv"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func e18(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func newTestAuctionCB(released, rcvdMTR, rsvdPrice *big.Int) *auction.AuctionCB {
+	cb := &auction.AuctionCB{
+		StartEpoch: 1,
+		EndEpoch:   meter.NEpochPerAuction,
+		Sequence:   1,
+		RlsdMTRG:   released,
+		RsvdMTRG:   e18(10),
+		RsvdPrice:  rsvdPrice,
+		RcvdMTR:    rcvdMTR,
+	}
+	cb.AuctionID = cb.ID()
+	return cb
+}
+
+// clearAuction runs ClearAuction on the auction with an autobid of its received MTR and a user bid,
+// and returns the actual price, the leftover MTRG and the validator benefit.
+func clearAuction(t *testing.T, cb *auction.AuctionCB, userBid, benefitRatio *big.Int) (*big.Int, *big.Int, *big.Int) {
+	kv, _ := lvldb.NewMem()
+	st, _ := state.New(meter.Bytes32{}, kv)
+	builtin.Params.Native(st).Set(meter.KeyValidatorBenefitRatio, benefitRatio)
+	st.SetEnergy(auction.AuctionAccountAddr, e18(1000000))
+
+	c := *cb
+	c.AuctionTxs = []*auction.AuctionTx{
+		auction.NewAuctionTx(meter.BytesToAddress([]byte("autobid")), cb.RcvdMTR, auction.AUTO_BID, 0, 0),
+		auction.NewAuctionTx(meter.BytesToAddress([]byte("userbid")), userBid, auction.USER_BID, 0, 0),
+	}
+	c.RcvdMTR = new(big.Int).Add(cb.RcvdMTR, userBid)
+
+	a := auction.NewAuction(nil, nil)
+	env := auction.NewAuctionEnv(a, st, &xenv.TransactionContext{}, &auction.AuctionAccountAddr)
+	actualPrice, leftover, _, err := a.ClearAuction(&c, st, env)
+	require.Nil(t, err)
+	return actualPrice, leftover, st.GetEnergy(meter.ValidatorBenefitAddr)
+}
+
+func TestClearAuction(t *testing.T) {
+	benefitRatio := new(big.Int).Mul(big.NewInt(4), big.NewInt(1e17))
+	tests := []struct {
+		name      string
+		released  *big.Int
+		autobid   *big.Int
+		userBid   *big.Int
+		rsvdPrice *big.Int
+	}{
+		{"all sold", e18(1000), e18(200), e18(300), new(big.Int).Mul(big.NewInt(4), big.NewInt(1e17))},
+		{"reserved price", e18(1000), e18(200), e18(300), e18(1)},
+		{"no user bid", e18(1000), e18(200), big.NewInt(0), e18(1)},
+		{"nothing released", big.NewInt(0), e18(200), e18(300), e18(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := newTestAuctionCB(tt.released, tt.autobid, tt.rsvdPrice)
+			actualPrice, leftover, benefit := clearAuction(t, cb, tt.userBid, benefitRatio)
+
+			s := &simulator{
+				opts:         &simOptions{},
+				benefitRatio: benefitRatio,
+				cb:           cb,
+				summaries:    make([]*auction.AuctionSummary, 0),
+				userBid:      tt.userBid,
+			}
+			summary, simBenefit := s.clearAuction()
+			assert.Equal(t, actualPrice, summary.ActualPrice)
+			assert.Equal(t, leftover, summary.LeftoverMTRG)
+			assert.Equal(t, benefit, simBenefit)
+			assert.Equal(t, new(big.Int).Add(tt.autobid, tt.userBid), summary.RcvdMTR)
+			assert.Equal(t, []*auction.AuctionSummary{summary}, s.summaries)
+		})
+	}
+}
+
+func TestStep(t *testing.T) {
+	benefitRatio := new(big.Int).Mul(big.NewInt(4), big.NewInt(1e17))
+	rsvdPrice := new(big.Int).Mul(big.NewInt(5), big.NewInt(1e17))
+	userBid := e18(300)
+
+	delegates := make([]*types.Delegate, 0)
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.Nil(t, err)
+		d := types.NewDelegate([]byte(fmt.Sprintf("delegate%v", i)), meter.BytesToAddress(key.X.Bytes()), key.PublicKey, bls.PublicKey{}, 1000, 0)
+		d.DistList = []*types.Distributor{{Address: d.Address, Autobid: 100, Shares: 1e09}}
+		delegates = append(delegates, d)
+	}
+
+	cb := newTestAuctionCB(e18(1000), e18(200), rsvdPrice)
+	s := &simulator{
+		opts:          &simOptions{CommitteeSize: 3, Participation: 1},
+		epoch:         cb.EndEpoch + meter.NEpochPerAuction - 2,
+		baseReward:    e18(1000),
+		benefitRatio:  benefitRatio,
+		reservedPrice: rsvdPrice,
+		delegates:     delegates,
+		cb:            cb,
+		summaries:     make([]*auction.AuctionSummary, 0),
+		userBid:       userBid,
+		rand:          rand.New(rand.NewSource(1)),
+	}
+
+	// the autobids go to the active auction until it is cleared
+	r, err := s.step()
+	require.Nil(t, err)
+	assert.Nil(t, r.Closed)
+	assert.Equal(t, uint64(1), r.Sequence)
+	assert.True(t, r.Autobid.Sign() > 0)
+	assert.Equal(t, new(big.Int).Add(e18(200), r.Autobid), r.Received)
+
+	// cleared with the same math as ClearAuction, then the next auction starts
+	actualPrice, leftover, benefit := clearAuction(t, newTestAuctionCB(e18(1000), r.Received, rsvdPrice), userBid, benefitRatio)
+	r, err = s.step()
+	require.Nil(t, err)
+	require.NotNil(t, r.Closed)
+	assert.Equal(t, uint64(1), r.Closed.Sequence)
+	assert.Equal(t, actualPrice, r.Closed.ActualPrice)
+	assert.Equal(t, leftover, r.Closed.LeftoverMTRG)
+	assert.Equal(t, benefit, r.Benefit)
+	assert.Equal(t, uint64(2), r.Sequence)
+	assert.Equal(t, r.Autobid, r.Received)
+	assert.Equal(t, s.epoch, s.cb.EndEpoch)
+}
+
+func TestSortDelegates(t *testing.T) {
+	delegates := []*api_staking.Delegate{
+		{Name: "low", PubKey: "key1", VotingPower: e18(1).String()},
+		{Name: "none", PubKey: "key2", VotingPower: "0"},
+		{Name: "high", PubKey: "key3", VotingPower: e18(3).String()},
+		{Name: "tie-low-key", PubKey: "key4", VotingPower: e18(2).String()},
+		{Name: "tie-high-key", PubKey: "key5", VotingPower: e18(2).String()},
+	}
+	sorted, err := sortDelegates(delegates)
+	require.Nil(t, err)
+
+	names := make([]string, 0)
+	for _, d := range sorted {
+		names = append(names, d.Name)
+	}
+	assert.Equal(t, []string{"high", "tie-high-key", "tie-low-key", "low"}, names)
+
+	_, err = sortDelegates([]*api_staking.Delegate{{Name: "bad", VotingPower: "x"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	api_auction "github.com/dfinlab/meter/api/auction"
+	api_staking "github.com/dfinlab/meter/api/staking"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/genesis"
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/reward"
+	"github.com/dfinlab/meter/script/auction"
+	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/state"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// Snapshot is the staking state the simulation starts from. Delegates and auctions are in the
+// same format as the staking and auction APIs, so a snapshot can also be put together from them.
+type Snapshot struct {
+	Number        uint32                        `json:"number"`
+	Epoch         uint64                        `json:"epoch"`
+	BaseReward    string                        `json:"validatorBaseReward"`
+	BenefitRatio  string                        `json:"validatorBenefitRatio"`
+	ReservedPrice string                        `json:"auctionReservedPrice"`
+	Delegates     []*api_staking.Delegate       `json:"delegates"`
+	Auction       *api_auction.AuctionCB        `json:"auction"` // the active auction, if any
+	Summaries     []*api_auction.AuctionSummary `json:"summaries"`
+}
+
+func loadSnapshotFile(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, errors.WithMessage(err, "decode snapshot")
+	}
+	return &snap, nil
+}
+
+func saveSnapshotFile(path string, snap *Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// loadSnapshotFromDB reads the staking state of the node database at the revision.
+func loadSnapshotFromDB(ctx *cli.Context) (*Snapshot, error) {
+	var gene *genesis.Genesis
+	switch network := ctx.String(networkFlag.Name); network {
+	case "test", "warringstakes":
+		gene = genesis.NewTestnet()
+	case "main", "main-private":
+		gene = genesis.NewMainnet()
+	default:
+		return nil, fmt.Errorf("unrecognized value '%s' for flag -%s", network, networkFlag.Name)
+	}
+
+	instanceDir := filepath.Join(ctx.String(dataDirFlag.Name), fmt.Sprintf("instance-%x", gene.ID().Bytes()[24:]))
+	dbDir := filepath.Join(instanceDir, "main.db")
+	if _, err := os.Stat(dbDir); err != nil {
+		return nil, errors.WithMessage(err, "open chain database")
+	}
+	mainDB, err := lvldb.New(dbDir, lvldb.Options{CacheSize: 128, OpenFilesCacheCapacity: 64})
+	if err != nil {
+		return nil, errors.WithMessage(err, "open chain database")
+	}
+	defer mainDB.Close()
+
+	stateCreator := state.NewCreator(mainDB)
+	genesisBlock, _, err := gene.Build(stateCreator)
+	if err != nil {
+		return nil, errors.WithMessage(err, "build genesis block")
+	}
+	chain, err := chain.New(mainDB, genesisBlock, false)
+	if err != nil {
+		return nil, errors.WithMessage(err, "initialize block chain")
+	}
+	blk, err := parseRevision(chain, ctx.String(revisionFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	st, err := stateCreator.NewState(blk.Header().StateRoot())
+	if err != nil {
+		return nil, err
+	}
+
+	// the state readers don't touch the module fields, no need to start the script engine
+	delegates := new(staking.Staking).GetDelegateList(st)
+	cb := new(auction.Auction).GetAuctionCB(st)
+	summaries := new(auction.Auction).GetSummaryList(st)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{
+		Number:        blk.Header().Number(),
+		Epoch:         blk.GetBlockEpoch(),
+		BaseReward:    reward.GetValidatorBaseRewards(st).String(),
+		BenefitRatio:  reward.GetValidatorBenefitRatio(st).String(),
+		ReservedPrice: builtin.Params.Native(st).Get(meter.KeyAuctionReservedPrice).String(),
+		Delegates:     make([]*api_staking.Delegate, 0),
+		Summaries:     make([]*api_auction.AuctionSummary, 0),
+	}
+	for _, d := range delegates.GetDelegates() {
+		snap.Delegates = append(snap.Delegates, convertDelegate(d))
+	}
+	if cb.IsActive() {
+		snap.Auction = convertAuctionCB(cb)
+	}
+	for _, s := range summaries.Summaries {
+		snap.Summaries = append(snap.Summaries, convertSummary(s))
+	}
+	return snap, nil
+}
+
+// parseRevision returns the block of the revision, which can be best, block number or block id.
+func parseRevision(chain *chain.Chain, revision string) (*block.Block, error) {
+	if revision == "" || revision == "best" {
+		return chain.BestBlock(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		blockID, err := meter.ParseBytes32(revision)
+		if err != nil {
+			return nil, errors.WithMessage(err, "revision")
+		}
+		return chain.GetBlock(blockID)
+	}
+	n, err := strconv.ParseUint(revision, 0, 32)
+	if err != nil {
+		return nil, errors.WithMessage(err, "revision")
+	}
+	return chain.GetTrunkBlock(uint32(n))
+}
+
+func convertDelegate(d *staking.Delegate) *api_staking.Delegate {
+	dists := make([]*api_staking.Distributor, 0)
+	for _, dist := range d.DistList {
+		dists = append(dists, &api_staking.Distributor{
+			Address: dist.Address,
+			Autobid: dist.Autobid,
+			Shares:  dist.Shares,
+		})
+	}
+	return &api_staking.Delegate{
+		Name:        string(bytes.Trim(d.Name, "\x00")),
+		Address:     d.Address,
+		PubKey:      string(d.PubKey),
+		VotingPower: d.VotingPower.String(),
+		IPAddr:      string(d.IPAddr),
+		Port:        d.Port,
+		Commission:  d.Commission,
+		DistList:    dists,
+	}
+}
+
+func convertAuctionTxs(txs []*auction.AuctionTx) []*api_auction.AuctionTx {
+	result := make([]*api_auction.AuctionTx, 0)
+	for _, t := range txs {
+		bidType := "autobid"
+		if t.Type == auction.USER_BID {
+			bidType = "userbid"
+		}
+		result = append(result, &api_auction.AuctionTx{
+			TxID:      t.TxID.String(),
+			Address:   t.Address.String(),
+			Amount:    t.Amount.String(),
+			Type:      bidType,
+			Timestamp: t.Timestamp,
+			Nonce:     t.Nonce,
+		})
+	}
+	return result
+}
+
+func convertAuctionCB(cb *auction.AuctionCB) *api_auction.AuctionCB {
+	return &api_auction.AuctionCB{
+		AuctionID:   cb.AuctionID.String(),
+		StartHeight: cb.StartHeight,
+		StartEpoch:  cb.StartEpoch,
+		EndHeight:   cb.EndHeight,
+		EndEpoch:    cb.EndEpoch,
+		Sequence:    cb.Sequence,
+		RlsdMTRG:    cb.RlsdMTRG.String(),
+		RsvdMTRG:    cb.RsvdMTRG.String(),
+		RsvdPrice:   cb.RsvdPrice.String(),
+		CreateTime:  cb.CreateTime,
+		RcvdMTR:     cb.RcvdMTR.String(),
+		AuctionTxs:  convertAuctionTxs(cb.AuctionTxs),
+	}
+}
+
+func convertSummary(s *auction.AuctionSummary) *api_auction.AuctionSummary {
+	return &api_auction.AuctionSummary{
+		AuctionID:    s.AuctionID.String(),
+		StartHeight:  s.StartHeight,
+		StartEpoch:   s.StartEpoch,
+		EndHeight:    s.EndHeight,
+		EndEpoch:     s.EndEpoch,
+		Sequence:     s.Sequence,
+		RlsdMTRG:     s.RlsdMTRG.String(),
+		RsvdMTRG:     s.RsvdMTRG.String(),
+		RsvdPrice:    s.RsvdPrice.String(),
+		CreateTime:   s.CreateTime,
+		RcvdMTR:      s.RcvdMTR.String(),
+		ActualPrice:  s.ActualPrice.String(),
+		LeftoverMTRG: s.LeftoverMTRG.String(),
+		AuctionTxs:   convertAuctionTxs(s.AuctionTxs),
+	}
+}
+
+// parseAmount parses a decimal amount of the snapshot, empty is treated as 0.
+func parseAmount(name, s string) (*big.Int, error) {
+	if s == "" {
+		return big.NewInt(0), nil
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v: %v", name, s)
+	}
+	return v, nil
+}
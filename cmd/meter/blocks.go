@@ -0,0 +1,226 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dfinlab/meter/api/doc"
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/cmd/meter/node"
+	"github.com/dfinlab/meter/consensus"
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/script"
+	"github.com/dfinlab/meter/signer"
+	"github.com/dfinlab/meter/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	exportBlocksCommand = cli.Command{
+		Name:      "export-blocks",
+		Usage:     "export trunk blocks in rlp, including QC and committee info",
+		ArgsUsage: "<file>",
+		Flags:     []cli.Flag{networkFlag, dataDirFlag, verbosityFlag, fromBlockFlag, toBlockFlag},
+		Action:    exportBlocksAction,
+	}
+	importBlocksCommand = cli.Command{
+		Name:      "import-blocks",
+		Usage:     "import blocks exported by export-blocks, blocks are re-executed and verified",
+		ArgsUsage: "<file>",
+		Flags: []cli.Flag{
+			networkFlag,
+			dataDirFlag,
+			verbosityFlag,
+			forceLastKFrameFlag,
+			skipSignatureCheckFlag,
+			minCommitteeSizeFlag,
+			maxCommitteeSizeFlag,
+			maxDelegateSizeFlag,
+			discoTopicFlag,
+			initCfgdDelegatesFlag,
+			epochBlockCountFlag,
+		},
+		Action: importBlocksAction,
+	}
+)
+
+func exportBlocksAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("file path required")
+	}
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer mainDB.Close()
+
+	genesisBlock, _, err := gene.Build(state.NewCreator(mainDB))
+	if err != nil {
+		return errors.WithMessage(err, "build genesis block")
+	}
+	chain, err := chain.New(mainDB, genesisBlock, false)
+	if err != nil {
+		return errors.WithMessage(err, "initialize block chain")
+	}
+
+	from := uint32(ctx.Uint64(fromBlockFlag.Name))
+	to := chain.BestBlock().Header().Number()
+	if ctx.IsSet(toBlockFlag.Name) && uint32(ctx.Uint64(toBlockFlag.Name)) < to {
+		to = uint32(ctx.Uint64(toBlockFlag.Name))
+	}
+	if from > to {
+		return fmt.Errorf("invalid range [%v, %v]", from, to)
+	}
+
+	file, err := os.Create(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	start := time.Now()
+	reported := start
+	for num := from; num <= to; num++ {
+		blk, err := chain.GetTrunkBlock(num)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("get block %v", num))
+		}
+		if err := rlp.Encode(w, blk); err != nil {
+			return err
+		}
+		if time.Since(reported) > time.Second*2 {
+			fmt.Printf("exported blocks up to %v/%v\n", num, to)
+			reported = time.Now()
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("exported blocks [%v, %v] in %v\n", from, to, time.Since(start))
+	return nil
+}
+
+// newImportMaster makes a throwaway node master for importing blocks. Imported blocks are
+// only verified, nothing is proposed or signed, so the node keys are not loaded.
+func newImportMaster() (*node.Master, *consensus.BlsCommon, error) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	params, pairing, system, err := getBlsParams()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "load bls params")
+	}
+	blsPubKey, blsPrivKey, err := bls.GenKeys(system)
+	if err != nil {
+		return nil, nil, err
+	}
+	master := &node.Master{PrivateKey: privKey, PublicKey: &privKey.PublicKey}
+	master.Signer = signer.NewLocal(privKey, blsPrivKey, blsPubKey, system, nil)
+	return master, consensus.NewBlsCommonFromParams(blsPubKey, blsPrivKey, system, params, pairing), nil
+}
+
+func importBlocksAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("file path required")
+	}
+	exitSignal := handleExitSignal()
+	initLogger(ctx)
+
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB, false)
+	master, blsCommon, err := newImportMaster()
+	if err != nil {
+		return err
+	}
+
+	loadPresetConfig(ctx)
+	meter.InitBlockChainConfig(gene.ID(), ctx.String(networkFlag.Name))
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v %v", doc.Version(), ctx.String(discoTopicFlag.Name))))
+	copy(consensusMagic[:], sum[:4])
+	initDelegates := loadDelegates(ctx, blsCommon)
+
+	stateCreator := state.NewCreator(mainDB)
+	sc := script.NewScriptEngine(chain, stateCreator)
+	cons := consensus.NewConsensusReactor(ctx, chain, stateCreator, master.Signer, nil, consensusMagic, blsCommon, initDelegates)
+	n := node.New(master, chain, stateCreator, logDB, nil, "", nil, cons, sc)
+
+	file, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	importCtx, cancel := context.WithCancel(exitSignal)
+	defer cancel()
+
+	stream := make(chan *block.Block, 64)
+	var (
+		readErr error
+		skipped int
+	)
+	go func() {
+		defer close(stream)
+		s := rlp.NewStream(bufio.NewReader(file), 0)
+		for {
+			var blk block.Block
+			if err := s.Decode(&blk); err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+			// resume from the best block
+			if blk.Header().Number() <= chain.BestBlock().Header().Number() {
+				skipped++
+				continue
+			}
+			select {
+			case stream <- &blk:
+			case <-importCtx.Done():
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	err = n.ImportBlocks(importCtx, stream)
+	cancel()
+	for range stream {
+		// drain to let the reader exit
+	}
+	if err != nil {
+		return err
+	}
+	if readErr != nil {
+		return errors.WithMessage(readErr, "read blocks")
+	}
+	best := chain.BestBlock().Header()
+	fmt.Printf("imported blocks up to %v %v in %v, %v known blocks skipped\n", best.Number(), best.ID(), time.Since(start), skipped)
+	return nil
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/logdb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/script/auction"
+	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/state"
+	"github.com/dfinlab/meter/tx"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var dbCommand = cli.Command{
+	Name:  "db",
+	Usage: "database maintenance tools",
+	Subcommands: []cli.Command{
+		{
+			Name:   "check",
+			Usage:  "verify trunk blocks, receipts, tx metas, state roots and logs, optionally rewind to the last consistent block",
+			Flags:  []cli.Flag{networkFlag, dataDirFlag, verbosityFlag, repairFlag},
+			Action: dbCheckAction,
+		},
+		{
+			Name:   "backfill",
+			Usage:  "index the logs added by upgrades for trunk blocks committed before them",
+			Flags:  []cli.Flag{networkFlag, dataDirFlag, verbosityFlag, fromBlockFlag, toBlockFlag},
+			Action: dbBackfillAction,
+		},
+	},
+}
+
+// newBackfillers returns the indexers of the logs of a trunk block, rows are replaced so they can run again.
+func newBackfillers(chain *chain.Chain, stateCreator *state.Creator) []func(batch *logdb.BlockBatch, blk *block.Block) error {
+	return []func(batch *logdb.BlockBatch, blk *block.Block) error{
+		func(batch *logdb.BlockBatch, blk *block.Block) error {
+			return auction.IndexClosedAuction(batch, blk.Header())
+		},
+		func(batch *logdb.BlockBatch, blk *block.Block) error {
+			if len(blk.Transactions()) == 0 {
+				return nil
+			}
+			receipts, err := chain.GetBlockReceipts(blk.Header().ID())
+			if err != nil {
+				return errors.WithMessage(err, "get receipts")
+			}
+			parentHeader, err := chain.GetBlockHeader(blk.Header().ParentID())
+			if err != nil {
+				return errors.WithMessage(err, "get parent header")
+			}
+			parentState, err := stateCreator.NewState(parentHeader.StateRoot())
+			if err != nil {
+				return errors.WithMessage(err, "open parent state")
+			}
+			for i, tx := range blk.Transactions() {
+				origin, err := tx.Signer()
+				if err != nil {
+					return errors.WithMessage(err, "recover tx signer")
+				}
+				batch.InsertTx(tx, origin, receipts[i], parentState)
+				for _, output := range receipts[i].Outputs {
+					batch.InsertTokenTransfers(tx.ID(), origin, output.Events)
+					batch.InsertJailEvents(output.Events)
+				}
+			}
+			return nil
+		},
+		// jail events are emitted since tesla fork4, the jail history before is from the jail lists
+		func(batch *logdb.BlockBatch, blk *block.Block) error {
+			header := blk.Header()
+			if len(blk.Transactions()) == 0 || header.Number() == 0 || meter.BlockChainConfig.IsTeslaFork4(header.Number()) {
+				return nil
+			}
+			parentHeader, err := chain.GetBlockHeader(header.ParentID())
+			if err != nil {
+				return errors.WithMessage(err, "get parent header")
+			}
+			parentState, err := stateCreator.NewState(parentHeader.StateRoot())
+			if err != nil {
+				return errors.WithMessage(err, "open parent state")
+			}
+			state, err := stateCreator.NewState(header.StateRoot())
+			if err != nil {
+				return errors.WithMessage(err, "open state")
+			}
+			s := staking.GetStakingGlobInst()
+			list := s.GetInJailList(state)
+			parentList := s.GetInJailList(parentState)
+			for _, j := range list.ToList() {
+				if p := parentList.Get(j.Addr); p != nil && p.JailedTime == j.JailedTime {
+					continue
+				}
+				batch.InsertJailRecord(newJailRecord(&j, logdb.JailIn))
+			}
+			for _, p := range parentList.ToList() {
+				if !list.Exist(p.Addr) {
+					batch.InsertJailRecord(newJailRecord(&p, logdb.JailOut))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newJailRecord(d *staking.DelegateJailed, action logdb.JailAction) *logdb.JailRecord {
+	return &logdb.JailRecord{
+		Address:     d.Addr,
+		Name:        d.Name,
+		Action:      action,
+		TotalPoints: d.TotalPts,
+		BailAmount:  d.BailAmount,
+		JailedTime:  d.JailedTime,
+	}
+}
+
+func dbBackfillAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer mainDB.Close()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer logDB.Close()
+
+	stateCreator := state.NewCreator(mainDB)
+	genesisBlock, _, err := gene.Build(stateCreator)
+	if err != nil {
+		return errors.WithMessage(err, "build genesis block")
+	}
+	chain, err := chain.New(mainDB, genesisBlock, false)
+	if err != nil {
+		return errors.WithMessage(err, "initialize block chain")
+	}
+	meter.InitBlockChainConfig(gene.ID(), ctx.String(networkFlag.Name))
+	// the indexers read the module states through the global instances
+	auction.NewAuction(chain, stateCreator)
+	staking.NewStaking(chain, stateCreator)
+
+	from := uint32(ctx.Uint64(fromBlockFlag.Name))
+	to := chain.BestBlock().Header().Number()
+	if ctx.IsSet(toBlockFlag.Name) && uint32(ctx.Uint64(toBlockFlag.Name)) < to {
+		to = uint32(ctx.Uint64(toBlockFlag.Name))
+	}
+	if from > to {
+		return fmt.Errorf("invalid range [%v, %v]", from, to)
+	}
+
+	backfillers := newBackfillers(chain, stateCreator)
+	start := time.Now()
+	reported := start
+	for num := from; num <= to; num++ {
+		blk, err := chain.GetTrunkBlock(num)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("get block %v", num))
+		}
+		batch := logDB.Prepare(blk.Header())
+		for _, backfill := range backfillers {
+			if err := backfill(batch, blk); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("backfill block %v", num))
+			}
+		}
+		if err := batch.Commit(); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("commit logs of block %v", num))
+		}
+		if time.Since(reported) > time.Second*2 {
+			fmt.Printf("backfilled blocks up to %v/%v\n", num, to)
+			reported = time.Now()
+		}
+	}
+	fmt.Printf("backfilled blocks [%v, %v] in %v\n", from, to, time.Since(start))
+	return nil
+}
+
+func dbCheckAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer mainDB.Close()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer logDB.Close()
+
+	checker, err := chain.NewChecker(mainDB)
+	if err != nil {
+		return err
+	}
+	bestNum := block.Number(checker.BestBlockID())
+	fmt.Printf("checking blocks [0, %v], best block %v\n", bestNum, checker.BestBlockID())
+
+	var (
+		lastGood *block.Block
+		parentID meter.Bytes32
+		badErr   error
+	)
+	start := time.Now()
+	reported := start
+	for num := uint32(0); num <= bestNum; num++ {
+		id, err := checker.GetTrunkBlockID(num)
+		if err != nil {
+			badErr = errors.WithMessage(err, fmt.Sprintf("get trunk block id #%v", num))
+			break
+		}
+		if num == 0 && id != gene.ID() {
+			return errors.New("genesis mismatch")
+		}
+		blk, receipts, err := checker.CheckBlock(id, parentID)
+		if err == nil && num > 0 {
+			err = checkBlockLogs(logDB, blk, receipts)
+		}
+		if err != nil {
+			badErr = errors.WithMessage(err, fmt.Sprintf("block #%v %v", num, id))
+			break
+		}
+		lastGood = blk
+		parentID = id
+
+		if time.Since(reported) > time.Second*2 {
+			fmt.Printf("checked blocks up to %v/%v\n", num, bestNum)
+			reported = time.Now()
+		}
+	}
+
+	if badErr == nil {
+		fmt.Printf("all %v blocks are consistent, elapsed %v\n", bestNum+1, time.Since(start))
+		if mainDB.Recovered() {
+			// entries not reached by the check, e.g. states of old blocks, may still be lost
+			return errors.New("chain database was corrupted and has been recovered, data not covered by the check may be lost")
+		}
+		return nil
+	}
+	fmt.Println("inconsistency found:", badErr)
+	if lastGood == nil {
+		return errors.New("genesis block is inconsistent, resync required")
+	}
+	if !ctx.Bool(repairFlag.Name) {
+		return fmt.Errorf("run with --%v to rewind to block #%v %v", repairFlag.Name, lastGood.Header().Number(), lastGood.Header().ID())
+	}
+
+	if err := checker.Rewind(lastGood); err != nil {
+		return errors.WithMessage(err, "rewind chain")
+	}
+	if err := logDB.Truncate(lastGood.Header().Number()); err != nil {
+		return errors.WithMessage(err, "truncate logs")
+	}
+	fmt.Printf("rewound to block #%v %v\n", lastGood.Header().Number(), lastGood.Header().ID())
+	return nil
+}
+
+// checkBlockLogs verifies that logs indexed for the block match its receipts.
+func checkBlockLogs(logDB *logdb.LogDB, blk *block.Block, receipts tx.Receipts) error {
+	var events, transfers int
+	for _, r := range receipts {
+		for _, o := range r.Outputs {
+			events += len(o.Events)
+			transfers += len(o.Transfers)
+		}
+	}
+
+	counts, err := logDB.CountLogs(context.Background(), blk.Header().Number())
+	if err != nil {
+		return errors.WithMessage(err, "count logs")
+	}
+	found := false
+	for _, c := range counts {
+		if c.BlockID != blk.Header().ID() {
+			return fmt.Errorf("logs of non-trunk block %v indexed", c.BlockID)
+		}
+		if c.Events != events || c.Transfers != transfers {
+			return fmt.Errorf("logs mismatch: want %v events %v transfers, got %v events %v transfers", events, transfers, c.Events, c.Transfers)
+		}
+		found = true
+	}
+	if !found && events+transfers > 0 {
+		return errors.New("logs missing")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/state"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var dumpStateCommand = cli.Command{
+	Name:   "dump-state",
+	Usage:  "dump accounts and storage at the given revision as JSON lines",
+	Flags:  []cli.Flag{networkFlag, dataDirFlag, revisionFlag},
+	Action: dumpStateAction,
+}
+
+// dumpAccount is a line of the state dump.
+type dumpAccount struct {
+	Address      *meter.Address        `json:"address"` // nil if the preimage is not recorded
+	AddressHash  meter.Bytes32         `json:"addressHash"`
+	Balance      *math.HexOrDecimal256 `json:"balance"`
+	Energy       *math.HexOrDecimal256 `json:"energy"`
+	BoundBalance *math.HexOrDecimal256 `json:"boundBalance"`
+	BoundEnergy  *math.HexOrDecimal256 `json:"boundEnergy"`
+	Master       *meter.Address        `json:"master,omitempty"`
+	CodeHash     meter.Bytes32         `json:"codeHash"`
+	// storage key, or hashed key if the preimage is not recorded -> value
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+func dumpStateAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer mainDB.Close()
+
+	genesisBlock, _, err := gene.Build(state.NewCreator(mainDB))
+	if err != nil {
+		return errors.WithMessage(err, "build genesis block")
+	}
+	chain, err := chain.New(mainDB, genesisBlock, false)
+	if err != nil {
+		return errors.WithMessage(err, "initialize block chain")
+	}
+	header, err := parseRevision(chain, ctx.String(revisionFlag.Name))
+	if err != nil {
+		return err
+	}
+	st, err := state.NewCreator(mainDB).NewState(header.StateRoot())
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	var rangeErr error
+	if err := st.RangeAccounts(nil, func(hash meter.Bytes32, addr *meter.Address, acc *state.Account) bool {
+		line := &dumpAccount{
+			Address:      addr,
+			AddressHash:  hash,
+			Balance:      (*math.HexOrDecimal256)(acc.Balance),
+			Energy:       (*math.HexOrDecimal256)(acc.Energy),
+			BoundBalance: (*math.HexOrDecimal256)(acc.BoundBalance),
+			BoundEnergy:  (*math.HexOrDecimal256)(acc.BoundEnergy),
+			CodeHash:     meter.BytesToBytes32(acc.CodeHash),
+		}
+		if len(acc.Master) > 0 {
+			master := meter.BytesToAddress(acc.Master)
+			line.Master = &master
+		}
+		if rangeErr = st.RangeStorage(acc, nil, func(hash meter.Bytes32, key *meter.Bytes32, value rlp.RawValue) bool {
+			if line.Storage == nil {
+				line.Storage = make(map[string]string)
+			}
+			k := hash
+			if key != nil {
+				k = *key
+			}
+			line.Storage[k.String()] = storageString(value)
+			return true
+		}); rangeErr != nil {
+			return false
+		}
+		rangeErr = enc.Encode(line)
+		return rangeErr == nil
+	}); err != nil {
+		return err
+	}
+	if rangeErr != nil {
+		return rangeErr
+	}
+	return st.Err()
+}
+
+// storageString formats the storage value as bytes32, or raw rlp if it's customized.
+func storageString(value rlp.RawValue) string {
+	kind, content, _, err := rlp.Split(value)
+	if err != nil || kind == rlp.List {
+		return hexutil.Encode(value)
+	}
+	return meter.BytesToBytes32(content).String()
+}
+
+// parseRevision returns the block header of the revision, which can be best, block
+// number or block id.
+func parseRevision(chain *chain.Chain, revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return chain.BestBlock().Header(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		blockID, err := meter.ParseBytes32(revision)
+		if err != nil {
+			return nil, errors.WithMessage(err, "revision")
+		}
+		return chain.GetBlockHeader(blockID)
+	}
+	n, err := strconv.ParseUint(revision, 0, 32)
+	if err != nil {
+		return nil, errors.WithMessage(err, "revision")
+	}
+	return chain.GetTrunkBlockHeader(uint32(n))
+}
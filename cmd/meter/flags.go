@@ -44,6 +44,28 @@ var (
 		Value: 50000000,
 		Usage: "limit contract call gas",
 	}
+	apiEnableCustomTracerFlag = cli.BoolFlag{
+		Name:  "api-enable-custom-tracer",
+		Usage: "allow custom JavaScript tracer code in debug APIs",
+	}
+	apiTracerStepLimitFlag = cli.IntFlag{
+		Name:  "api-tracer-step-limit",
+		Value: 10000000,
+		Usage: "limit the number of steps traced by custom tracer code (0 = unlimited)",
+	}
+	apiTracerTimeoutFlag = cli.IntFlag{
+		Name:  "api-tracer-timeout",
+		Value: 5000,
+		Usage: "custom tracer code execution timeout value in milliseconds (0 = unlimited)",
+	}
+	recordPreimagesFlag = cli.BoolFlag{
+		Name:  "record-preimages",
+		Usage: "record preimages of hashed addresses and storage keys, for state dump and iteration",
+	}
+	disableSnapshotFlag = cli.BoolFlag{
+		Name:  "disable-snapshot",
+		Usage: "disable the flat state snapshot for fast state reads",
+	}
 	apiBacktraceLimitFlag = cli.IntFlag{
 		Name:  "api-backtrace-limit",
 		Value: 1000,
@@ -94,6 +116,35 @@ var (
 		Name:  "export",
 		Usage: "export master key to keystore",
 	}
+	exportBlsKeyFlag = cli.BoolFlag{
+		Name:  "with-bls",
+		Usage: "export BLS key along with ECDSA key, in the master keystore format instead of v3 keystore",
+	}
+	encryptMasterKeyFlag = cli.BoolFlag{
+		Name:  "encrypt",
+		Usage: "encrypt the plaintext master key in place",
+	}
+	masterKeyPasswordFileFlag = cli.StringFlag{
+		Name:  "password-file",
+		Usage: "file containing the passphrase of encrypted master key",
+	}
+	signerURLFlag = cli.StringFlag{
+		Name:  "signer-url",
+		Usage: "url of the remote signer holding the master key, e.g. https://10.0.0.2:8680",
+	}
+	signerSecretFileFlag = cli.StringFlag{
+		Name:  "signer-secret-file",
+		Usage: "file containing the secret shared with the remote signer",
+	}
+	signerAddrFlag = cli.StringFlag{
+		Name:  "signer-addr",
+		Value: "localhost:8680",
+		Usage: "remote signer listening address",
+	}
+	signerInsecureFlag = cli.BoolFlag{
+		Name:  "signer-insecure",
+		Usage: "allow the remote signer to be served and reached over plain http",
+	}
 	generateKFrameFlag = cli.BoolFlag{
 		Name:  "gen-kframe",
 		Usage: "start a coroutine for kframe generation (FOR TEST ONLY)",
@@ -173,4 +224,34 @@ var (
 		Usage: "path for https key file (default is meterio.key)",
 		Value: "meterio.key",
 	}
+	paramKeyFlag = cli.StringFlag{
+		Name:  "key",
+		Usage: "governance param key, by name (e.g. KeyBaseGasPrice), text or hex",
+	}
+	paramValueFlag = cli.StringFlag{
+		Name:  "value",
+		Usage: "new value of the governance param, decimal or hex",
+	}
+	proposalIDFlag = cli.StringFlag{
+		Name:  "id",
+		Usage: "proposal id",
+	}
+	repairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "rewind the best block to the last consistent block",
+	}
+	fromBlockFlag = cli.Uint64Flag{
+		Name:  "from",
+		Value: 1,
+		Usage: "number of the first block",
+	}
+	toBlockFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "number of the last block (default: best block)",
+	}
+	revisionFlag = cli.StringFlag{
+		Name:  "revision",
+		Value: "best",
+		Usage: "block revision, can be best, block number or block id",
+	}
 )
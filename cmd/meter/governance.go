@@ -0,0 +1,120 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var governanceCommand = cli.Command{
+	Name:  "governance",
+	Usage: "draft clauses for on-chain governance through the executor contract",
+	Subcommands: []cli.Command{
+		{
+			Name:   "propose",
+			Usage:  "draft a proposal to set a governance param",
+			Flags:  []cli.Flag{paramKeyFlag, paramValueFlag},
+			Action: proposeParamAction,
+		},
+		{
+			Name:   "approve",
+			Usage:  "draft an approval of a proposal",
+			Flags:  []cli.Flag{proposalIDFlag},
+			Action: proposalAction("approve"),
+		},
+		{
+			Name:   "execute",
+			Usage:  "draft an execution of an approved proposal",
+			Flags:  []cli.Flag{proposalIDFlag},
+			Action: proposalAction("execute"),
+		},
+		{
+			Name:   "keys",
+			Usage:  "list known governance param keys",
+			Action: paramKeysAction,
+		},
+	},
+}
+
+// draftClause is the clause to be signed and sent to the executor contract.
+type draftClause struct {
+	To    meter.Address `json:"to"`
+	Value string        `json:"value"`
+	Data  string        `json:"data"`
+}
+
+func printDraftClause(data []byte) error {
+	out, err := json.MarshalIndent(&draftClause{
+		To:    builtin.Executor.Address,
+		Value: "0x0",
+		Data:  hexutil.Encode(data),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func proposeParamAction(ctx *cli.Context) error {
+	if !ctx.IsSet(paramKeyFlag.Name) || !ctx.IsSet(paramValueFlag.Name) {
+		return fmt.Errorf("flag %s and %s are required", paramKeyFlag.Name, paramValueFlag.Name)
+	}
+	key, err := meter.ParseParamKey(ctx.String(paramKeyFlag.Name))
+	if err != nil {
+		return errors.WithMessage(err, "key")
+	}
+	value, ok := math.ParseBig256(ctx.String(paramValueFlag.Name))
+	if !ok || value.Sign() < 0 {
+		return errors.New("value: invalid number")
+	}
+
+	set, _ := builtin.Params.ABI.MethodByName("set")
+	setData, err := set.EncodeInput(key, value)
+	if err != nil {
+		return err
+	}
+	propose, _ := builtin.Executor.ABI.MethodByName("propose")
+	data, err := propose.EncodeInput(builtin.Params.Address, setData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Proposal: set %v (%v) to %v\n", meter.ParamKeyName(key), key, value)
+	return printDraftClause(data)
+}
+
+func proposalAction(name string) func(ctx *cli.Context) error {
+	return func(ctx *cli.Context) error {
+		if !ctx.IsSet(proposalIDFlag.Name) {
+			return fmt.Errorf("flag %s is required", proposalIDFlag.Name)
+		}
+		id, err := meter.ParseBytes32(ctx.String(proposalIDFlag.Name))
+		if err != nil {
+			return errors.WithMessage(err, "id")
+		}
+		method, _ := builtin.Executor.ABI.MethodByName(name)
+		data, err := method.EncodeInput(id)
+		if err != nil {
+			return err
+		}
+		return printDraftClause(data)
+	}
+}
+
+func paramKeysAction(ctx *cli.Context) error {
+	for _, k := range meter.ParamKeys {
+		fmt.Printf("%-32v %v\n", k.Name, k.Key)
+	}
+	return nil
+}
@@ -17,7 +17,11 @@ import (
 
 	"github.com/dfinlab/meter/consensus"
 	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
@@ -59,6 +63,10 @@ type KeyLoader struct {
 	blsPrivKey   *bls.PrivateKey
 	blsPubKey    *bls.PublicKey
 
+	// passphrase to encrypt the master key, keys are saved in plaintext if empty
+	passphrase   string
+	passwordFile string
+
 	updated bool
 }
 
@@ -80,10 +88,57 @@ func NewKeyLoader(ctx *cli.Context) *KeyLoader {
 		masterBytes: masterBytes,
 		publicBytes: publicBytes,
 
+		passwordFile: ctx.String(masterKeyPasswordFileFlag.Name),
+
 		updated: false,
 	}
 }
 
+// unlock decrypts the master key if it's encrypted, so that it can be parsed in the plaintext format.
+func (k *KeyLoader) unlock() error {
+	if !isMasterKeystore(k.masterBytes) {
+		if len(k.masterBytes) == 0 {
+			// keys to be generated, encrypt them if password file given
+			if k.passwordFile != "" {
+				passphrase, err := readMasterKeyPassphrase(k.passwordFile, "", false)
+				if err != nil {
+					return err
+				}
+				k.passphrase = passphrase
+			}
+		} else if k.passphrase == "" {
+			fmt.Println("WARNING: master key is stored in plaintext, run `meter master-key --encrypt` to encrypt it")
+		}
+		return nil
+	}
+	passphrase, err := readMasterKeyPassphrase(k.passwordFile, "Enter passphrase to unlock master key: ", false)
+	if err != nil {
+		return err
+	}
+	priv, _, err := decryptMasterKey(k.masterBytes, passphrase)
+	if err != nil {
+		return errors.WithMessage(err, "unlock master key")
+	}
+	k.masterBytes = priv
+	k.passphrase = passphrase
+	return nil
+}
+
+// encrypt returns the loaded keys in the encrypted keystore format.
+func (k *KeyLoader) encrypt(passphrase string) ([]byte, error) {
+	address := meter.Address(crypto.PubkeyToAddress(k.ecdsaPrivKey.PublicKey))
+	return encryptMasterKey(k.masterBytes, k.publicBytes, address, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// encryptECDSA returns the ECDSA key in the Ethereum v3 keystore format.
+func (k *KeyLoader) encryptECDSA(passphrase string) ([]byte, error) {
+	return keystore.EncryptKey(&keystore.Key{
+		PrivateKey: k.ecdsaPrivKey,
+		Address:    crypto.PubkeyToAddress(k.ecdsaPrivKey.PublicKey),
+		Id:         uuid.NewRandom()},
+		passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
 func (k *KeyLoader) genECDSA() error {
 	k.updated = true
 	key, err := crypto.GenerateKey()
@@ -190,15 +245,32 @@ func (k *KeyLoader) saveKeys(system bls.System) error {
 	pub := strings.Join([]string{ecdsaPubB64, blsPubB64}, ":::")
 	k.masterBytes = []byte(priv)
 	k.publicBytes = []byte(pub)
-	err := ioutil.WriteFile(k.masterPath, []byte(priv+"\n"), 0600)
+	return k.writeKeys()
+}
+
+// writeKeys writes master key and public key files, the master key is encrypted if passphrase is set.
+func (k *KeyLoader) writeKeys() error {
+	master := []byte(string(k.masterBytes) + "\n")
+	if k.passphrase != "" {
+		data, err := k.encrypt(k.passphrase)
+		if err != nil {
+			return err
+		}
+		master = append(data, '\n')
+	}
+	err := ioutil.WriteFile(k.masterPath, master, 0600)
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(k.publicPath, []byte(pub+"\n"), 0600)
+	err = ioutil.WriteFile(k.publicPath, []byte(string(k.publicBytes)+"\n"), 0600)
 	return err
 }
 
 func (k *KeyLoader) Load() (*ecdsa.PrivateKey, *ecdsa.PublicKey, *consensus.BlsCommon, error) {
+	if err := k.unlock(); err != nil {
+		return nil, nil, nil, err
+	}
+
 	err := k.validateECDSA()
 	if err != nil {
 		fmt.Println("could not validate ecdsa keys, error:", err)
@@ -0,0 +1,198 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	masterKeystoreVersion = 1
+
+	keystoreScryptR     = 8
+	keystoreScryptDKLen = 32
+)
+
+var errKeystoreDecrypt = errors.New("could not decrypt key with given passphrase")
+
+// masterKeystore is the encrypted form of master key, which holds both ECDSA and BLS private keys.
+// The crypto section follows the Ethereum v3 keystore (scrypt + aes-128-ctr).
+type masterKeystore struct {
+	Address   meter.Address      `json:"address"`
+	PublicKey string             `json:"publicKey"` // same as the content of public.key
+	Crypto    keystoreCryptoJSON `json:"crypto"`
+	ID        string             `json:"id"`
+	Version   int                `json:"version"`
+}
+
+type keystoreCryptoJSON struct {
+	Cipher       string `json:"cipher"`
+	CipherText   string `json:"ciphertext"`
+	CipherParams struct {
+		IV string `json:"iv"`
+	} `json:"cipherparams"`
+	KDF       string `json:"kdf"`
+	KDFParams struct {
+		N     int    `json:"n"`
+		R     int    `json:"r"`
+		P     int    `json:"p"`
+		DKLen int    `json:"dklen"`
+		Salt  string `json:"salt"`
+	} `json:"kdfparams"`
+	MAC string `json:"mac"`
+}
+
+// isMasterKeystore returns whether the data is an encrypted master key.
+func isMasterKeystore(data []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return false
+	}
+	var ks masterKeystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return false
+	}
+	return ks.Version == masterKeystoreVersion && ks.Crypto.CipherText != ""
+}
+
+// encryptMasterKey encrypts the master key in the plaintext format (ECDSA and BLS private keys in base64 joined by ':::').
+func encryptMasterKey(priv []byte, pub []byte, address meter.Address, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, keystoreScryptR, scryptP, keystoreScryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], priv, iv)
+	if err != nil {
+		return nil, err
+	}
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	ks := masterKeystore{
+		Address:   address,
+		PublicKey: string(pub),
+		ID:        uuid.NewRandom().String(),
+		Version:   masterKeystoreVersion,
+	}
+	ks.Crypto.Cipher = "aes-128-ctr"
+	ks.Crypto.CipherText = hex.EncodeToString(cipherText)
+	ks.Crypto.CipherParams.IV = hex.EncodeToString(iv)
+	ks.Crypto.KDF = "scrypt"
+	ks.Crypto.KDFParams.N = scryptN
+	ks.Crypto.KDFParams.R = keystoreScryptR
+	ks.Crypto.KDFParams.P = scryptP
+	ks.Crypto.KDFParams.DKLen = keystoreScryptDKLen
+	ks.Crypto.KDFParams.Salt = hex.EncodeToString(salt)
+	ks.Crypto.MAC = hex.EncodeToString(mac)
+	return json.MarshalIndent(&ks, "", "  ")
+}
+
+// decryptMasterKey decrypts the encrypted master key, and returns it in the plaintext format.
+func decryptMasterKey(data []byte, passphrase string) ([]byte, *masterKeystore, error) {
+	var ks masterKeystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, nil, err
+	}
+	if ks.Version != masterKeystoreVersion {
+		return nil, nil, errors.New("unsupported keystore version")
+	}
+	if ks.Crypto.Cipher != "aes-128-ctr" || ks.Crypto.KDF != "scrypt" {
+		return nil, nil, errors.New("unsupported cipher or kdf")
+	}
+	mac, err := hex.DecodeString(ks.Crypto.MAC)
+	if err != nil {
+		return nil, nil, err
+	}
+	iv, err := hex.DecodeString(ks.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, nil, err
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, nil, err
+	}
+	salt, err := hex.DecodeString(ks.Crypto.KDFParams.Salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	params := ks.Crypto.KDFParams
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(derivedKey) < 32 || !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, nil, errKeystoreDecrypt
+	}
+	priv, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv, &ks, nil
+}
+
+func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(block, iv)
+	outText := make([]byte, len(inText))
+	stream.XORKeyStream(outText, inText)
+	return outText, nil
+}
+
+// readMasterKeyPassphrase reads passphrase from the password file if given, or from the terminal.
+func readMasterKeyPassphrase(passwordFile string, prompt string, confirm bool) (string, error) {
+	if passwordFile != "" {
+		data, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return "", err
+		}
+		password := strings.TrimRight(string(data), "\r\n")
+		if password == "" {
+			return "", errors.New("non-empty passphrase required")
+		}
+		return password, nil
+	}
+	password, err := readPasswordFromNewTTY(prompt)
+	if err != nil {
+		return "", err
+	}
+	if !confirm {
+		return password, nil
+	}
+	if password == "" {
+		return "", errors.New("non-empty passphrase required")
+	}
+	again, err := readPasswordFromNewTTY("Confirm passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if password != again {
+		return "", errors.New("passphrase confirmation mismatch")
+	}
+	return password, nil
+}
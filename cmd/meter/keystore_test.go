@@ -0,0 +1,68 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasterKeystore(t *testing.T) {
+	priv := []byte("ZWNkc2E=:::Ymxz")
+	pub := []byte("cHViZWNkc2E=:::cHViYmxz")
+	addr := meter.BytesToAddress([]byte("addr"))
+
+	data, err := encryptMasterKey(priv, pub, addr, "pass", keystore.LightScryptN, keystore.LightScryptP)
+	assert.Nil(t, err)
+	assert.True(t, isMasterKeystore(data))
+	assert.False(t, isMasterKeystore(priv), "plaintext")
+
+	decrypted, ks, err := decryptMasterKey(data, "pass")
+	assert.Nil(t, err)
+	assert.Equal(t, priv, decrypted)
+	assert.Equal(t, string(pub), ks.PublicKey)
+	assert.Equal(t, addr, ks.Address)
+
+	_, _, err = decryptMasterKey(data, "wrong")
+	assert.Equal(t, errKeystoreDecrypt, err)
+}
+
+func TestReadMasterKeyPassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "passphrase")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "password")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("pass\n"), 0600))
+	password, err := readMasterKeyPassphrase(file, "", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "pass", password)
+
+	assert.Nil(t, ioutil.WriteFile(file, []byte("\r\n"), 0600))
+	_, err = readMasterKeyPassphrase(file, "", false)
+	assert.EqualError(t, err, "non-empty passphrase required")
+}
+
+func TestExportECDSAKeystore(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+	k := &KeyLoader{ecdsaPrivKey: privKey, ecdsaPubKey: &privKey.PublicKey}
+
+	keyjson, err := k.encryptECDSA("pass")
+	assert.Nil(t, err)
+	assert.False(t, isMasterKeystore(keyjson), "v3 keystore")
+
+	key, err := keystore.DecryptKey(keyjson, "pass")
+	assert.Nil(t, err)
+	assert.Equal(t, privKey.D, key.PrivateKey.D)
+}
@@ -37,7 +37,6 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/inconshreveable/log15"
 	"github.com/mattn/go-isatty"
-	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	cli "gopkg.in/urfave/cli.v1"
 )
@@ -93,6 +92,11 @@ func main() {
 			apiTimeoutFlag,
 			apiCallGasLimitFlag,
 			apiBacktraceLimitFlag,
+			apiEnableCustomTracerFlag,
+			apiTracerStepLimitFlag,
+			apiTracerTimeoutFlag,
+			disableSnapshotFlag,
+			recordPreimagesFlag,
 			verbosityFlag,
 			maxPeersFlag,
 			p2pPortFlag,
@@ -115,16 +119,23 @@ func main() {
 			epochBlockCountFlag,
 			httpsCertFlag,
 			httpsKeyFlag,
+			masterKeyPasswordFileFlag,
+			signerURLFlag,
+			signerSecretFileFlag,
+			signerInsecureFlag,
 		},
 		Action: defaultAction,
 		Commands: []cli.Command{
 			{
 				Name:  "master-key",
-				Usage: "import and export master key",
+				Usage: "import, export and encrypt master key",
 				Flags: []cli.Flag{
 					dataDirFlag,
 					importMasterKeyFlag,
 					exportMasterKeyFlag,
+					exportBlsKeyFlag,
+					encryptMasterKeyFlag,
+					masterKeyPasswordFileFlag,
 				},
 				Action: masterKeyAction,
 			},
@@ -142,6 +153,7 @@ func main() {
 				Usage: "export public key",
 				Flags: []cli.Flag{
 					dataDirFlag,
+					masterKeyPasswordFileFlag,
 				},
 				Action: publicKeyAction,
 			},
@@ -154,6 +166,13 @@ func main() {
 				},
 				Action: peersAction,
 			},
+			governanceCommand,
+			dumpStateCommand,
+			exportBlocksCommand,
+			importBlocksCommand,
+			dbCommand,
+			signerCommand,
+			signProtectionCommand,
 		},
 	}
 
@@ -223,7 +242,7 @@ func defaultAction(ctx *cli.Context) error {
 	logDB := openLogDB(ctx, instanceDir)
 	defer func() { log.Info("closing log database..."); logDB.Close() }()
 
-	chain := initChain(gene, mainDB, logDB)
+	chain := initChain(gene, mainDB, logDB, ctx.Bool(recordPreimagesFlag.Name))
 	master, blsCommon := loadNodeMaster(ctx)
 	pubkey, err := getNodeComplexPubKey(master, blsCommon)
 	if err != nil {
@@ -231,21 +250,7 @@ func defaultAction(ctx *cli.Context) error {
 	}
 
 	// load preset config
-	if "warringstakes" == ctx.String(networkFlag.Name) {
-		config := preset.ShoalPresetConfig
-		ctx.Set("committee-min-size", strconv.Itoa(config.CommitteeMinSize))
-		ctx.Set("committee-max-size", strconv.Itoa(config.CommitteeMaxSize))
-		ctx.Set("delegate-max-size", strconv.Itoa(config.DelegateMaxSize))
-		ctx.Set("disco-topic", config.DiscoTopic)
-		ctx.Set("disco-server", config.DiscoServer)
-	} else if "main" == ctx.String(networkFlag.Name) {
-		config := preset.MainPresetConfig
-		ctx.Set("committee-min-size", strconv.Itoa(config.CommitteeMinSize))
-		ctx.Set("committee-max-size", strconv.Itoa(config.CommitteeMaxSize))
-		ctx.Set("delegate-max-size", strconv.Itoa(config.DelegateMaxSize))
-		ctx.Set("disco-topic", config.DiscoTopic)
-		ctx.Set("disco-server", config.DiscoServer)
-	}
+	loadPresetConfig(ctx)
 
 	// init blockchain config
 	meter.InitBlockChainConfig(gene.ID(), ctx.String(networkFlag.Name))
@@ -263,7 +268,22 @@ func defaultAction(ctx *cli.Context) error {
 	initDelegates := loadDelegates(ctx, blsCommon)
 	printDelegates(initDelegates)
 
-	txPool := txpool.New(chain, state.NewCreator(mainDB), defaultTxPoolOptions)
+	var snaps *state.Snapshots
+	if !ctx.Bool(disableSnapshotFlag.Name) {
+		snaps = state.NewSnapshots(mainDB, chain.BestBlock().Header().StateRoot())
+		defer func() {
+			log.Info("closing state snapshot...")
+			if err := snaps.Close(chain.BestBlock().Header().StateRoot()); err != nil {
+				log.Warn("failed to close state snapshot", "err", err)
+			}
+		}()
+	}
+	stateCreator := state.NewCreatorWithSnapshots(mainDB, snaps)
+	if ctx.Bool(recordPreimagesFlag.Name) {
+		stateCreator.EnablePreimages()
+	}
+
+	txPool := txpool.New(chain, stateCreator, defaultTxPoolOptions)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
 	defaultPowPoolOptions.Node = ctx.String("pow-node")
@@ -272,11 +292,12 @@ func defaultAction(ctx *cli.Context) error {
 	defaultPowPoolOptions.Pass = ctx.String("pow-pass")
 	fmt.Println(defaultPowPoolOptions)
 
-	powPool := powpool.New(defaultPowPoolOptions, chain, state.NewCreator(mainDB))
+	powPool := powpool.New(defaultPowPoolOptions, chain, stateCreator)
 	defer func() { log.Info("closing pow pool..."); powPool.Close() }()
 
 	p2pcom := newP2PComm(ctx, chain, txPool, instanceDir, powPool, p2pMagic)
-	apiHandler, apiCloser := api.New(chain, state.NewCreator(mainDB), txPool, logDB, p2pcom.comm, ctx.String(apiCorsFlag.Name), uint32(ctx.Int(apiBacktraceLimitFlag.Name)), uint64(ctx.Int(apiCallGasLimitFlag.Name)), p2pcom.p2pSrv, pubkey)
+	apiHandler, apiCloser := api.New(chain, stateCreator, txPool, logDB, p2pcom.comm, ctx.String(apiCorsFlag.Name), uint32(ctx.Int(apiBacktraceLimitFlag.Name)), uint64(ctx.Int(apiCallGasLimitFlag.Name)), p2pcom.p2pSrv, pubkey,
+		ctx.Bool(apiEnableCustomTracerFlag.Name), uint64(ctx.Int(apiTracerStepLimitFlag.Name)), time.Duration(ctx.Int(apiTracerTimeoutFlag.Name))*time.Millisecond)
 	defer func() { log.Info("closing API..."); apiCloser() }()
 
 	apiURL, srvCloser := startAPIServer(ctx, apiHandler, chain.GenesisBlock().Header().ID())
@@ -288,9 +309,8 @@ func defaultAction(ctx *cli.Context) error {
 	powApiURL, powSrvCloser := startPowAPIServer(ctx, powApiHandler)
 	defer func() { log.Info("stopping Pow API server..."); powSrvCloser() }()
 
-	stateCreator := state.NewCreator(mainDB)
 	sc := script.NewScriptEngine(chain, stateCreator)
-	cons := consensus.NewConsensusReactor(ctx, chain, stateCreator, master.PrivateKey, master.PublicKey, consensusMagic, blsCommon, initDelegates)
+	cons := consensus.NewConsensusReactor(ctx, chain, stateCreator, master.Signer, consensusMagic, blsCommon, initDelegates)
 
 	observeURL, observeSrvCloser := startObserveServer(ctx, cons, pubkey, p2pcom.comm, chain)
 	defer func() { log.Info("closing Observe Server ..."); observeSrvCloser() }()
@@ -320,6 +340,24 @@ func defaultAction(ctx *cli.Context) error {
 		Run(exitSignal)
 }
 
+func loadPresetConfig(ctx *cli.Context) {
+	if "warringstakes" == ctx.String(networkFlag.Name) {
+		config := preset.ShoalPresetConfig
+		ctx.Set("committee-min-size", strconv.Itoa(config.CommitteeMinSize))
+		ctx.Set("committee-max-size", strconv.Itoa(config.CommitteeMaxSize))
+		ctx.Set("delegate-max-size", strconv.Itoa(config.DelegateMaxSize))
+		ctx.Set("disco-topic", config.DiscoTopic)
+		ctx.Set("disco-server", config.DiscoServer)
+	} else if "main" == ctx.String(networkFlag.Name) {
+		config := preset.MainPresetConfig
+		ctx.Set("committee-min-size", strconv.Itoa(config.CommitteeMinSize))
+		ctx.Set("committee-max-size", strconv.Itoa(config.CommitteeMaxSize))
+		ctx.Set("delegate-max-size", strconv.Itoa(config.DelegateMaxSize))
+		ctx.Set("disco-topic", config.DiscoTopic)
+		ctx.Set("disco-server", config.DiscoServer)
+	}
+}
+
 func newKFrameGenerator(ctx *cli.Context, cons *consensus.ConsensusReactor) func() {
 	done := make(chan int)
 	go func() {
@@ -348,13 +386,21 @@ func newKFrameGenerator(ctx *cli.Context, cons *consensus.ConsensusReactor) func
 func masterKeyAction(ctx *cli.Context) error {
 	hasImportFlag := ctx.Bool(importMasterKeyFlag.Name)
 	hasExportFlag := ctx.Bool(exportMasterKeyFlag.Name)
-	if hasImportFlag && hasExportFlag {
-		return fmt.Errorf("flag %s and %s are exclusive", importMasterKeyFlag.Name, exportMasterKeyFlag.Name)
+	hasEncryptFlag := ctx.Bool(encryptMasterKeyFlag.Name)
+	n := 0
+	for _, has := range []bool{hasImportFlag, hasExportFlag, hasEncryptFlag} {
+		if has {
+			n++
+		}
+	}
+	if n > 1 {
+		return fmt.Errorf("flag %s, %s and %s are exclusive", importMasterKeyFlag.Name, exportMasterKeyFlag.Name, encryptMasterKeyFlag.Name)
 	}
 
-	if !hasImportFlag && !hasExportFlag {
-		return fmt.Errorf("missing flag, either %s or %s", importMasterKeyFlag.Name, exportMasterKeyFlag.Name)
+	if n == 0 {
+		return fmt.Errorf("missing flag, either %s, %s or %s", importMasterKeyFlag.Name, exportMasterKeyFlag.Name, encryptMasterKeyFlag.Name)
 	}
+	passwordFile := ctx.String(masterKeyPasswordFileFlag.Name)
 
 	if hasImportFlag {
 		if isatty.IsTerminal(os.Stdin.Fd()) {
@@ -368,50 +414,93 @@ func masterKeyAction(ctx *cli.Context) error {
 		if err := json.Unmarshal(keyjson, &map[string]interface{}{}); err != nil {
 			return errors.WithMessage(err, "unmarshal")
 		}
-		password, err := readPasswordFromNewTTY("Enter passphrase: ")
+		password, err := readMasterKeyPassphrase("", "Enter passphrase of keystore: ", false)
 		if err != nil {
 			return err
 		}
 
-		key, err := keystore.DecryptKey(keyjson, password)
-		if err != nil {
-			return errors.WithMessage(err, "decrypt")
-		}
-
-		if err := crypto.SaveECDSA(masterKeyPath(ctx), key.PrivateKey); err != nil {
+		// the imported keys are saved the same way as the current master key, encrypted
+		// with its passphrase or in plaintext
+		keyLoader := NewKeyLoader(ctx)
+		if err := keyLoader.unlock(); err != nil {
 			return err
 		}
-		fmt.Println("Master key imported:", meter.Address(key.Address))
+		if isMasterKeystore(keyjson) {
+			// both ECDSA and BLS keys
+			priv, ks, err := decryptMasterKey(keyjson, password)
+			if err != nil {
+				return errors.WithMessage(err, "decrypt")
+			}
+			keyLoader.masterBytes = priv
+			keyLoader.publicBytes = []byte(ks.PublicKey)
+			if err := keyLoader.validateECDSA(); err != nil {
+				return err
+			}
+			if err := keyLoader.validateBls(); err != nil {
+				return err
+			}
+			if keyLoader.updated {
+				return errors.New("invalid keys in keystore")
+			}
+			if err := keyLoader.writeKeys(); err != nil {
+				return err
+			}
+		} else {
+			// ECDSA key only, keep the current BLS key
+			key, err := keystore.DecryptKey(keyjson, password)
+			if err != nil {
+				return errors.WithMessage(err, "decrypt")
+			}
+			if err := keyLoader.validateBls(); err != nil {
+				return err
+			}
+			system, err := getBlsSystem()
+			if err != nil {
+				return err
+			}
+			keyLoader.ecdsaPrivKey = key.PrivateKey
+			keyLoader.ecdsaPubKey = &key.PrivateKey.PublicKey
+			if err := keyLoader.saveKeys(*system); err != nil {
+				return err
+			}
+		}
+		fmt.Println("Master key imported:", meter.Address(crypto.PubkeyToAddress(keyLoader.ecdsaPrivKey.PublicKey)))
 		return nil
 	}
 
-	if hasExportFlag {
-		masterKey, err := loadOrGeneratePrivateKey(masterKeyPath(ctx))
-		if err != nil {
-			return err
-		}
+	keyLoader := NewKeyLoader(ctx)
+	if _, _, _, err := keyLoader.Load(); err != nil {
+		return err
+	}
 
-		password, err := readPasswordFromNewTTY("Enter passphrase: ")
+	if hasEncryptFlag {
+		if keyLoader.passphrase != "" {
+			return errors.New("master key already encrypted")
+		}
+		password, err := readMasterKeyPassphrase(passwordFile, "Enter passphrase: ", true)
 		if err != nil {
 			return err
 		}
-		if password == "" {
-			return errors.New("non-empty passphrase required")
+		keyLoader.passphrase = password
+		if err := keyLoader.writeKeys(); err != nil {
+			return err
 		}
-		confirm, err := readPasswordFromNewTTY("Confirm passphrase: ")
+		fmt.Println("Master key encrypted:", keyLoader.masterPath)
+		return nil
+	}
+
+	if hasExportFlag {
+		password, err := readMasterKeyPassphrase("", "Enter passphrase: ", true)
 		if err != nil {
 			return err
 		}
 
-		if password != confirm {
-			return errors.New("passphrase confirmation mismatch")
+		var keyjson []byte
+		if ctx.Bool(exportBlsKeyFlag.Name) {
+			keyjson, err = keyLoader.encrypt(password)
+		} else {
+			keyjson, err = keyLoader.encryptECDSA(password)
 		}
-
-		keyjson, err := keystore.EncryptKey(&keystore.Key{
-			PrivateKey: masterKey,
-			Address:    crypto.PubkeyToAddress(masterKey.PublicKey),
-			Id:         uuid.NewRandom()},
-			password, keystore.StandardScryptN, keystore.StandardScryptP)
 		if err != nil {
 			return err
 		}
@@ -6,6 +6,7 @@
 package main
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/tls"
 	b64 "encoding/base64"
@@ -38,6 +39,7 @@ import (
 	"github.com/dfinlab/meter/p2psrv"
 	"github.com/dfinlab/meter/powpool"
 	"github.com/dfinlab/meter/preset"
+	"github.com/dfinlab/meter/signer"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/txpool"
 	"github.com/dfinlab/meter/types"
@@ -242,9 +244,22 @@ func openMainDB(ctx *cli.Context, dataDir string) *lvldb.LevelDB {
 	if err != nil {
 		fatal(fmt.Sprintf("open chain database [%v]: %v", dir, err))
 	}
+	if db.Recovered() {
+		log.Warn("chain database was corrupted and has been recovered, run `meter db check` to verify it", "dir", dir)
+	}
 	return db
 }
 
+// openSignProtection opens the double-vote protection records, which follow the master key in data dir.
+func openSignProtection(ctx *cli.Context) *signer.Protection {
+	path := filepath.Join(makeDataDir(ctx), signProtectionFile)
+	p, err := signer.OpenProtection(path)
+	if err != nil {
+		fatal(fmt.Sprintf("open sign protection [%v]: %v", path, err))
+	}
+	return p
+}
+
 func openLogDB(ctx *cli.Context, dataDir string) *logdb.LogDB {
 	dir := filepath.Join(dataDir, "logs.db")
 	db, err := logdb.New(dir)
@@ -254,8 +269,13 @@ func openLogDB(ctx *cli.Context, dataDir string) *logdb.LogDB {
 	return db
 }
 
-func initChain(gene *genesis.Genesis, mainDB *lvldb.LevelDB, logDB *logdb.LogDB) *chain.Chain {
-	genesisBlock, genesisEvents, err := gene.Build(state.NewCreator(mainDB))
+func initChain(gene *genesis.Genesis, mainDB *lvldb.LevelDB, logDB *logdb.LogDB, recordPreimages bool) *chain.Chain {
+	stateCreator := state.NewCreator(mainDB)
+	if recordPreimages {
+		// genesis state is committed on each start, so that its preimages get recorded
+		stateCreator.EnablePreimages()
+	}
+	genesisBlock, genesisEvents, err := gene.Build(stateCreator)
 	if err != nil {
 		fatal("build genesis block: ", err)
 	}
@@ -328,12 +348,47 @@ func loadNodeMaster(ctx *cli.Context) (*node.Master, *consensus.BlsCommon) {
 		}, nil
 	}
 
+	if url := ctx.String(signerURLFlag.Name); url != "" {
+		return loadRemoteMaster(ctx, url)
+	}
+
 	keyLoader := NewKeyLoader(ctx)
 	ePrivKey, ePubKey, blsCommon, err := keyLoader.Load()
 	if err != nil {
 		fatal("load key error: ", err)
 	}
 	master := &node.Master{PrivateKey: ePrivKey, PublicKey: ePubKey}
+	master.Signer = signer.NewLocal(ePrivKey, blsCommon.PrivKey, blsCommon.PubKey, *blsCommon.GetSystem(), openSignProtection(ctx))
+	master.Beneficiary = beneficiary(ctx)
+	return master, blsCommon
+}
+
+// loadRemoteMaster loads node master whose keys are held by the remote signer.
+func loadRemoteMaster(ctx *cli.Context, url string) (*node.Master, *consensus.BlsCommon) {
+	if !strings.HasPrefix(url, "https://") && !ctx.Bool(signerInsecureFlag.Name) {
+		fatal(fmt.Sprintf("remote signer url %v is not https, use --%v to allow plain http", url, signerInsecureFlag.Name))
+	}
+	secret, err := ioutil.ReadFile(ctx.String(signerSecretFileFlag.Name))
+	if err != nil {
+		fatal("read signer secret:", err)
+	}
+	remote, err := signer.NewRemote(url, bytes.TrimSpace(secret))
+	if err != nil {
+		fatal("connect remote signer:", err)
+	}
+	params, pairing, system, err := getBlsParams()
+	if err != nil {
+		fatal("load bls params:", err)
+	}
+	blsPubKey, err := system.PubKeyFromBytes(remote.BlsPublicKey())
+	if err != nil {
+		fatal("invalid bls public key from remote signer:", err)
+	}
+	log.Info("using remote signer", "url", url, "address", meter.Address(crypto.PubkeyToAddress(*remote.PublicKey())))
+
+	// no private key in process
+	blsCommon := consensus.NewBlsCommonFromParams(blsPubKey, bls.PrivateKey{}, system, params, pairing)
+	master := &node.Master{PublicKey: remote.PublicKey(), Signer: remote}
 	master.Beneficiary = beneficiary(ctx)
 	return master, blsCommon
 }
@@ -415,7 +470,7 @@ func newP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, ins
 	opts.KnownNodes = append(opts.KnownNodes, validNodes...)
 
 	return &p2pComm{
-		comm:           comm.New(chain, txPool, powPool, topic, magic),
+		comm:           comm.New(chain, txPool, powPool, topic, magic, comm.NewBanList(filepath.Join(instanceDir, "peers.banned"))),
 		p2pSrv:         p2psrv.New(opts),
 		peersCachePath: peersCachePath,
 	}
@@ -9,15 +9,20 @@ import (
 	"crypto/ecdsa"
 
 	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/signer"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type Master struct {
-	PrivateKey  *ecdsa.PrivateKey
+	PrivateKey  *ecdsa.PrivateKey // nil if keys are held by remote signer
 	PublicKey   *ecdsa.PublicKey
+	Signer      signer.Signer
 	Beneficiary *meter.Address
 }
 
 func (m *Master) Address() meter.Address {
+	if m.PublicKey != nil {
+		return meter.Address(crypto.PubkeyToAddress(*m.PublicKey))
+	}
 	return meter.Address(crypto.PubkeyToAddress(m.PrivateKey.PublicKey))
 }
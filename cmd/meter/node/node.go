@@ -24,6 +24,7 @@ import (
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/packer"
 	"github.com/dfinlab/meter/script"
+	"github.com/dfinlab/meter/script/auction"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/tx"
 	"github.com/dfinlab/meter/txpool"
@@ -45,14 +46,15 @@ type Node struct {
 	packer *packer.Packer
 	cons   *consensus.ConsensusReactor
 
-	master      *Master
-	chain       *chain.Chain
-	logDB       *logdb.LogDB
-	txPool      *txpool.TxPool
-	txStashPath string
-	comm        *comm.Communicator
-	script      *script.ScriptEngine
-	commitLock  sync.Mutex
+	master       *Master
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+	txPool       *txpool.TxPool
+	txStashPath  string
+	comm         *comm.Communicator
+	script       *script.ScriptEngine
+	commitLock   sync.Mutex
 }
 
 func SetGlobNode(node *Node) bool {
@@ -76,15 +78,16 @@ func New(
 	script *script.ScriptEngine,
 ) *Node {
 	node := &Node{
-		packer:      packer.New(chain, stateCreator, master.Address(), master.Beneficiary),
-		cons:        cons,
-		master:      master,
-		chain:       chain,
-		logDB:       logDB,
-		txPool:      txPool,
-		txStashPath: txStashPath,
-		comm:        comm,
-		script:      script,
+		packer:       packer.New(chain, stateCreator, master.Address(), master.Beneficiary),
+		cons:         cons,
+		master:       master,
+		chain:        chain,
+		stateCreator: stateCreator,
+		logDB:        logDB,
+		txPool:       txPool,
+		txStashPath:  txStashPath,
+		comm:         comm,
+		script:       script,
 	}
 	SetGlobNode(node)
 	return node
@@ -126,10 +129,13 @@ func (n *Node) handleBlockStream(ctx context.Context, stream <-chan *block.Block
 	for blk = range stream {
 		log.Debug("handle block", "block", blk)
 		if isTrunk, err := n.processBlock(blk, &stats); err != nil {
+			if consensus.IsCritical(err) {
+				return comm.InvalidBlockError(err)
+			}
 			return err
 		} else if isTrunk {
 			// this processBlock happens after consensus SyncDone, need to broadcast
-			if n.cons.SyncDone {
+			if n.cons.SyncDone && n.comm != nil {
 				n.comm.BroadcastBlock(blk)
 			}
 		}
@@ -151,6 +157,12 @@ func (n *Node) handleBlockStream(ctx context.Context, stream <-chan *block.Block
 	return nil
 }
 
+// ImportBlocks processes and commits blocks from the stream, the same way as blocks
+// synced from peers. It's used to import blocks offline.
+func (n *Node) ImportBlocks(ctx context.Context, stream <-chan *block.Block) error {
+	return n.handleBlockStream(ctx, stream)
+}
+
 func (n *Node) houseKeeping(ctx context.Context) {
 	log.Debug("enter house keeping")
 	defer log.Debug("leave house keeping")
@@ -182,6 +194,8 @@ func (n *Node) houseKeeping(ctx context.Context) {
 					(consensus.IsParentMissing(err) && futureBlocks.Contains(newBlock.Header().ParentID())) {
 					log.Debug("future block added", "id", newBlock.Header().ID())
 					futureBlocks.Set(newBlock.Header().ID(), newBlock.Block)
+				} else if consensus.IsCritical(err) {
+					n.comm.ScorePeer(newBlock.From, comm.ScoreInvalidBlock)
 				}
 			} else if isTrunk {
 				n.comm.BroadcastBlock(newBlock.Block)
@@ -354,6 +368,7 @@ func (n *Node) commitBlock(newBlock *block.Block, receipts tx.Receipts) (*chain.
 		forkIDs = append(forkIDs, header.ID())
 	}
 
+	accounts := n.parentAccounts(newBlock.Header())
 	batch := n.logDB.Prepare(newBlock.Header())
 	for i, tx := range newBlock.Transactions() {
 		origin, _ := tx.Signer()
@@ -361,6 +376,10 @@ func (n *Node) commitBlock(newBlock *block.Block, receipts tx.Receipts) (*chain.
 		for _, output := range receipts[i].Outputs {
 			txBatch.Insert(output.Events, output.Transfers)
 		}
+		batch.InsertTx(tx, origin, receipts[i], accounts)
+	}
+	if err := auction.IndexClosedAuction(batch, newBlock.Header()); err != nil {
+		log.Warn("failed to index closed auction", "err", err)
 	}
 
 	if err := batch.Commit(forkIDs...); err != nil {
@@ -369,6 +388,22 @@ func (n *Node) commitBlock(newBlock *block.Block, receipts tx.Receipts) (*chain.
 	return fork, nil
 }
 
+// parentAccounts opens the parent state of the block, which tells contracts created
+// internally from existing accounts when indexing txs.
+func (n *Node) parentAccounts(header *block.Header) logdb.Accounts {
+	parentHeader, err := n.chain.GetBlockHeader(header.ParentID())
+	if err != nil {
+		log.Warn("failed to get parent header, contracts created internally are not indexed", "err", err)
+		return nil
+	}
+	parentState, err := n.stateCreator.NewState(parentHeader.StateRoot())
+	if err != nil {
+		log.Warn("failed to open parent state, contracts created internally are not indexed", "err", err)
+		return nil
+	}
+	return parentState
+}
+
 func (n *Node) processFork(fork *chain.Fork) {
 	if len(fork.Branch) >= 2 {
 		trunkLen := len(fork.Trunk)
@@ -381,6 +416,10 @@ branch:   %v  %v`, fork.Ancestor,
 			trunkLen, fork.Trunk[trunkLen-1],
 			branchLen, fork.Branch[branchLen-1]))
 	}
+	if n.txPool == nil {
+		// no tx pool when importing offline
+		return
+	}
 	for _, header := range fork.Branch {
 		body, err := n.chain.GetBlockBody(header.ID())
 		if err != nil {
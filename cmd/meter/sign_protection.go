@@ -0,0 +1,62 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// signProtectionFile holds the last signed proposal and vote, to avoid double signing after restart.
+const signProtectionFile = "sign-protection.json"
+
+var signProtectionCommand = cli.Command{
+	Name:  "sign-protection",
+	Usage: "export or import the double-vote protection records, to migrate a validator between machines",
+	Subcommands: []cli.Command{
+		{
+			Name:      "export",
+			Usage:     "print the records of last signed proposal and vote",
+			Flags:     []cli.Flag{dataDirFlag},
+			Action:    exportSignProtectionAction,
+			ArgsUsage: " ",
+		},
+		{
+			Name:      "import",
+			Usage:     "merge the exported records, the later one of each kind is kept",
+			Flags:     []cli.Flag{dataDirFlag},
+			Action:    importSignProtectionAction,
+			ArgsUsage: "<file>",
+		},
+	},
+}
+
+func exportSignProtectionAction(ctx *cli.Context) error {
+	data, err := openSignProtection(ctx).Export()
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func importSignProtectionAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("exported file required")
+	}
+	data, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	if err := openSignProtection(ctx).Import(data); err != nil {
+		return errors.WithMessage(err, "import sign protection")
+	}
+	fmt.Println("sign protection imported")
+	return nil
+}
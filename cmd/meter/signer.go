@@ -0,0 +1,90 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path/filepath"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/signer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var signerCommand = cli.Command{
+	Name:  "signer",
+	Usage: "serve the master key as a remote signer, for nodes started with --" + signerURLFlag.Name,
+	Flags: []cli.Flag{
+		dataDirFlag,
+		verbosityFlag,
+		masterKeyPasswordFileFlag,
+		signerAddrFlag,
+		signerSecretFileFlag,
+		httpsCertFlag,
+		httpsKeyFlag,
+		signerInsecureFlag,
+	},
+	Action: signerAction,
+}
+
+func signerAction(ctx *cli.Context) error {
+	exitSignal := handleExitSignal()
+	initLogger(ctx)
+
+	secret, err := ioutil.ReadFile(ctx.String(signerSecretFileFlag.Name))
+	if err != nil {
+		return errors.WithMessage(err, "read signer secret")
+	}
+	secret = bytes.TrimSpace(secret)
+	if len(secret) == 0 {
+		return errors.New("empty signer secret")
+	}
+
+	dataDir := makeDataDir(ctx)
+	certFile := filepath.Join(dataDir, ctx.String(httpsCertFlag.Name))
+	keyFile := filepath.Join(dataDir, ctx.String(httpsKeyFlag.Name))
+	useTLS := fileExists(certFile) && fileExists(keyFile)
+	if !useTLS && !ctx.Bool(signerInsecureFlag.Name) {
+		return errors.Errorf("https cert %v or key %v not found, use --%v to serve over plain http", certFile, keyFile, signerInsecureFlag.Name)
+	}
+
+	keyLoader := NewKeyLoader(ctx)
+	privKey, _, blsCommon, err := keyLoader.Load()
+	if err != nil {
+		return err
+	}
+	local := signer.NewLocal(privKey, blsCommon.PrivKey, blsCommon.PubKey, *blsCommon.GetSystem(), openSignProtection(ctx))
+
+	listener, err := net.Listen("tcp", ctx.String(signerAddrFlag.Name))
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: signer.NewServer(local, secret)}
+	if !useTLS {
+		log.Warn("remote signer is served over plain http")
+	}
+	go func() {
+		var err error
+		if useTLS {
+			err = srv.ServeTLS(listener, certFile, keyFile)
+		} else {
+			err = srv.Serve(listener)
+		}
+		if err != http.ErrServerClosed {
+			log.Error("remote signer stopped", "err", err)
+		}
+	}()
+	log.Info("remote signer started", "addr", listener.Addr(), "master", meter.Address(crypto.PubkeyToAddress(privKey.PublicKey)))
+
+	<-exitSignal.Done()
+	log.Info("stopping remote signer...")
+	return srv.Close()
+}
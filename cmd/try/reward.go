@@ -1,86 +0,0 @@
-package main
-
-import (
-	"crypto/ecdsa"
-	"encoding/json"
-	"fmt"
-	"math/big"
-	"net"
-
-	api_staking "github.com/dfinlab/meter/api/staking"
-	bls "github.com/dfinlab/meter/crypto/multi_sig"
-	"github.com/dfinlab/meter/reward"
-	"github.com/dfinlab/meter/types"
-)
-
-/*
-Name        []byte          `json:"name"`
-	Address     meter.Address   `json:"address"`
-	PubKey      ecdsa.PublicKey `json:"pub_key"`
-	BlsPubKey   bls.PublicKey   `json:"bsl_pubkey"`
-	VotingPower int64           `json:"voting_power"`
-	NetAddr     NetAddress      `json:"network_addr"`
-	Commission  uint64          `json:"commission"`
-	DistList    []*Distributor  `json:"distibutor_list"`
-*/
-var (
-	FakeECDSAPK = ecdsa.PublicKey{}
-	FakeBlsPK   = bls.PublicKey{}
-)
-
-const delegateStr = `[{"name":"Meter HODL (Will Spread Vote)","address":"0x5308b6f26f21238963d0ea0b391eafa9be53c78e","pubKey":"BO7rFL1PBHZ4nlLv9BHNSQ1XXe/ziEIlWMXiAeRmXWCOjmdZWNN2HVj4hj8QgecoDTY0dp5zxmMZpuXTz8Xdr7M=:::J3He1tVJh4VLwjjnyeSRnH7D8WJAqOlGvtLAzYw8CZ+3V1Uj5xSAT133yVTScCHmS2gJBBP1edBlg37ebr9lXwA=","votingPower":"8503840177328297790617700","ipAddr":"13.250.120.155","port":8670,"commission":100000000,"distributors":[{"address":"0x5308b6f26f21238963d0ea0b391eafa9be53c78e","autobid":100,"shares":176917660},{"address":"0x2fa2d56e312c47709537acb198446205736022aa","autobid":100,"shares":182814852},{"address":"0x2fa2d56e312c47709537acb198446205736022aa","autobid":100,"shares":235890014},{"address":"0x5308b6f26f21238963d0ea0b391eafa9be53c78e","autobid":100,"shares":265376149},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":117944778},{"address":"0xb535c815d234bb2e9b61c456dbb7607baaf9f3b2","autobid":100,"shares":99528},{"address":"0x22591a660b1cd30b7d65504c1d439d985e5f695c","autobid":100,"shares":30546},{"address":"0xf71852f0418150ddecd6f3183a021d226ba43596","autobid":100,"shares":31133},{"address":"0xe8e06ee4aae5f9ec4a5ff926fea0de4facc03e16","autobid":100,"shares":13420},{"address":"0x1bbe7620c91c09e5cfc181cd739e3460685fddfa","autobid":100,"shares":13420},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":5897236},{"address":"0x03004a8736fb9dcb136fc5f719f6ced2e9509f5e","autobid":100,"shares":66861},{"address":"0x279b7e99f81da9fadb6e1866a160369eb72ba5db","autobid":100,"shares":1831648},{"address":"0xcfdd0dcd6aa9fe40ad18a5be8b9601a3df21c622","autobid":100,"shares":22637},{"address":"0x45fd1092856b136f5c259e326abf40d275dee992","autobid":100,"shares":41108},{"address":"0xc3ba930c33376dc9091dcfa98fcd46490829fd0c","autobid":100,"shares":82325},{"address":"0x62eff1e3de2b3caceb5b95f7fd5980c17ced0dca","autobid":100,"shares":275960},{"address":"0xea4a9c5dfc537337dab120780a9eddadc2b097ed","autobid":100,"shares":32776},{"address":"0xe0fe783214de95e8a4bbfc43afe87895be3be2b2","autobid":100,"shares":1343893},{"address":"0xee1f65c97c256cb424d9aee8cc8cefef9d8375fa","autobid":100,"shares":29457},{"address":"0xefa1b807edbcb7f7e5b5cf2f3774dfa7e574985a","autobid":100,"shares":18705},{"address":"0xbfecdadf522dde96bbab4b983778ca971f8eb38a","autobid":100,"shares":19912},{"address":"0xbbf1d45a3e73a14d893dfd967837e263feb39812","autobid":100,"shares":21895},{"address":"0x8d10d894e99c76cbfa77f29af1d8ba1c7b9c02ec","autobid":100,"shares":937600},{"address":"0x78b7ce2c3e3dfa1a1fa6544d6337aeda56f2c5da","autobid":100,"shares":100421},{"address":"0x78b7ce2c3e3dfa1a1fa6544d6337aeda56f2c5da","autobid":100,"shares":12368},{"address":"0x78b7ce2c3e3dfa1a1fa6544d6337aeda56f2c5da","autobid":100,"shares":35272},{"address":"0x4e56248a9bda09e72ff30b32b78122a45ff967a0","autobid":100,"shares":19073},{"address":"0x28f8d3bff1d9ec9fd55793dce8f26b89718f55e4","autobid":100,"shares":36108},{"address":"0x6a7c708fc89d9d6f2426c4180fc50a7671b02ed6","autobid":100,"shares":14123},{"address":"0x93b89be9cf685cf7ce6148560a35b6785ee02373","autobid":100,"shares":25053},{"address":"0x226b124c9d2605c35bb24ea4e607e882818852aa","autobid":100,"shares":30679},{"address":"0x78b7ce2c3e3dfa1a1fa6544d6337aeda56f2c5da","autobid":100,"shares":43946},{"address":"0x1ce46b7bf47e144e3aa0203e5de1395e85fce087","autobid":100,"shares":2351878},{"address":"0x793208d2d5776c63a7105ea3011b53b7e8b1b174","autobid":100,"shares":2362462},{"address":"0x99fe93ddefafd31f6258844fc82ad406f7442f1c","autobid":100,"shares":2823547},{"address":"0x38c3d791907dfa5112cf8699d36a89a560e966f2","autobid":100,"shares":2363285},{"address":"0x12d8834336c7f15ceb79e6f6c612f2c22897edd8","autobid":100,"shares":28254}]},{"name":"Pow3r-ITALY89","address":"0x927495f329ab083e6cff350d6e9d8652d76f90f7","pubKey":"BG1+8tr8l5uN6h5OBdS8fZKdVtNheqVVhMIZpcJsipvgfAyLDsXTBzmt9vkJJJ+TO9tAiLO2/fvx5fMSLvdDoUM=:::TM94VxsR5qM7urz3qImqgYNct8MH0JAaPmjGv0srxhcYHM/IRQcxiyLb6JcJPpm8bjZ5WQsic96Wahf8F8TJZAA=","votingPower":"511911871191789030697875","ipAddr":"18.194.126.109","port":8670,"commission":80000000,"distributors":[{"address":"0x927495f329ab083e6cff350d6e9d8652d76f90f7","autobid":100,"shares":36722938},{"address":"0xbccf20720d06d91ea6e463ec50c6f64186066b25","autobid":100,"shares":317487},{"address":"0x86f22c3eba0ce82f590e82a28d1838c891fc5cd6","autobid":100,"shares":5222841},{"address":"0xa13e81b67938821e0df2d7057f0d1345a1ed7253","autobid":100,"shares":31379986},{"address":"0x3355365bfe29b0e04d7c4f4856c232c9c32c4705","autobid":100,"shares":1357501},{"address":"0xfebe0bba16486c4ae41597f993773a28fd3b3894","autobid":100,"shares":195977},{"address":"0x4a179a005dcbe770c6970ee390a43d2284f67527","autobid":100,"shares":40329958},{"address":"0xa1b1b24f1e5b0ffbd778ffc1f249a85a5a2aacd0","autobid":100,"shares":417022},{"address":"0xd364d506720c7891823c5f9920f04f5f448e1128","autobid":100,"shares":456605},{"address":"0x4a179a005dcbe770c6970ee390a43d2284f67527","autobid":100,"shares":1775495},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":8365985},{"address":"0x592da7c1b8b9cca9a20ece5d6070f8140895de6a","autobid":100,"shares":10846927},{"address":"0x0e9ab07a56576f8ab8d4508c729600a587b249d5","autobid":100,"shares":3821389},{"address":"0x4a179a005dcbe770c6970ee390a43d2284f67527","autobid":100,"shares":4356346},{"address":"0xf2c4b0b9c08cd870ec11636d5e028424b6796b12","autobid":100,"shares":821352},{"address":"0x47f0a063e5e5add2daacf76689f994365566fd7a","autobid":100,"shares":456543},{"address":"0xbd51fc2e0f304bf33eb532a4f98dcfafba0377b6","autobid":100,"shares":359530},{"address":"0x4a179a005dcbe770c6970ee390a43d2284f67527","autobid":100,"shares":1880796},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":544572},{"address":"0xd84c450cfe23e11dfbab852b4dde3b120ae88fd2","autobid":100,"shares":388258},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":944160},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":564138},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":1602303},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":428978},{"address":"0xf2c4b0b9c08cd870ec11636d5e028424b6796b12","autobid":100,"shares":458340},{"address":"0x5f1619d49193fbdcb9e409fd213700656288bf36","autobid":100,"shares":15187637},{"address":"0x592da7c1b8b9cca9a20ece5d6070f8140895de6a","autobid":100,"shares":4989160},{"address":"0x7d4ae3cf5426f5dfafb6f5d295ef45b81b3047d3","autobid":100,"shares":3938816},{"address":"0x7d4ae3cf5426f5dfafb6f5d295ef45b81b3047d3","autobid":100,"shares":7177253},{"address":"0x592da7c1b8b9cca9a20ece5d6070f8140895de6a","autobid":100,"shares":3879240},{"address":"0x5f1619d49193fbdcb9e409fd213700656288bf36","autobid":100,"shares":11480547},{"address":"0x7d4ae3cf5426f5dfafb6f5d295ef45b81b3047d3","autobid":100,"shares":13902094},{"address":"0xf0ad79db078b24a30da65fa88e4e3afefcc9d965","autobid":100,"shares":5063161},{"address":"0xdf33800a566f993ea50c409a5d85b7170b60c0fd","autobid":100,"shares":293774},{"address":"0xdf33800a566f993ea50c409a5d85b7170b60c0fd","autobid":100,"shares":293961},{"address":"0xbd51fc2e0f304bf33eb532a4f98dcfafba0377b6","autobid":100,"shares":604687},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":1656767},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":1956158},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":836108},{"address":"0x058c41beb615413678c7beb75f54672c93ef0942","autobid":100,"shares":2128214},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":1094266},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":2648483},{"address":"0x4a179a005dcbe770c6970ee390a43d2284f67527","autobid":100,"shares":1127446},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":3362671},{"address":"0x67315df1fc8124be82c1236f695628f64a2f453b","autobid":100,"shares":745838},{"address":"0xc3723d43c42ab85209a86a6af9c6921b77dea5aa","autobid":100,"shares":558615},{"address":"0x0179b7808d1cb55203c2b2bdf26efab04b6e5a91","autobid":100,"shares":476832},{"address":"0x7188babb7612daab83c19462110b699a20eb1264","autobid":100,"shares":2462155},{"address":"0x4a179a005dcbe770c6970ee390a43d2284f67527","autobid":100,"shares":598801},{"address":"0x45c68c278047095d5d438debf63b7c84b94e92ce","autobid":100,"shares":675618},{"address":"0x43bcba5f2f57c3e5b88c124cb2ae12bd50d52dbc","autobid":100,"shares":5445272},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":2878025},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":352062496},{"address":"0xd84c450cfe23e11dfbab852b4dde3b120ae88fd2","autobid":100,"shares":379399},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":1507611},{"address":"0x0d3b1fa8d870326f8a2375b3fbffb3b70f0c4800","autobid":100,"shares":1702916},{"address":"0x5cd20ca8232536a041c609871335cd8f7a220b9c","autobid":100,"shares":3106596},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":371324039},{"address":"0x5f1619d49193fbdcb9e409fd213700656288bf36","autobid":100,"shares":214972},{"address":"0x7d4ae3cf5426f5dfafb6f5d295ef45b81b3047d3","autobid":100,"shares":201292},{"address":"0x7db6ac6fa3c8aa2c6fb9bdcd4800e8baacdd2ee8","autobid":100,"shares":19558617},{"address":"0x27cabef682992686a34e260574594aaf50aec80a","autobid":100,"shares":465004}]},{"name":"JamesMeterianITALY_89","address":"0xad2b2f10edeac77525323a914e584aa4bb56be95","pubKey":"BLxuhlDwp4m52CwtjmwXWl1puJchKPV9bPmnIzg6JpfsHCsOjsZSnJaznsKMz5jSEERFrZb92qrjDzVGq7gwOB8=:::fUkJzSrTmKdCTqXXYwXOVOpR8hs3/j1RnFo+/L7r+lxD0snrkSOXa9QTdHw+G7yum2YNuAbuQXMHzFa6Jql4ZAE=","votingPower":"480260832763146636442373","ipAddr":"3.65.207.169","port":8670,"commission":80000000,"distributors":[{"address":"0xad2b2f10edeac77525323a914e584aa4bb56be95","autobid":100,"shares":39212761},{"address":"0xec8bf70c6879c602c54b94c41181023248a8b14d","autobid":100,"shares":4451580},{"address":"0xb6b6ab0556345854ef5e2de5d6cdc4a26bab5c68","autobid":100,"shares":2481185},{"address":"0xf6959e47b94c82b93867fc876b4c99f37e496897","autobid":100,"shares":18837013},{"address":"0x86f22c3eba0ce82f590e82a28d1838c891fc5cd6","autobid":100,"shares":5124011},{"address":"0x7a78a14ae0b2b58e6dd3ccc5cdb6abacb1a1d3e0","autobid":100,"shares":313341},{"address":"0x7a78a14ae0b2b58e6dd3ccc5cdb6abacb1a1d3e0","autobid":100,"shares":555658},{"address":"0x2968d83b7fa1da5fc9d6ff8b0cea1440dbe33890","autobid":100,"shares":1616466},{"address":"0x71cd50ba682de704fa0010a2da238fddbc958697","autobid":100,"shares":12304387},{"address":"0xa694bc4b69cee40cb0f5f630394bb73b09718fb8","autobid":100,"shares":2937050},{"address":"0x4276fa441c95f36d3d95316297539fd229567430","autobid":100,"shares":918972},{"address":"0x651381a1d075d918d6f864130db7bd6be6bc81d3","autobid":100,"shares":580363},{"address":"0xf2c4b0b9c08cd870ec11636d5e028424b6796b12","autobid":100,"shares":12652938},{"address":"0x8cddd94f4ff868111b4fed54d149a791e91099fb","autobid":100,"shares":315099},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":208863},{"address":"0x81fc21bfccf416aa19672ef32cc5144598945664","autobid":100,"shares":1204583},{"address":"0x8f195ed7bff2d46d35d5c0ec3f356610a4147f49","autobid":0,"shares":208790},{"address":"0xb6474b2266ce86c5e9e8bf700fe328566f65d4d2","autobid":100,"shares":417542},{"address":"0x6923973e6f913cd449107fee68ac9265335ec1a4","autobid":100,"shares":404687},{"address":"0x56058710c1947b445cb31ac593a785653e2defe7","autobid":100,"shares":4177357},{"address":"0xd370326427640ae46e274219486ffaf18e5f2227","autobid":100,"shares":231787},{"address":"0x10dc36923fc2ff3d4bf83615d0a8b6714955a28f","autobid":100,"shares":312796},{"address":"0xc9de59e8c2e34ec1d08a999e68479e7ac667d228","autobid":100,"shares":1216927},{"address":"0x2fa2d56e312c47709537acb198446205736022aa","autobid":100,"shares":417685723},{"address":"0x4aca1e189a604bf53fce5a4c69234bff769e670d","autobid":100,"shares":381939},{"address":"0x78ce63b90d12cbc1d99a377f657705505da6cca5","autobid":100,"shares":859283},{"address":"0xbabcdec1ab34369befca6888edcdff523d8bb693","autobid":100,"shares":20863241},{"address":"0x86f22c3eba0ce82f590e82a28d1838c891fc5cd6","autobid":100,"shares":557499},{"address":"0x5dba80ff0f565ac2b9c2376b64bdfc6726698f6c","autobid":100,"shares":338209},{"address":"0xf8be177c7932c11117a96b54af405d3bfb5f7ea2","autobid":100,"shares":221111},{"address":"0xc9de59e8c2e34ec1d08a999e68479e7ac667d228","autobid":100,"shares":239922},{"address":"0x8d10d894e99c76cbfa77f29af1d8ba1c7b9c02ec","autobid":100,"shares":29667479},{"address":"0xc9de59e8c2e34ec1d08a999e68479e7ac667d228","autobid":100,"shares":346984},{"address":"0xa632bbe79c851a360ff758862cfdd98e8d4031a9","autobid":100,"shares":693705},{"address":"0x7a25b16def085674ab3f88e136e2bcf92d31c815","autobid":100,"shares":225056},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":417027435},{"address":"0x2ae72279a2b4d043e81e3ffeca28f4bcc6baece3","autobid":100,"shares":208242}]},{"name":"*🐬dolphintwo","address":"0x8be2191d7ce01dbbe93c87fcd889a428a317d919","pubKey":"BKYVRvo0/9gFpqf+8lWx/TNvlO2QZtAe7FdsJsf1fXnuuqgEnft03AXt5xxMVXb9woYzyPN0odyCveVXzYE2YaA=:::aue7SlbELKmqVlE2W5tzwKk5KxwBWevOAWcT+Zg8637h9MD5lB4UeqTDycHG/aNSWD0Rc+HuokVA5kmvb6VdnQE=","votingPower":"343897866481204421891453","ipAddr":"101.36.127.130","port":8670,"commission":50000000,"distributors":[{"address":"0x8be2191d7ce01dbbe93c87fcd889a428a317d919","autobid":0,"shares":10670973},{"address":"0x95a55ba61bfc237736e7c97dda8acb61dd8c345a","autobid":100,"shares":2463039},{"address":"0xb463a6874df1be82d59a32e166424266af76b612","autobid":100,"shares":2333582},{"address":"0xfda3114e78fad5d33d628c6e895e23c216c67ee0","autobid":100,"shares":3389527},{"address":"0xbf85ef4216340eb5cd3c57b550aae7a2712d48d2","autobid":100,"shares":2041622},{"address":"0xef01faf03e976c3e5fbc6fcc21fdbe3741ab1ed4","autobid":100,"shares":352877},{"address":"0xca473d7f246dd069bd1244081fe2e84eeb495aa5","autobid":100,"shares":972404},{"address":"0x26705612546292241c81f6ff572dcf3f76576343","autobid":100,"shares":7749947},{"address":"0x2befaabee26cb6ab642a28a8fc16c78c60dce402","autobid":100,"shares":466496},{"address":"0x9f7f45eb459ab0fd8f2e58ce9172524b550209d0","autobid":100,"shares":472494},{"address":"0x2e9b5f6ecfe3b7b2b0b4a2dc3b285ea0f10c2d90","autobid":100,"shares":472494},{"address":"0x848ec68f3221ee18a85e7117a1deb2f8c74e5795","autobid":100,"shares":22702749},{"address":"0x848ec68f3221ee18a85e7117a1deb2f8c74e5795","autobid":100,"shares":16114295},{"address":"0x848ec68f3221ee18a85e7117a1deb2f8c74e5795","autobid":100,"shares":3488066},{"address":"0x9b7f77a7e3d41112d5a03282b36f6b75ca9c7ccf","autobid":100,"shares":293163},{"address":"0x82d038e252023158c4e54d73bb2f2c3c229cb55f","autobid":100,"shares":813577},{"address":"0xd2839749b28a048e6a82257b4292515165e0eada","autobid":100,"shares":43037047},{"address":"0xb138a440c5d19061c938276fc078c27e4ae85a3b","autobid":100,"shares":329147},{"address":"0xa32ad39dece579ae138811fcb819781e3a0ba241","autobid":100,"shares":6086092},{"address":"0x7777282c8b083ff4d1a87aac6a26a961ae55b061","autobid":100,"shares":582412},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":582387600},{"address":"0x4ece62124441dac7090ba575221f7b5d37bfc71d","autobid":100,"shares":531503},{"address":"0xdf33800a566f993ea50c409a5d85b7170b60c0fd","autobid":100,"shares":437300},{"address":"0x5f36afe0ac649d597dacafd653c2344089b40b92","autobid":100,"shares":1160019},{"address":"0xb08b050f1caac760f662c527c02ad59138bd4992","autobid":100,"shares":20376906},{"address":"0x80a63a848c2b945275c4d49c6554edf35e13a656","autobid":100,"shares":3318501},{"address":"0x6d6f80cc28dc6e936e3db0e5d77725c9b01339a1","autobid":100,"shares":625586},{"address":"0x8cddd94f4ff868111b4fed54d149a791e91099fb","autobid":100,"shares":394438},{"address":"0xbaba85c7ea4c1ff79f86bcbbf66778bf40bfd42e","autobid":100,"shares":437021},{"address":"0x734e3c5e1130c4378cc7be37e775178883364a0c","autobid":100,"shares":813107},{"address":"0xefee31549ea157d2191edb42956e661284378ed9","autobid":100,"shares":471517},{"address":"0x31801cc97ab652e5c968a57564e80de3f919ab99","autobid":100,"shares":3086518},{"address":"0xbe7acad0aa02ed06c7f3c27c6172b36e93d70662","autobid":0,"shares":472321},{"address":"0xebb86150862250654e8ff50401e30503d9ae272e","autobid":0,"shares":472321},{"address":"0x48a8991dc20d4bf5da5577282801e6ab52a84626","autobid":0,"shares":67337672},{"address":"0xd2d8e0cf1da9903e6958f5b6f60380f2f3105e02","autobid":100,"shares":40713105},{"address":"0xdf33800a566f993ea50c409a5d85b7170b60c0fd","autobid":100,"shares":437303},{"address":"0xe3aa575d47e435468060e9f9bc488665bd9bc32a","autobid":0,"shares":151695240}]},{"name":"Noderunners","address":"0x5bfef0997ce0ea62cb29fffb28ad2e187e51af26","pubKey":"BMtKc1gDWzrisiVg66YpyzxPHYxV8Ltfi9oV7gX9OClR9xQdpF4rJgLeNjxPybkTEOfo/WAukAxIJF10oDAZxyo=:::uT6squdXlDoMtDoevipaVIagQJkYzJQY4jl3Vs7XevmPLOiUr9njGnCpF2oZmDiUC10gy6dsldlM7/N6nO3Q8AA=","votingPower":"252720361779071578785979","ipAddr":"89.163.215.210","port":8670,"commission":40000000,"distributors":[{"address":"0x5bfef0997ce0ea62cb29fffb28ad2e187e51af26","autobid":100,"shares":10320218},{"address":"0x5bfef0997ce0ea62cb29fffb28ad2e187e51af26","autobid":100,"shares":396926},{"address":"0xc315fcf319a00ef06e2e4d7bbe99907091e1e9f1","autobid":100,"shares":635035},{"address":"0xee763ead4b11e330efaf8dc7e4ae08dabc4d7837","autobid":100,"shares":69389356},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":21323769},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":20806178},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":793749301},{"address":"0xbabcdec1ab34369befca6888edcdff523d8bb693","autobid":100,"shares":39646971},{"address":"0xa02c6c3b3be52438f66b3970e6d729d1805d96c3","autobid":100,"shares":1585770},{"address":"0xd1ede7b4e1b91af49d370e69d8471d24707a1456","autobid":100,"shares":1927301},{"address":"0x5b6795c6cc79470532fa3d555ea3d513f420726e","autobid":100,"shares":1399503},{"address":"0x80bd14693cce7624fd8189dd44f9d2d32dfc7bc4","autobid":100,"shares":634683},{"address":"0x5bef3cd067d67e98ac0736c3c6e89174e223fb7e","autobid":100,"shares":447899},{"address":"0x278d25bbd45370949540d80dfbd09beef78548aa","autobid":100,"shares":1368537},{"address":"0x68c4f0f62f3ae4a67d2242f1ba9b91a7258e43de","autobid":100,"shares":1368538},{"address":"0x45c7d77703218e35dc7f71e0db90a7605be1a68d","autobid":100,"shares":2745007},{"address":"0x64790a1f9171fbc955b2070f4619ac7ced0bda4a","autobid":100,"shares":1368536},{"address":"0x787c150d14f70ffbb0db0e58cb372dd2997cf02d","autobid":100,"shares":1646210},{"address":"0x42f2b8c49ffba51b71c29655815e4ba30d6f981a","autobid":100,"shares":19799552},{"address":"0x5cdd7d799bbfe72287a0ac316fa2b6e311fdab83","autobid":100,"shares":3951068},{"address":"0x5bfef0997ce0ea62cb29fffb28ad2e187e51af26","autobid":100,"shares":439319},{"address":"0x7777282c8b083ff4d1a87aac6a26a961ae55b061","autobid":100,"shares":819131},{"address":"0xe3aa575d47e435468060e9f9bc488665bd9bc32a","autobid":0,"shares":4231181}]},{"name":"Nodeasy.com","address":"0x6ffce98521d49780b31161bb9466b75d33fe1ab8","pubKey":"BG2jdKaMVcijCFp7Z9ApCSAwJIRkAnh3rPrlKzpHVhqygG7d9yZuoQAI1z9jktnh2qJCZuZzjBOA1RiR4nu8LDA=:::MMBzl6pWz3KR/TTF+h1KsiYYqEweI0huVcu4Nzq8fe0Bh76qyGLCEGZYCPEcdYH57P01Q/9ebqTZRhN4zYBO6gA=","votingPower":"244273356376834411465004","ipAddr":"65.21.54.162","port":8670,"commission":80000000,"distributors":[{"address":"0x6ffce98521d49780b31161bb9466b75d33fe1ab8","autobid":100,"shares":12978371},{"address":"0x3ece69bddc4418b3780d8e17f0aac9220f567c05","autobid":100,"shares":2053486},{"address":"0xbf85ef4216340eb5cd3c57b550aae7a2712d48d2","autobid":100,"shares":410697},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":246359157},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":738198286}]},{"name":"Meter_Italy96","address":"0x3a87eae486c641820b13a476e67005a455ae7c34","pubKey":"BLfL0UeR86wSJ/erzIOXwAhlRPpDXC0+LeoG0YePJEbfrif/i8DQ+nM77gya1VfsOJC8J9R8UOVx2TobhwUuEVY=:::KnKq4OM0CTgnItkiKvsQU0bwpWVvUpHv2Ji35CPzG3WSqx1hO7O+f7+wRH1gcKzHLK6/h6cZ0iGcADPn8VdFpAA=","votingPower":"224748414496502661303192","ipAddr":"54.199.33.223","port":8670,"commission":100000000,"distributors":[{"address":"0x3a87eae486c641820b13a476e67005a455ae7c34","autobid":100,"shares":54113986},{"address":"0xf6b33b143631237df679a954d0f83fa293d716af","autobid":100,"shares":43072052},{"address":"0x17b618152bd6689b0dd371092832210ba37c1daf","autobid":100,"shares":11675234},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":891138726}]},{"name":"huglester","address":"0xc3da1e332dc1de729b1ae2aa94821046be4ff1a4","pubKey":"BLIDkAJw+nSURFpJZUDXXruzMzB2Ht4Bt4FwWN+KZbGwtVBiTzYE+CwIpOtr66COcZj/I6SDBYLzZW3fNVv+uk8=:::nOxWmBeIFXQAzUV8CuWEiFr6keaqwzhlv1LwS+6CuZsnD5mb2oV8GIVnoV3QJt0qMP0W5NAexW6Hmd4mhxZ3uAE=","votingPower":"212473704816717402348130","ipAddr":"168.119.143.40","port":8670,"commission":80000000,"distributors":[{"address":"0xc3da1e332dc1de729b1ae2aa94821046be4ff1a4","autobid":100,"shares":33051371},{"address":"0x42f2b8c49ffba51b71c29655815e4ba30d6f981a","autobid":100,"shares":23604635},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":943343992}]},{"name":"*SeeYouLater","address":"0x16fb7dc58954fc1fa65318b752fc91f2824115b6","pubKey":"BKB+x5yubzeubAQ941ERyHr5RxCRLvDeYb5j53yyGj2vYhZsKH3pcnM1PEMEfwNZD7J20mF0MQoZ4L6RxuKRm9c=:::he90rmuLPiHBBwGWGzZMrAj5QwkI7f/yz/j93itLUc+6maDdyH5k75q680bbQOBhi4CMstWZq7DhtNvyKCxzsgE=","votingPower":"211729359818024421608992","ipAddr":"139.180.188.132","port":8670,"commission":50000000,"distributors":[{"address":"0x16fb7dc58954fc1fa65318b752fc91f2824115b6","autobid":100,"shares":9476689},{"address":"0x9e26622cf2e902e06a740fca6ea7f99f8d5f0b3b","autobid":100,"shares":758133},{"address":"0x16fb7dc58954fc1fa65318b752fc91f2824115b6","autobid":100,"shares":9476624},{"address":"0x0d0628076238b734759c29e575fba331de2a9f09","autobid":100,"shares":5211662},{"address":"0x7acd43327f5d77fd6fe546ee3cd1d0cc69bb02c8","autobid":100,"shares":473766},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":23685502},{"address":"0x8cddd94f4ff868111b4fed54d149a791e91099fb","autobid":100,"shares":499466},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":946661368},{"address":"0xe2aa22be6d1c042bbbbc96f9284ef48750627a4d","autobid":100,"shares":3756785}]},{"name":"crazybee","address":"0x801100c7f48dfb8755b0ed6a818eb0d8f9366579","pubKey":"BPJVssfCQawKsHH7plRn4dcgjBUT+iKX+uMwpi4i5LC7Si+AQasmfjdroZ7YIClloiHjtCuqtJm4YC/4HEsLgkQ=:::uKBrOO5Fgmg9T4b58mPYq4J3faeNpWFFtwutGrgul9gRPi92sMUb26ZAkR9Y6JynMgZJ551LVNTydQ+nA+FIMQA=","votingPower":"211079737767704179347760","ipAddr":"47.241.163.87","port":8670,"commission":80000000,"distributors":[{"address":"0x801100c7f48dfb8755b0ed6a818eb0d8f9366579","autobid":100,"shares":13307732},{"address":"0xa40d2d4cf2eff086ba681c8b221d6383cfbd5d41","autobid":100,"shares":9980795},{"address":"0xa0b0ace25cc157a5403e562b11e7fef2e5aa69d5","autobid":100,"shares":765145},{"address":"0xffae8aca19ef3b950bfafebc62b99200e57c7aa6","autobid":100,"shares":765145},{"address":"0xa30ee390813be065ffcb0880471fcec8794c106d","autobid":100,"shares":765145},{"address":"0x98a058fc915151c16a4c2a762ae2c78f42815101","autobid":100,"shares":765144},{"address":"0x9314f1eb0b388b811c0684215d12ccf6af1af6e5","autobid":100,"shares":765144},{"address":"0x71e0e515b418a870c125efbac180ee450f9fe4ed","autobid":100,"shares":12626476},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":949574014},{"address":"0x794dcf9411d0edf4be0154002a09227241b44eb2","autobid":100,"shares":474782},{"address":"0x99669d9c7823bd84a1c937cf86c074ff73477707","autobid":100,"shares":9495455},{"address":"0xbaba85c7ea4c1ff79f86bcbbf66778bf40bfd42e","autobid":100,"shares":715018}]},{"name":"PngMeterItaly","address":"0xd7c2edb33b8509e1e7cbab03e43e38835cf269ee","pubKey":"BLMptAMmoQvGrz/YuIZ2uyytdE4kBRt3MLdYQ/1mvRkAk1TENyYcve697hyE5jFfvzs4JRBdL3q6NvfKx2jXCKk=:::S0GOIHxpWo4eucle+69y3vO8TdzsIEKWwksu92bWmQLMRE4J9rdwPsqON4HJMLI3qMfz4xoUEEAg9pN9Uuqi5gE=","votingPower":"208561750098505765659978","ipAddr":"54.250.242.174","port":8670,"commission":100000000,"distributors":[{"address":"0xd7c2edb33b8509e1e7cbab03e43e38835cf269ee","autobid":100,"shares":39699709},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":960300290}]},{"name":"!!HashFuture","address":"0x78be5b7a6b54e54a6d84900d4470d66e30a0f5e0","pubKey":"BCIPb+1wUYcDRfTLVmjvpZRNY2En8ZiQT52yvSRtyCu3D2OeVB37kEQZkDoVJvB00GdhhmbdCqEMXzG1NeYiQhg=:::mVX8AjtA5ZYAxp2NVkRGA9YwAf4MWPle3ta4Rss1+y14hCN7iMdotePSYUNr0b2xMjGoP0PclNkxf8+DS/VcEgE=","votingPower":"205711306595245366685964","ipAddr":"173.249.41.81","port":8670,"commission":100000000,"distributors":[{"address":"0x78be5b7a6b54e54a6d84900d4470d66e30a0f5e0","autobid":0,"shares":10241270},{"address":"0x8339c600c21b914814812f509d3d21fdcac86ac3","autobid":100,"shares":790026},{"address":"0xea964674cc9752bbab9ee797c4f2a4fd180af3d7","autobid":100,"shares":8289998},{"address":"0x953269ecf19a1fd0a26bcb74b0afe5db7acdc013","autobid":100,"shares":5815720},{"address":"0xd969c6fbdcdcb4b548f260fd3e965bdc8471129a","autobid":100,"shares":554930},{"address":"0xd4410a348663be23d0672a3538640ae82d0be728","autobid":100,"shares":789922},{"address":"0x125b2938847fdea1cc0d8d13375ef20575034dae","autobid":100,"shares":789921},{"address":"0x07e99ee6676a1f58e335dc71614d40fcb645e024","autobid":100,"shares":789921},{"address":"0xaf3c158aa19f751a5204765c120b783097d78dc0","autobid":100,"shares":789921},{"address":"0x0b6d1a8c686885bf94436b53aff068bc9d149283","autobid":100,"shares":487600},{"address":"0xccb8a32739579b704474c3988278457637a2fca8","autobid":100,"shares":789866},{"address":"0xb720493b4d5eb2e1b3dc47806da17fc0510e1a78","autobid":100,"shares":789863},{"address":"0x90fdae33cace65888c86639fa3f1e1045326faf4","autobid":100,"shares":554854},{"address":"0xe3640763fc1069358dbc76948b5a38077e79b11a","autobid":100,"shares":789863},{"address":"0x859da443e4cc63d02bf3df7de56000a6cc72a77f","autobid":100,"shares":573723},{"address":"0x67c7a2630c67db40ef190e72949df40310a932ab","autobid":100,"shares":554951},{"address":"0x2e356a3dfe9d70fd266f2570345e98224af81336","autobid":100,"shares":789862},{"address":"0xe353ddb0bfa58a7ee5f29356b745dd21c5c21336","autobid":100,"shares":554951},{"address":"0x164f91accd8246b7494615db0cdb4763b504ee7d","autobid":100,"shares":789862},{"address":"0x405838f0ec0c1572289628508ca652464fdaba1d","autobid":100,"shares":789862},{"address":"0xcb6efb8c6bf90fd7461c3242fb8d7852b72d18ed","autobid":100,"shares":789862},{"address":"0x41e8e0beae637255cbe592a09478f88d67c2b291","autobid":100,"shares":789862},{"address":"0x4cbe18d76f660c21e3c820870dd0930a60a6bfe0","autobid":100,"shares":789862},{"address":"0xedabe7d32194fca9fec27f8066b811d0d226c4c5","autobid":100,"shares":789862},{"address":"0x0aefa236bf176989c89e55e22d9b07d4167769c1","autobid":100,"shares":789862},{"address":"0xf049f4e0bb8aafe14b354fcc902905cac565356a","autobid":100,"shares":789862},{"address":"0x753bbc57ccb90f812e87821e5ef1a1be74c769c2","autobid":100,"shares":789862},{"address":"0x7ff3a4c50ae0f52550447a26134eab33c1d11d91","autobid":100,"shares":3413324},{"address":"0xef01faf03e976c3e5fbc6fcc21fdbe3741ab1ed4","autobid":100,"shares":617956},{"address":"0xe123d95b701f3e53356bfa4be1bb6de474b35e19","autobid":100,"shares":2031850},{"address":"0x7b66447a43cf82731c51f9faa19703dc050a711f","autobid":100,"shares":789920},{"address":"0xfb4219bbab6e3f14262dbec3a1f9d9e77e0cd89e","autobid":100,"shares":12955996},{"address":"0x7fe8da0b854956526eb1540eb7068eb2668e3b35","autobid":100,"shares":795563},{"address":"0x0de073fc2c105f890ed2ff6f70f3518b5d7fc231","autobid":100,"shares":1493508},{"address":"0xeb53000385e5b5f70f27293310623b76ffb09b20","autobid":100,"shares":789311},{"address":"0x67f393ffd13c57af007d1a5d315a8a33345100a6","autobid":100,"shares":789277},{"address":"0x5913ab6179430a1660ffba7e2228f5cbf00a57c8","autobid":100,"shares":75082570},{"address":"0xde647d750e03226ccbea44d43abe4d27da81971a","autobid":100,"shares":30608429},{"address":"0xe763a6e0e1ef8025431d80cc1295cf289d143b5c","autobid":100,"shares":550252},{"address":"0x85320afdec9db950b6f443472f6aaa1079750f28","autobid":100,"shares":550252},{"address":"0x7777282c8b083ff4d1a87aac6a26a961ae55b061","autobid":100,"shares":1119685},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":243401753},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":486803189},{"address":"0x8c2786c3efd9b1e15b8c9c55d658b556777fcbb9","autobid":100,"shares":1631511},{"address":"0xd39e528eaa3af4ec6b9c3cb4d1f02e40d8bb530b","autobid":100,"shares":640050},{"address":"0x885226bf815e2a7450d3bc5e21e51afed460e086","autobid":100,"shares":1921280},{"address":"0x6dd07adb7802ae3fa8a7cfe23245e472890a96df","autobid":0,"shares":3413754},{"address":"0x03f12378c53ad29698c789687a6c97240d911624","autobid":0,"shares":3647840},{"address":"0x78be5b7a6b54e54a6d84900d4470d66e30a0f5e0","autobid":0,"shares":789863},{"address":"0xba3cc41b18e78f161b240f661cec02315e7e1847","autobid":0,"shares":7884033},{"address":"0xf3c69175cb24ff525024e55587f20268b0523fb5","autobid":0,"shares":6300816},{"address":"0x74c5fc54e45ba53dbea0b80920ce15a5bed8e189","autobid":0,"shares":5325457},{"address":"0xe68970ba8355813375085aa7e14230ebb3813225","autobid":100,"shares":5364148},{"address":"0x82b00401b4b3939ad5f46522da7c9910d170db4c","autobid":0,"shares":9121929},{"address":"0x7b02341d7f08a1c010d2b54183d7d2c0fbce8d4e","autobid":100,"shares":576867},{"address":"0xd2d8e0cf1da9903e6958f5b6f60380f2f3105e02","autobid":100,"shares":48771496},{"address":"0xddb28b39d66b18d171800088e06cad5ec28d8726","autobid":100,"shares":972168}]},{"name":"anthonyhuanggr","address":"0x150b4febe7b197c4b2b455dc2629f1366ea84bd7","pubKey":"BBMrz0qwhJAJi7h0TL0ydH6cdhObV3nNchgttULvGTYs0rAtE8C1N5ev30TnUWdl2UJRy+wxOSWbBCFZz9vvoUc=:::OBmw1YnbMnBd1slmlASL1HOXvWPLrGYkpil64hgkYcKm5YkuWDqdhMO96g3njtL6XSNzRjFMSzuerp4R5hVzpgA=","votingPower":"203466090323756976153493","ipAddr":"54.245.166.7","port":8670,"commission":100000000,"distributors":[{"address":"0x150b4febe7b197c4b2b455dc2629f1366ea84bd7","autobid":100,"shares":108472686},{"address":"0xc1047b43383dda14677a80a3a18bb3b4cccea7e1","autobid":100,"shares":4930298},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":886597015}]},{"name":"Falcon 1","address":"0xdff1ef2b977b3e1c1c32068820f36584003cbdd3","pubKey":"BIVFbHcUT0H4bguYHkUwMOD1zKH+8B8ZKdlCv/hu04MCdjjNhQximKXreIxidR5Z+zR0akI6EJQj4s/dmYJ8Dk8=:::jiqIx7XeSd1a0exzL+N77AaQbhI3lHuWVnMkc18yZvPYMrpFPC8Ism32tBSje1o1EheM3+hjaV06sdsvqg5k3QE=","votingPower":"202471233328723190840257","ipAddr":"3.34.200.83","port":8670,"commission":100000000,"distributors":[{"address":"0xdff1ef2b977b3e1c1c32068820f36584003cbdd3","autobid":100,"shares":10011130},{"address":"0x869ce8d31c010fffc576c45c3ed1c0fefe3518a0","autobid":100,"shares":801640},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":989187228}]},{"name":"B.A.M","address":"0x00708f68bdacf412e2ef7d01faa3e8039df46f3b","pubKey":"BOammjiVF4ptbuAHqHRwO70gpDeVyyZgHDcZQRhcUyIFL/ui7XADDrDjlAuJLp+FlYdAXaSLtiXE6/XTvn/oWj8=:::fCpouAxVf0/OtGSNpCVTebLen4fL3ZO1QWzHHM5547peNGkAStOty77dDzCd3rEuB2XPG5LjlnMhBcHeZxzSTgA=","votingPower":"200752063473329190972365","ipAddr":"45.76.144.29","port":8670,"commission":60000000,"distributors":[{"address":"0x00708f68bdacf412e2ef7d01faa3e8039df46f3b","autobid":100,"shares":24981679},{"address":"0x7a26254dd7ce465cca7d3ce0bef6439227052c5a","autobid":100,"shares":10794148},{"address":"0x0805902001d37fbd64c7caabd1a7aa663cca925c","autobid":100,"shares":808969},{"address":"0x26cc168d3cfe0ea3a7704ef76cfc246d87cc4b89","autobid":100,"shares":13276048},{"address":"0x97f70b9d432414b4004c3f54976d38eaf5497619","autobid":100,"shares":587989},{"address":"0x5d1792eedfccc053e84c3c428922e90641864d31","autobid":100,"shares":808544},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":897891869},{"address":"0xbabcdec1ab34369befca6888edcdff523d8bb693","autobid":100,"shares":45360850},{"address":"0xbabcdec1ab34369befca6888edcdff523d8bb693","autobid":0,"shares":4984854},{"address":"0xd39e528eaa3af4ec6b9c3cb4d1f02e40d8bb530b","autobid":100,"shares":505048}]},{"name":"HashQuark","address":"0x59c59c58fbcff3ee1e550a23a537aea791885733","pubKey":"BKHb6T8chXrcLh1tCYNz78ZAiCeJzLd4f/o9YasZBi5eTer2GWKwm+msdZTONcx14osjy4rBWaRr5KPzP1UZn+c=:::crl8gT/f1KmY9BSuM8NQporeA3050MJVweAOK8z+/CY2IqN1mM/19K4fYwNdSHBlsETSo2H9jGdBMt4iX5dZTgA=","votingPower":"200725190707064941653140","ipAddr":"45.249.246.252","port":8670,"commission":100000000,"distributors":[{"address":"0x59c59c58fbcff3ee1e550a23a537aea791885733","autobid":100,"shares":10246026},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":89942331},{"address":"0x6bcb2acef180da4cbf10c5d706a1c091e9e9a42a","autobid":100,"shares":1097848},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":898713792}]},{"name":"DFL","address":"0x17a8792ab6a8bcbd321a95e2daed78adff9c154c","pubKey":"BMjardTyz3UzFDhmZEEuhj3Z4hn1cED9iaVZXf6ovitr3cAI8AY9901wo/UXdGTQCbd2WHdaXA7gtmME06m8Bjs=:::RMADuJwyZX/p89BMFsKJIDWhPiNA/Kk6K0/hlSGMDAb8ARulkXm3HIZYc41LFOQX+QaW2sdbzmrQNUdUvQgSsQE=","votingPower":"200094796426306443429426","ipAddr":"13.212.99.117","port":8670,"commission":100000000,"distributors":[{"address":"0x17a8792ab6a8bcbd321a95e2daed78adff9c154c","autobid":100,"shares":10025500},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":989974499}]},{"name":"LunaStake","address":"0x3256443cf7c7c6c9bf9c874f5f239225e0b3487e","pubKey":"BEaaRrf4wJZhtGlgmiffJGxn0dcR7mIbS5qMcDBvbfDs/njjV4jdSvibcQdE6agklgenUnMTlA9/6Zd3it1HxWI=:::VB7HSRio7qM9ikpBsDZw4UKRVkxH1jSV6+BE9tq1i+LlIdH/iP3Gf7kwSBTgKxStlglSA9CMNYp4M+XeNXb1jAE=","votingPower":"198817936189672136446376","ipAddr":"54.254.9.138","port":8670,"commission":80000000,"distributors":[{"address":"0x3256443cf7c7c6c9bf9c874f5f239225e0b3487e","autobid":100,"shares":10092187},{"address":"0x6000b0c987a60fa600038336468cfca07d87472d","autobid":100,"shares":3143534},{"address":"0x70680774cac8562299106badcf7afc1d47a8a8e4","autobid":100,"shares":504379},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":986259899}]},{"name":"NPC","address":"0xedf4c63fa2f3ab594318300c6914b0c9ae9d8612","pubKey":"BOIQX4eUFuT7gGkLR6DstG+WP1uRchjU4v4ru/UItPBh5uRosaRnrRrrUki7lmq/caXRtVfhUcwQV5tPEYFtpm8=:::bH8w0mGJ99JCrMg7x8qLFjlgDrQW8Y/YB7Wj7gOFX94gIXd+kofzbcQBPF37sVJHEwn5tJvT/WwQeu22SXTESgA=","votingPower":"189717356275265067131406","ipAddr":"155.138.160.127","port":8670,"commission":100000000,"distributors":[{"address":"0xedf4c63fa2f3ab594318300c6914b0c9ae9d8612","autobid":100,"shares":10554165},{"address":"0xbd44999904c59b87403a1824b3d199d520e4fa46","autobid":100,"shares":18630480},{"address":"0x991f3775c81d6f8331b9a812eda34ea48a7ea76d","autobid":100,"shares":20698338},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":950117015}]},{"name":"Wetez","address":"0x8b206256563e269de6aa37a80271305493f7474d","pubKey":"BFclh/A632/jfSzx30GB6H0DnUk3b68va0klkTaE7uWdLMQLgLt/2RkhxmDnwE3I9tg1fknII9tphlQonvUzZys=:::rHUPUxtYxjojxvv+o2DQ30r1KGPIKFLOk7StJ1LeAuCM5YGX2dKJeVgmZKsIu91uUJUm0fZRFwtpRfS+UxrgPwE=","votingPower":"184220566313102486047002","ipAddr":"194.36.145.108","port":8670,"commission":100000000,"distributors":[{"address":"0x8b206256563e269de6aa37a80271305493f7474d","autobid":100,"shares":19332529},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":979307678},{"address":"0xbb10140ae5a921bde21ff3cab6ee187039330618","autobid":100,"shares":1359792}]},{"name":"Bit Cat🐱","address":"0x19ee7bceba6181b0486d23b53e6e4ce0088ef3c7","pubKey":"BIy3EQg/ZRJ7rLopeViu4D51DCs4P/xhKVtGQLxnjr7LkoitJ/TR+kOu3oXnPnsPH9RyvIJW1ZRx8oFnVBJWwjE=:::fv55hEv0bpeN7LF2ef5X4CgArodSEQqQUSYsQ1aC5w1+MP9pPyvHoCO2xnUE24WUugSGhRA1MjOeVK4HPFQfAgE=","votingPower":"184002289322613560990595","ipAddr":"62.171.144.85","port":8670,"commission":80000000,"distributors":[{"address":"0x19ee7bceba6181b0486d23b53e6e4ce0088ef3c7","autobid":100,"shares":10903034},{"address":"0x3be11a515814f5295723bc6794ece88ae31aa690","autobid":100,"shares":882903},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":980381611},{"address":"0x128d0d1bb156054181390c4ad9e30d39942aac57","autobid":100,"shares":1520867},{"address":"0xba5a776b497a5e53e2535ab6fe662d76288aa264","autobid":100,"shares":1965898},{"address":"0xee1f65c97c256cb424d9aee8cc8cefef9d8375fa","autobid":100,"shares":1361407},{"address":"0x12e16dc6c7ad5c9e10ca14109cdf4a46da290580","autobid":100,"shares":571463},{"address":"0x12e16dc6c7ad5c9e10ca14109cdf4a46da290580","autobid":100,"shares":999585},{"address":"0xd39e528eaa3af4ec6b9c3cb4d1f02e40d8bb530b","autobid":100,"shares":543638},{"address":"0x30cb38295acb02f557bf982a640114b71272868e","autobid":100,"shares":869589}]},{"name":"isillien","address":"0x386d0d12134bd649d877fb4ddeaec700c2cb2537","pubKey":"BGiZcRnvCKWoGmQHTB278wJzVU3IPosit610CSI3HAsseBkwkY2THd5kM7satN38q/3assQOVYAPXyzd237Xek4=:::NqdOpjDib2jdiRXLi7WwKDNY0GwmzBbA/6v63Jm/Qc1cz84ZGZhawHYt3+rd35xXGAMHyftPjfxVpGLBklfE6AA=","votingPower":"183000430975710299339984","ipAddr":"46.4.90.79","port":8670,"commission":80000000,"distributors":[{"address":"0x386d0d12134bd649d877fb4ddeaec700c2cb2537","autobid":100,"shares":14252815},{"address":"0x0374f5867ab2effd2277c895e7d1088b10ec9452","autobid":100,"shares":985747184}]},{"name":"DFL Test","address":"0x78e6f3af2bf71afa209c6e81b161c6a41d2da79d","pubKey":"BJILLdUnkxU7dDfkluAO4proY7oaKb2fmt/+AxNz7du4b+7l9/oBCFzQJZB+5gX0OmCz8tLHpwMmdy7/BZC2txA=:::J5b5HwSEb3WOSBJj8gr8xeCAnjBlNmJtDuWrWAiFVnqtgLOc1hDb6ButSsTaQpqLEozBpG/LLkgWaH3B8TCpvAA=","votingPower":"133564325337961377473139","ipAddr":"13.212.236.0","port":8670,"commission":100000000,"distributors":[{"address":"0x78e6f3af2bf71afa209c6e81b161c6a41d2da79d","autobid":100,"shares":15006947},{"address":"0x08ebea6584b3d9bf6fbcacf1a1507d00a61d95b7","autobid":100,"shares":984993052}]},{"name":"erknfe","address":"0xcb609819b419d250026b595ab62db9be680bf24b","pubKey":"BLYFSxU8W1Sh5stLbKsl2mSxvuSKpgLYcsoWZ1RBZdDFcT18urAx57KzmQeTHnNj+qFY9KeEJjAzL67v3rJNfm8=:::F1GsqWtJuis6yM6zdXi4RUfbvKn0Wuma9vJhiWRE1Ni7G6oHvVu56QEUf9/bzo6qNsi/hoDVEDGAh21qcTqrbgE=","votingPower":"102617757027113925752747","ipAddr":"167.86.75.50","port":8670,"commission":10000000,"distributors":[{"address":"0xcb609819b419d250026b595ab62db9be680bf24b","autobid":0,"shares":24352304},{"address":"0xca0676a0e27a1e3d133dd9f99c7529309f194155","autobid":100,"shares":1583779},{"address":"0x9bc44da1a919a2564035e611b7967f8ccd800b14","autobid":0,"shares":1150389},{"address":"0x314aba55e9a3034dc7244b12743dd2ce9e2a4e86","autobid":100,"shares":15094753},{"address":"0x98fe66a59715f7e6350fe7e4446d101187d93ac1","autobid":100,"shares":1104732},{"address":"0xafdee197a58add3da13c253cec0b04d4c1d40197","autobid":100,"shares":1046074},{"address":"0x6ae2b7d7c6d6f3eaf27e8e6f973504b3b34604fc","autobid":100,"shares":1104732},{"address":"0xc3d8ec89f38d81f3df56d3e6be30081af0410a04","autobid":0,"shares":1583773},{"address":"0x8914d5250ea2858a06f2fcee860f6022bce9edb8","autobid":0,"shares":3392381},{"address":"0xce0fb318f31afb6d616ec58f8fa9c1aae206f4a8","autobid":100,"shares":1251368},{"address":"0x3af205ccec62a1eed976ebd4de850e43821602ba","autobid":0,"shares":3392381},{"address":"0x2c2b5dfbf282c2550a7eb872a980df31a9227b57","autobid":0,"shares":1584276},{"address":"0xdef562f731c2c700d18332d58f275d418efedba0","autobid":100,"shares":2548449},{"address":"0x427dbdcc596463bfb1c04cc63d7558afd79e5105","autobid":0,"shares":16320181},{"address":"0x86e549d398b3fe427ceaa93605ecc3039e1e8192","autobid":100,"shares":12337403},{"address":"0x7c22dd57bf4712ad4d26afb69c99a407ec5176f3","autobid":100,"shares":6000552},{"address":"0xa9b017f8795023a6a2c4036b4b65077b9b5d66fe","autobid":0,"shares":1584255},{"address":"0x94b1e28661d7cbbb834103f386f200efe81aa907","autobid":0,"shares":1583718},{"address":"0xe513fbe88aea3cffb5bfaa328846f9a1c1619072","autobid":100,"shares":1599699},{"address":"0xcb0326a4abc6911bbb4e665e1e37ef44f4ab9b4c","autobid":0,"shares":1583699},{"address":"0x1bbcb72c5a112aea1d615559186d1d12d75c523f","autobid":100,"shares":1583637},{"address":"0xf6ded1edef359b81019c166f3c0e4ff48562f3aa","autobid":100,"shares":1423292},{"address":"0xb9a57a854710f837661b31c35a9d2f8b94fc03f3","autobid":100,"shares":1573789},{"address":"0x9cfbf229d5ce8a54c0897dd7e77867d1f872b06b","autobid":100,"shares":7135646},{"address":"0x6c9ec80dccaa33e00a0711804cfa9b0d94e25da3","autobid":100,"shares":1593267},{"address":"0x77caa77ddedf1d5cabca2c60ea60b46afa6f32c3","autobid":0,"shares":983435},{"address":"0xa503d0931da0e831970d2592658c69f7ee94767f","autobid":0,"shares":983435},{"address":"0xc1e46c206a63e9a196820264fa213beb06667c20","autobid":100,"shares":1583500},{"address":"0x94f56705b3a776f08728604b0fb7a642774f825b","autobid":100,"shares":1103850},{"address":"0x44e2f4a3eda093b64790fac457f57f0db7c3773e","autobid":100,"shares":1375689},{"address":"0x01f2e3abfc77764a84aea850af60cff1b869bd6d","autobid":100,"shares":1589589},{"address":"0x8e26530e1d3ce2e31322ff12ddf27a65cc2bd92a","autobid":100,"shares":5626821},{"address":"0x2400ac2b8860cdb2528421d6b49fe87f237e078d","autobid":100,"shares":1581755},{"address":"0x5b16543c283dcb8d3c42585a4a479d4c97eefae7","autobid":100,"shares":1581755},{"address":"0xef01faf03e976c3e5fbc6fcc21fdbe3741ab1ed4","autobid":100,"shares":1182583},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":97734129},{"address":"0xb79618596d85eaf6bf9acf9088ab51fc86275478","autobid":100,"shares":1561802},{"address":"0xf5b67d41c1ecda8839a7013cff1efc622af8a6f3","autobid":100,"shares":1110993},{"address":"0x7777282c8b083ff4d1a87aac6a26a961ae55b061","autobid":100,"shares":985020},{"address":"0x571f08d865b06333a1a2062da231053ed6dc1bbc","autobid":100,"shares":1112748},{"address":"0xc0d059f7b4db726314238105f4a69e25050f283d","autobid":100,"shares":1141592},{"address":"0xaa502666ede1bfd4400bd582056e90d74feea85f","autobid":100,"shares":1141592},{"address":"0xf7baa4846df56c922c0d9b364f2bb5a361b6425e","autobid":100,"shares":2955137},{"address":"0xff65bf59cc4fe5cbaa30179540c3ede966388451","autobid":100,"shares":2277619},{"address":"0xd6a6d3ea931a6d0788b8a4d637c64a7ae03d2bea","autobid":100,"shares":1583523},{"address":"0xb06e2807328a0a36a8027350129822dc168fbbb7","autobid":100,"shares":1583522},{"address":"0x398f471ada182538cc9a91e5cfa3b162ed420107","autobid":100,"shares":1583983},{"address":"0xbe44bc3494b55e7e28f91f557c03c4e4c36c5fea","autobid":100,"shares":1583448},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":38116301},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":74447845},{"address":"0xd50ade20430d8ce4ef2fe361ed7aad9b0f998c81","autobid":0,"shares":2483183},{"address":"0xb18ce9f418efb94aab45d1d00938d04741a04f79","autobid":0,"shares":1582868},{"address":"0xd6305d28142f6534946ddae44ba00eb942c60077","autobid":0,"shares":1582867},{"address":"0x54b7204deb0799bf5fadec27e37d8985252e33d9","autobid":0,"shares":1582868},{"address":"0xac77e820a8a45d9a63e6b6f4f13f6a3bfc4b4c8e","autobid":100,"shares":1588005},{"address":"0x0f8684f6dc76617d6831b4546381eb6cfb1c559f","autobid":100,"shares":29292459},{"address":"0xce0fb318f31afb6d616ec58f8fa9c1aae206f4a8","autobid":100,"shares":1249590},{"address":"0xd2d8e0cf1da9903e6958f5b6f60380f2f3105e02","autobid":100,"shares":106933169},{"address":"0xe3aa575d47e435468060e9f9bc488665bd9bc32a","autobid":0,"shares":493684767}]},{"name":"CloudKing2","address":"0x353fdd79dd9a6fbc70a59178d602ad1f020ea52f","pubKey":"BOqHtOYo1TgDJ59MxAKFm+o2pILbkXVUXOFw0C2AhngFUvAAIty9pOggJkHaeQRzn18wKXQRFZRtzpLoYFN1/fA=:::O3wTshY8FNE9LATn+h/pLt1zifkQjcWQNm4uGLhpkH+waHJI+ykQp2hpSmQHnpXStRfNaon3BohVy1hOHlAQwQE=","votingPower":"70010705137401390632297","ipAddr":"18.188.84.29","port":8670,"commission":30000000,"distributors":[{"address":"0x353fdd79dd9a6fbc70a59178d602ad1f020ea52f","autobid":0,"shares":28603414},{"address":"0x6f02cfee6048c6300e37519239e9ba3313980492","autobid":100,"shares":114317045},{"address":"0x353fdd79dd9a6fbc70a59178d602ad1f020ea52f","autobid":0,"shares":28598628},{"address":"0x353fdd79dd9a6fbc70a59178d602ad1f020ea52f","autobid":0,"shares":85795945},{"address":"0xe3aa575d47e435468060e9f9bc488665bd9bc32a","autobid":0,"shares":742684966}]},{"name":"CloudKing1","address":"0xab35b9423deef8bf7d56ffe6d57d2ff4be970837","pubKey":"BHqX3LpA9a4y1jI+eG0rlJzFO5YiNMCQyfgh/H4HpDQik/mathkup+C5s52ddyU9dT88QSdeoMHwpRn8wASkjyc=:::fNuNUpmdeXjlRKOZxWif7/Qfrk/RQ7ytF8SyxurEmOmtc4EDn1gvcJmjJiLbgyOwHqPIC7XaJvrwCooCJNItRgE=","votingPower":"61175062618911719938533","ipAddr":"18.216.133.212","port":8670,"commission":30000000,"distributors":[{"address":"0xab35b9423deef8bf7d56ffe6d57d2ff4be970837","autobid":0,"shares":32734652},{"address":"0xab35b9423deef8bf7d56ffe6d57d2ff4be970837","autobid":0,"shares":32726936},{"address":"0xab35b9423deef8bf7d56ffe6d57d2ff4be970837","autobid":100,"shares":98187373},{"address":"0xe3aa575d47e435468060e9f9bc488665bd9bc32a","autobid":0,"shares":836351037}]},{"name":"cccccc","address":"0x7dae3f448d2e1c577ca008f84050079c02985ebf","pubKey":"BAhkz2TZqs+R2owUazmdaEUQc1sHi7i2aFVYcxrjB7lI/c331VzcwMi0HUQYv8knCd2Xb3gC9ywjUt+tNPkzb0M=:::aVesKyoVq63UsDkUqvCknu5mKUaoc8aiTW24znPPhW+iUAj+UisSMeSb5CbPayAWNZBpqpPlwbNuVW/psEG2rAE=","votingPower":"2900384683377727042090","ipAddr":"207.148.123.63","port":8670,"commission":50000000,"distributors":[{"address":"0x7dae3f448d2e1c577ca008f84050079c02985ebf","autobid":100,"shares":689655364},{"address":"0x7dae3f448d2e1c577ca008f84050079c02985ebf","autobid":100,"shares":310344635}]},{"name":"jetlife2","address":"0xab950438cf0dc5c2f98039731b9630358cc0deb9","pubKey":"BOUe2ytaFp0yyiesqkWqItpO06D6nQ/VYITHCq5IKbSpRtkEzEhWKCsx1VrhaYIx5L65SlhD9aUk64P0p+GrDf4=:::KBsP4Pfq7/nzQmok8uFxIBDIIiJ19oF8XRUeq/7odJOwXVzZtXKf8eqzgBiwPBm2h6VxZUOjtEAyznjYAF2fgQE=","votingPower":"2599267813595573312758","ipAddr":"82.65.223.126","port":8670,"commission":100000000,"distributors":[{"address":"0xab950438cf0dc5c2f98039731b9630358cc0deb9","autobid":100,"shares":1000000000}]},{"name":"NovaStake","address":"0xb980ff36a99c0c3b408279e025d8e2da7ef65105","pubKey":"BEdvoJsZLZw2mmKPkZIpLQ3B9/L0warwsmmS1dNHSXfDP0vBx6JSYsmuWwj/3so5j/mn9OfTJMMEMO/LtRmSPOo=:::LWilIBiprQWu9CfOxUGME9l09994UozRan3iterG/2zqtUzE6nw2Pv8vpAFJSQTz4fbq2hd5dHrQIjWX574ftAA=","votingPower":"2445736619501522069451","ipAddr":"54.179.74.104","port":8670,"commission":80000000,"distributors":[{"address":"0xb980ff36a99c0c3b408279e025d8e2da7ef65105","autobid":100,"shares":820410454},{"address":"0xc58439f7d84a61cac65a7f59e42ade934f8d4e59","autobid":100,"shares":179589545}]},{"name":"Falcon 4","address":"0x23499aa14cc3d1badf4dfef10248fe86b8a42d1c","pubKey":"BA37z37RVoRQme9Hx7ooryd8A+On9GG9BkM/pqN794Qv+1nxkjeciasK+sNHE1kBwyOy3hOV+Jmfyz9awm2An1Y=:::uVa7wExYahrmCLPv9kSCMZ4FO9AqD7tjPpngdxxB09P1gJt1cdugMDjwuEX9T2Pncvk2J4dLGIEk+Jm6SZrqFQA=","votingPower":"2429720614775177574356","ipAddr":"54.254.238.45","port":8670,"commission":100000000,"distributors":[{"address":"0x23499aa14cc3d1badf4dfef10248fe86b8a42d1c","autobid":100,"shares":834103281},{"address":"0xe1939c4eb18377d1ce9e1699454721513722464c","autobid":100,"shares":165896718}]},{"name":"infinitynodes","address":"0x824fb717b01b624ac0abbdb3519e6936019d1c2e","pubKey":"BEZO/q3aKFSIXgIh50FKN8zlNMk8CKdiYeq+rNLnDIFTpM/LYqAguaDuNzxHTgFXiB/imQLj9ZlFQMK5j/Cj9PE=:::Ptux3rGIa1Isfw7UVy5+LkFzYI4FFV33ssqmO6qTD8ZeocZybNJwKPLIpgCurN2tjQDwGP1YKt1XZ4GLMl1OrwE=","votingPower":"2257009319904236427747","ipAddr":"54.179.238.94","port":8670,"commission":80000000,"distributors":[{"address":"0x824fb717b01b624ac0abbdb3519e6936019d1c2e","autobid":100,"shares":889011693},{"address":"0xbb10140ae5a921bde21ff3cab6ee187039330618","autobid":100,"shares":110988306}]},{"name":"Meterians","address":"0x31a645d4303216feccf15e3408cd934deaa11961","pubKey":"BF+pjkGM9bDnjjRIEt+3W82sMj5vfcOm5EVTKQnUUtpP5DZS/ranmGy5/pPmz3/CxvtjcGiCuRNdem78kNg85yM=:::jMotv64MoMcQZLniGahXEWsZsAK18ycvZ9t1rxFOqQuwfGchEcqrWCS0YR9hJrkPd2Ny7kQETGXsTDSNapaZJwE=","votingPower":"2199014435641806189228","ipAddr":"18.159.127.35","port":8670,"commission":80000000,"distributors":[{"address":"0x31a645d4303216feccf15e3408cd934deaa11961","autobid":100,"shares":912457289},{"address":"0xcfdd0dcd6aa9fe40ad18a5be8b9601a3df21c622","autobid":100,"shares":87542710}]},{"name":"Falcon 2","address":"0x478986ff955a06a78024a91b3f07773dd1d58ce7","pubKey":"BALtaZ8UQabTLfLq3s9w5TF6cU44LaDUgrYPQgkDrE8nxDIw2/XJno9ORrUNw2+38BIMMOGHIb2w96400v0+DXs=:::Gj0D/xMLewWPPiiUq55wcPUF3Rcfm4XeKT+rILEgG64YvI0ul9rJnh6EShC0+sHUqNkWXAZqsFEFA3IaYZVBWgA=","votingPower":"2026939295779426686698","ipAddr":"13.212.188.168","port":8670,"commission":100000000,"distributors":[{"address":"0x478986ff955a06a78024a91b3f07773dd1d58ce7","autobid":100,"shares":1000000000}]},{"name":"Falcon 3","address":"0x2d10a5cf474828b22ac89423a812b08fd41dc1b4","pubKey":"BGWmp4kEgOUUWSwyfDSioqr50NCrkOq82bKw/EbUeyYMR2SXLuRkcyaYEamijd/PJbIeK0gLjSxhygVEhN6iJfI=:::t5Xm92uV/zyWIMeCsaQXr7exyyx04ZeXfhnjzQPWjW+1JTfscxhuMRmnrBEn6a12IRTgyvxpkaGDNwjWSY8ypgA=","votingPower":"2026638782897323693308","ipAddr":"13.125.138.221","port":8670,"commission":100000000,"distributors":[{"address":"0x2d10a5cf474828b22ac89423a812b08fd41dc1b4","autobid":100,"shares":1000000000}]},{"name":"Falcon 5","address":"0xf5fe4e9c5d2e008934cfca559dd61921d3c19184","pubKey":"BBKWAWPUDRArxwiDltNEORUStjBu7VW4wGMRQg/GNQ9GK5aTuRoAJckr9+K7yi4ILnzdG80pb1NWGsj1vAVn0lA=:::sIhlli4i/QhuGqRCOotr8IG/0dUzp3Jx2EsBCpB7Y9/DfSyBud9MvTtbRuWiA+1Y2hrFY0J4TfdeCqZKVxT0JwA=","votingPower":"2026637472349061389908","ipAddr":"54.251.209.163","port":8670,"commission":100000000,"distributors":[{"address":"0xf5fe4e9c5d2e008934cfca559dd61921d3c19184","autobid":100,"shares":1000000000}]},{"name":"Meter_China","address":"0xc34064e35b6b1cc6734ef11283c8ccb1965f3554","pubKey":"BKlDbAgOYwXvRv/aaPgFurf/v3BlCIokqdrqlEgdWT7lIDyjvc1kr2lghsWLD/McatoDL9P1N6jNg/H0ScwziVw=:::Ko1HNNW2h+kzN6DfMrQ/IKMc5vIeCA8xWQ3c8flJAN3i4PU6PqidQ2IIxHPq0UvHlPkGIEXQvNBGTeE5IjbAAAA=","votingPower":"2010122310692541856922","ipAddr":"3.34.193.113","port":8670,"commission":100000000,"distributors":[{"address":"0xc34064e35b6b1cc6734ef11283c8ccb1965f3554","autobid":100,"shares":1000000000}]},{"name":"PantaLabs","address":"0xe66893147a730ddaf9e0ae42ad7ec8456d1fc50d","pubKey":"BJmMSFBVnr0JSTo6MhJr5TWlVaKp88tIZhwsn83hnwwAa12WKJrgJLYgmXs3cudZoENDhqpVWLTo9mLogc5fZv4=:::Io+O9rtIHMRB+2b0KAnARYTe7pD+r3U3DrVS9dPEpHgKcLXSpdnkkx8pbfdBCz68s+stkWcajRCEHN0O1tbC4gE=","votingPower":"2006507435946220192505","ipAddr":"52.77.114.121","port":8670,"commission":80000000,"distributors":[{"address":"0xe66893147a730ddaf9e0ae42ad7ec8456d1fc50d","autobid":100,"shares":1000000000}]},{"name":"SumerMoney","address":"0x903f577c60796bac2bab28c71d1cb1fdc6c4c409","pubKey":"BBzIyiEmHlh+k9qbN/jQWmW7CfFzjmnNJPtSVCpPobH+0k/2zgtPic4UcHA7WiOrdWR6aAUML/eVvPH/p4LR2xo=:::PvuNxTb5AFHAQmZHhfJv3lS0i1dpIKNVuyUuaJSzFCtkG/Zhd9N0UAhe9I211GQL09ielD1MHAAvdvqUq/HxngA=","votingPower":"2006507315449010654201","ipAddr":"34.208.128.26","port":8670,"commission":80000000,"distributors":[{"address":"0x903f577c60796bac2bab28c71d1cb1fdc6c4c409","autobid":100,"shares":1000000000}]},{"name":"Staked","address":"0x42abec81b1cf45825c9dbf4d695051df90ce75ca","pubKey":"BJbdlkf5FYkKcBArsZoh2HiG9bO7e2Wpc5pPQ2vcsX5KTJr+gzUbCyvrThVXaGKWS2HUpXdqZ5kfPO2uAWR6X1I=:::BC0M7dmHdV3M0eSEQz3vli+n3FkS8mPPSz/Y7FA2r/ugqmqf12c4YLNVCdtLQAfmmfzQhXKQTqay+o3rNMf3AQE=","votingPower":"2006507229832572298036","ipAddr":"54.213.175.41","port":8670,"commission":80000000,"distributors":[{"address":"0x42abec81b1cf45825c9dbf4d695051df90ce75ca","autobid":100,"shares":1000000000}]},{"name":"calibra","address":"0x9e9a88fb8df6d9419e4563a357aa40280c88020b","pubKey":"BBJ16RGbjlyunmWC8RcD5P7CGMH0dh7T3whhV3XNHaCA3kcbzGYeuc4E7T6W5xJO96ly3eEp5bhq9qdm/5Zpb4M=:::mFHRB+OK/l8ye2rwOZXO9Dnlu4rnPq6reaygdJ5dBiOkMYHSLlJ0A+bFhzabk+TB8NKGx617d5NZ69LS/J3qdgE=","votingPower":"2006507109335362759730","ipAddr":"44.233.110.76","port":8670,"commission":80000000,"distributors":[{"address":"0x9e9a88fb8df6d9419e4563a357aa40280c88020b","autobid":100,"shares":1000000000}]},{"name":"certusone","address":"0xc4a6d309f6f755d0eecde320423896d42e545fd9","pubKey":"BPO/wh3qI7oxiNZFkp7hNvL0NIPtQTzONkXq/oCIIYKmB3/FzRK7b2Z7kI/wnfeeyEDKbjfMicH1kbfxJRwMNx8=:::caxrbfsN8mxPYlNi9czhsA9TSOLt0Xp/npCmcfh/27fZcJgTYaQ4fTnPY4dEPReWJnvoDBzaowOZLRURaj1wbgA=","votingPower":"2006506979325215626295","ipAddr":"44.238.1.188","port":8670,"commission":80000000,"distributors":[{"address":"0xc4a6d309f6f755d0eecde320423896d42e545fd9","autobid":100,"shares":1000000000}]},{"name":"stakerus","address":"0x6c814a9176922f462f0d21dcb512693212ca5a88","pubKey":"BJQkmBX6K24DPM920sK5CxRFEEzfGA0wIzNR/bTUpXBEp9AkNEArZJ/eMPBX7E/kcPvrv+VpESLH+ECs3wuUOdQ=:::OeyP//h+fig22Sh6uFL3ktVtOTyyPq2RAYbNmXCgRIRpcliJh2X6zOExIaZZbhv0SrDYPbSxEbhjW4mrOoX3PQA=","votingPower":"2006506861998985286366","ipAddr":"3.127.246.0","port":8670,"commission":80000000,"distributors":[{"address":"0x6c814a9176922f462f0d21dcb512693212ca5a88","autobid":100,"shares":1000000000}]},{"name":"SolarStorm","address":"0xe135af414ef577807d85f292dce067cf20964971","pubKey":"BOwo7bnxahUTL4DeSGo4ioZqocs1LjY7h9drAVP/HVHmXBD3WVDJrUIhzcIR3XpyzShw9RWxS0Oi788mEisVGSE=:::ZbPc7iPjBmHapOKNusHK8ux8791J65xQrgFTUGpEpqOWh2cF4bstR2q6QO5hi/SykqeVvw6pqiI22IIlP50EOwE=","votingPower":"2006506646372399796767","ipAddr":"18.193.104.143","port":8670,"commission":80000000,"distributors":[{"address":"0xe135af414ef577807d85f292dce067cf20964971","autobid":100,"shares":1000000000}]},{"name":"Trafalgar Law⚡️","address":"0x48f450d5f51b5340f8d7ebd9a8417625ea5df531","pubKey":"BDPXTG1sXY6q2CvbdSrlGxo6UubaBTOItl5Cmtbb788D1aI/qgk1/FZ1XbJU6CDFiNfMZ5xEWlKfL95Da6OOoTs=:::VaqjGkZB2bIl3EaMosOgbL2/c6hiFg5q0E+x8qiY+yA23bTzDAmahvj9ThV7+mw79qe43R5TsT5i8aiAxqvXXgA=","votingPower":"2001495253210616438339","ipAddr":"18.188.47.21","port":8670,"commission":100000000,"distributors":[{"address":"0x48f450d5f51b5340f8d7ebd9a8417625ea5df531","autobid":100,"shares":1000000000}]},{"name":"Inotel","address":"0xa9e3ac784db15472d16aee912bd0cd976c6cfe78","pubKey":"BHpejUUku7nK0PEpo6HooF6rAMFQFyuZGq3ROQW/L7hsaNAYkp2rSJgI2BX4AKO/hMmyGtri6dzPhJkhs1kx1Ck=:::PBOmXgmmFKOwMdnggpyjPvWgGJPqxtqgosdNVoF5ikqTnj3AsP5R2cHi0rbDGgeUa4xt+fAhj18vXK7bJxV8fQE=","votingPower":"2001043499492643328214","ipAddr":"185.144.83.167","port":8670,"commission":100000000,"distributors":[{"address":"0xa9e3ac784db15472d16aee912bd0cd976c6cfe78","autobid":100,"shares":1000000000}]},{"name":"verso","address":"0xec190235fe6d05b352826da07e12324b358906d7","pubKey":"BA9YO6aIw74kN/wMd1W71PjAREtv+LwRjR8kXb+UxuWJYy5+EAoYRW7LGPr1LJ0Mkt78k4FVi5O2uNvefLsC9fo=:::bYscmczI7MjZgf1bmU4fy7/PCoYt73KAv4H2nZhC27CfxlR1BNXFB4+5UUJ9IDLcGlCjWTAme7j8EEOLJMZyHAE=","votingPower":"2000477568493150684917","ipAddr":"116.203.148.101","port":8670,"commission":50000000,"distributors":[{"address":"0xec190235fe6d05b352826da07e12324b358906d7","autobid":100,"shares":1000000000}]}]`
-
-func main() {
-	apiDelegates := make([]*api_staking.Delegate, 0)
-	json.Unmarshal([]byte(delegateStr), &apiDelegates)
-
-	delegates := make([]*types.Delegate, 0)
-	for _, d := range apiDelegates {
-		dist := make([]*types.Distributor, 0)
-		for _, dd := range d.DistList {
-			dist = append(dist, &types.Distributor{
-				Address: dd.Address,
-				Shares:  dd.Shares,
-				Autobid: dd.Autobid,
-			})
-		}
-		vp := big.NewInt(0)
-		vp.SetString(d.VotingPower, 10)
-		vp.Div(vp, big.NewInt(1e12))
-		ip := net.ParseIP(d.IPAddr)
-		netAddr := types.NewNetAddressIPPort(ip, 8670)
-		delegate := &types.Delegate{
-			Name:        []byte(d.Name),
-			Address:     d.Address,
-			PubKey:      FakeECDSAPK,
-			BlsPubKey:   FakeBlsPK,
-			NetAddr:     *netAddr,
-			VotingPower: vp.Int64(),
-			Commission:  d.Commission,
-			DistList:    dist,
-		}
-		delegates = append(delegates, delegate)
-	}
-
-	baseReward := big.NewInt(479166666666666636)
-	totalReward := new(big.Int).Mul(big.NewInt(3057035746508147716), big.NewInt(100))
-	rewardMap, _ := reward.ComputeRewardMap(baseReward, totalReward, delegates, true)
-
-	distSum := big.NewInt(0)
-	fmt.Println("**** Dist List")
-	for _, d := range rewardMap.GetDistList() {
-		fmt.Println(d.String())
-		distSum.Add(distSum, d.Amount)
-	}
-
-	autobidSum := big.NewInt(0)
-	fmt.Println("**** Autobid List")
-	for _, a := range rewardMap.GetAutobidList() {
-		fmt.Println(a.String())
-		autobidSum.Add(autobidSum, a.Amount)
-	}
-	fmt.Println("dist sum:", distSum.String(), "autobid sum:", autobidSum.String())
-	totalSum := new(big.Int).Add(distSum, autobidSum)
-	fmt.Println("total sum:", totalSum.String(), "total reward:", totalReward.String())
-	fmt.Println("diff: ", new(big.Int).Sub(totalReward, totalSum))
-}
@@ -71,6 +71,9 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID meter.Bytes32) {
 	result, err := proto.GetBlockByID(c.ctx, peer, newBlockID)
 	if err != nil {
 		peer.logger.Debug("failed to get block by id", "err", err)
+		if isTimeout(err) {
+			c.scorePeer(peer, ScoreTimeout)
+		}
 		return
 	}
 	if len(result) == 0 {
@@ -81,10 +84,12 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID meter.Bytes32) {
 	var blk block.Block
 	if err := rlp.DecodeBytes(result, &blk); err != nil {
 		peer.logger.Debug("failed to decode block got by id", "err", err)
+		c.scorePeer(peer, ScoreInvalidBlock)
 		return
 	}
 
 	c.newBlockFeed.Send(&NewBlockEvent{
 		Block: &blk,
+		From:  peer.ID(),
 	})
 }
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// BannedPeer records a temporarily banned node.
+type BannedPeer struct {
+	ID     discover.NodeID
+	Until  uint64 // unix timestamp the ban expires at
+	Reason string
+}
+
+// BanList keeps banned nodes, and persists them to file if the path is not empty.
+type BanList struct {
+	path string
+	m    map[discover.NodeID]*BannedPeer
+	lock sync.Mutex
+}
+
+// NewBanList creates a ban list, loads unexpired bans from the file at path.
+func NewBanList(path string) *BanList {
+	b := &BanList{
+		path: path,
+		m:    make(map[discover.NodeID]*BannedPeer),
+	}
+	if path == "" {
+		return b
+	}
+
+	var entries []*BannedPeer
+	if data, err := ioutil.ReadFile(path); err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("failed to load ban list", "err", err)
+		}
+	} else if err := rlp.DecodeBytes(data, &entries); err != nil {
+		log.Warn("failed to load ban list", "err", err)
+	}
+	now := uint64(time.Now().Unix())
+	for _, e := range entries {
+		if e.Until > now {
+			b.m[e.ID] = e
+		}
+	}
+	return b
+}
+
+// Ban bans the node for the given duration.
+func (b *BanList) Ban(id discover.NodeID, duration time.Duration, reason string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.m[id] = &BannedPeer{
+		ID:     id,
+		Until:  uint64(time.Now().Add(duration).Unix()),
+		Reason: reason,
+	}
+	return b.save()
+}
+
+// Unban removes the node from the list, returns false if it's not banned.
+func (b *BanList) Unban(id discover.NodeID) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.m[id]; !ok {
+		return false, nil
+	}
+	delete(b.m, id)
+	return true, b.save()
+}
+
+// IsBanned returns if the node is banned now.
+func (b *BanList) IsBanned(id discover.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	e, ok := b.m[id]
+	if !ok {
+		return false
+	}
+	if e.Until <= uint64(time.Now().Unix()) {
+		delete(b.m, id)
+		return false
+	}
+	return true
+}
+
+// List returns unexpired bans, sorted by expiry.
+func (b *BanList) List() []*BannedPeer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := uint64(time.Now().Unix())
+	list := make([]*BannedPeer, 0, len(b.m))
+	for _, e := range b.m {
+		if e.Until > now {
+			list = append(list, e)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Until < list[j].Until
+	})
+	return list
+}
+
+func (b *BanList) save() error {
+	if b.path == "" {
+		return nil
+	}
+	entries := make([]*BannedPeer, 0, len(b.m))
+	for _, e := range b.m {
+		entries = append(entries, e)
+	}
+	data, err := rlp.EncodeToBytes(entries)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(b.path, data, 0600)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dfinlab/meter/comm"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBanList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banlist")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "peers.banned")
+
+	var id1, id2 discover.NodeID
+	id1[0], id2[0] = 1, 2
+
+	bans := comm.NewBanList(path)
+	assert.Nil(t, bans.Ban(id1, time.Hour, "test"))
+	assert.Nil(t, bans.Ban(id2, -time.Second, "expired"))
+	assert.True(t, bans.IsBanned(id1))
+	assert.False(t, bans.IsBanned(id2))
+
+	// reloaded from file
+	bans = comm.NewBanList(path)
+	assert.True(t, bans.IsBanned(id1))
+	assert.Equal(t, 1, len(bans.List()))
+	assert.Equal(t, "test", bans.List()[0].Reason)
+
+	ok, err := bans.Unban(id1)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, _ = bans.Unban(id1)
+	assert.False(t, ok)
+
+	bans = comm.NewBanList(path)
+	assert.False(t, bans.IsBanned(id1))
+}
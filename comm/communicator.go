@@ -41,6 +41,7 @@ type Communicator struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	peerSet        *PeerSet
+	bans           *BanList
 	syncedCh       chan struct{}
 	newBlockFeed   event.Feed
 	announcementCh chan *announcement
@@ -64,7 +65,11 @@ func GetGlobCommInst() *Communicator {
 }
 
 // New create a new Communicator instance.
-func New(chain *chain.Chain, txPool *txpool.TxPool, powPool *powpool.PowPool, configTopic string, magic [4]byte) *Communicator {
+// Misbehaving peers are banned in bans, which can be nil to keep bans in memory only.
+func New(chain *chain.Chain, txPool *txpool.TxPool, powPool *powpool.PowPool, configTopic string, magic [4]byte, bans *BanList) *Communicator {
+	if bans == nil {
+		bans = NewBanList("")
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c := &Communicator{
 		chain:          chain,
@@ -73,6 +78,7 @@ func New(chain *chain.Chain, txPool *txpool.TxPool, powPool *powpool.PowPool, co
 		ctx:            ctx,
 		cancel:         cancel,
 		peerSet:        newPeerSet(),
+		bans:           bans,
 		syncedCh:       make(chan struct{}),
 		announcementCh: make(chan *announcement),
 		configTopic:    configTopic,
@@ -138,6 +144,7 @@ func (c *Communicator) Sync(handler HandleBlockStream, qcHandler HandleQC) {
 					log.Info("trigger sync with peer", "peer", peer.RemoteAddr().String())
 					if err := c.sync(peer, best.Number(), handler, qcHandler); err != nil {
 						peer.logger.Info("synchronization failed", "err", err)
+						c.scoreSyncError(peer, err)
 					}
 					log.Info("triggered synchronization done", "bestQC", c.chain.BestQC().QCHeight, "bestBlock", c.chain.BestBlock().Header().Number())
 				}
@@ -162,6 +169,7 @@ func (c *Communicator) Sync(handler HandleBlockStream, qcHandler HandleQC) {
 				} else {
 					if err := c.sync(peer, best.Number(), handler, qcHandler); err != nil {
 						peer.logger.Debug("synchronization failed", "err", err)
+						c.scoreSyncError(peer, err)
 						break
 					}
 					peer.logger.Debug("synchronization done")
@@ -215,6 +223,9 @@ type txsToSync struct {
 }
 
 func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+	if c.bans.IsBanned(p.ID()) {
+		return errors.New("banned peer: " + p.ID().String())
+	}
 	peer, dir := newPeer(p, rw, c.magic)
 	curIP := peer.RemoteAddr().String()
 	lastIndex := strings.LastIndex(curIP, ":")
@@ -357,6 +368,7 @@ func (c *Communicator) PeersStats() []*PeerStats {
 	var stats []*PeerStats
 	for _, peer := range c.peerSet.Slice() {
 		bestID, totalScore := peer.Head()
+		score := peer.Score()
 		stats = append(stats, &PeerStats{
 			Name:        peer.Name(),
 			BestBlockID: bestID,
@@ -365,6 +377,7 @@ func (c *Communicator) PeersStats() []*PeerStats {
 			NetAddr:     peer.RemoteAddr().String(),
 			Inbound:     peer.Inbound(),
 			Duration:    uint64(time.Duration(peer.Duration()) / time.Second),
+			Score:       &score,
 		})
 	}
 	sort.Slice(stats, func(i, j int) bool {
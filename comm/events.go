@@ -9,11 +9,13 @@ import (
 	"context"
 
 	"github.com/dfinlab/meter/block"
+	"github.com/ethereum/go-ethereum/p2p/discover"
 )
 
 // NewBlockEvent event emitted when received block announcement.
 type NewBlockEvent struct {
 	*block.Block
+	From discover.NodeID // the peer the block received from
 }
 
 // HandleBlockStream to handle the stream of downloaded blocks in sync process.
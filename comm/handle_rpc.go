@@ -15,6 +15,7 @@ import (
 	"github.com/dfinlab/meter/metric"
 	//"github.com/dfinlab/meter/powpool"
 	"github.com/dfinlab/meter/tx"
+	"github.com/dfinlab/meter/txpool"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
@@ -50,9 +51,12 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			return errors.WithMessage(err, "decode msg")
 		}
 
+		if !peer.IsBlockKnown(newBlock.Header().ID()) {
+			c.scorePeer(peer, ScoreUsefulData)
+		}
 		peer.MarkBlock(newBlock.Header().ID())
 		peer.UpdateHead(newBlock.Header().ID(), newBlock.Header().TotalScore())
-		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock})
+		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock, From: peer.ID()})
 		write(&struct{}{})
 	case proto.MsgNewBlockID:
 		var newBlockID meter.Bytes32
@@ -71,7 +75,13 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			return errors.WithMessage(err, "decode msg")
 		}
 		peer.MarkTransaction(newTx.ID())
-		c.txPool.StrictlyAdd(newTx)
+		if err := c.txPool.StrictlyAdd(newTx); err != nil {
+			if txpool.IsBadTx(err) {
+				c.scorePeer(peer, ScoreBadTx)
+			}
+		} else {
+			c.scorePeer(peer, ScoreUsefulData)
+		}
 		write(&struct{}{})
 	case proto.MsgGetBlockByID:
 		var blockID meter.Bytes32
@@ -53,6 +53,7 @@ type Peer struct {
 	knownTxs       *lru.Cache
 	knownBlocks    *lru.Cache
 	knownPowBlocks *lru.Cache
+	score          peerScore
 	head           struct {
 		sync.Mutex
 		id         meter.Bytes32
@@ -139,6 +140,11 @@ func (p *Peer) IsBlockKnown(id meter.Bytes32) bool {
 	return p.knownBlocks.Contains(id)
 }
 
+// Score returns the score of the peer.
+func (p *Peer) Score() PeerScore {
+	return p.score.snapshot()
+}
+
 // Duration returns duration of connection.
 func (p *Peer) Duration() mclock.AbsTime {
 	return mclock.Now() - p.createdTime
@@ -0,0 +1,153 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/pkg/errors"
+)
+
+// ScoreEvent is a kind of peer behavior which changes the score of the peer.
+type ScoreEvent int
+
+const (
+	ScoreInvalidBlock ScoreEvent = iota // sent a block failed to pass validation
+	ScoreBadTx                          // sent a malformed or invalid tx
+	ScoreTimeout                        // failed to respond in time
+	ScoreUsefulData                     // sent a new block or tx
+)
+
+const (
+	maxPeerScore       = 100
+	banScoreThreshold  = -100
+	defaultBanDuration = time.Hour
+)
+
+var scoreDeltas = map[ScoreEvent]int{
+	ScoreInvalidBlock: -50,
+	ScoreBadTx:        -10,
+	ScoreTimeout:      -5,
+	ScoreUsefulData:   1,
+}
+
+// PeerScore snapshot of a peer's score and the counts of behaviors.
+type PeerScore struct {
+	Score         int
+	InvalidBlocks uint64
+	BadTxs        uint64
+	Timeouts      uint64
+	UsefulData    uint64
+}
+
+type peerScore struct {
+	sync.Mutex
+	PeerScore
+}
+
+// update applies the event and returns the new score.
+func (s *peerScore) update(ev ScoreEvent) int {
+	s.Lock()
+	defer s.Unlock()
+
+	switch ev {
+	case ScoreInvalidBlock:
+		s.InvalidBlocks++
+	case ScoreBadTx:
+		s.BadTxs++
+	case ScoreTimeout:
+		s.Timeouts++
+	case ScoreUsefulData:
+		s.UsefulData++
+	}
+	s.Score += scoreDeltas[ev]
+	if s.Score > maxPeerScore {
+		s.Score = maxPeerScore
+	}
+	return s.Score
+}
+
+func (s *peerScore) snapshot() PeerScore {
+	s.Lock()
+	defer s.Unlock()
+	return s.PeerScore
+}
+
+// invalidBlockError marks an error caused by an invalid block.
+type invalidBlockError struct {
+	err error
+}
+
+func (e invalidBlockError) Error() string {
+	return "invalid block: " + e.err.Error()
+}
+
+// InvalidBlockError wraps the error returned by HandleBlockStream, to indicate that
+// the streamed block is invalid, and the peer sent it should be penalized.
+func InvalidBlockError(err error) error {
+	return invalidBlockError{err}
+}
+
+func isInvalidBlock(err error) bool {
+	_, ok := errors.Cause(err).(invalidBlockError)
+	return ok
+}
+
+func isTimeout(err error) bool {
+	return errors.Cause(err) == context.DeadlineExceeded
+}
+
+// ScorePeer updates the score of the connected peer with the given node ID.
+// The peer is banned and disconnected once its score drops to the threshold.
+func (c *Communicator) ScorePeer(id discover.NodeID, ev ScoreEvent) {
+	if peer := c.peerSet.Find(id); peer != nil {
+		c.scorePeer(peer, ev)
+	}
+}
+
+func (c *Communicator) scorePeer(peer *Peer, ev ScoreEvent) {
+	score := peer.score.update(ev)
+	if score > banScoreThreshold {
+		return
+	}
+	peer.logger.Info("peer banned due to low score", "score", score)
+	if err := c.bans.Ban(peer.ID(), defaultBanDuration, "low score"); err != nil {
+		log.Warn("failed to save ban list", "err", err)
+	}
+	peer.Disconnect(p2p.DiscUselessPeer)
+}
+
+// BannedPeers returns all peers being banned.
+func (c *Communicator) BannedPeers() []*BannedPeer {
+	return c.bans.List()
+}
+
+// BanPeer bans the node for the given duration, and disconnects it if connected.
+func (c *Communicator) BanPeer(id discover.NodeID, duration time.Duration, reason string) error {
+	err := c.bans.Ban(id, duration, reason)
+	if peer := c.peerSet.Find(id); peer != nil {
+		peer.Disconnect(p2p.DiscRequested)
+	}
+	return err
+}
+
+// UnbanPeer lifts the ban of the node, returns false if it's not banned.
+func (c *Communicator) UnbanPeer(id discover.NodeID) (bool, error) {
+	return c.bans.Unban(id)
+}
+
+func (c *Communicator) scoreSyncError(peer *Peer, err error) {
+	switch {
+	case isInvalidBlock(err):
+		c.scorePeer(peer, ScoreInvalidBlock)
+	case isTimeout(err):
+		c.scorePeer(peer, ScoreTimeout)
+	}
+}
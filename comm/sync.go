@@ -73,11 +73,11 @@ func (c *Communicator) download(peer *Peer, fromNum uint32, handler HandleBlockS
 			for _, raw := range result {
 				var blk block.Block
 				if err := rlp.DecodeBytes(raw, &blk); err != nil {
-					errCh <- errors.Wrap(err, "invalid block")
+					errCh <- InvalidBlockError(errors.Wrap(err, "decode"))
 					return
 				}
 				if blk.Header().Number() != fromNum {
-					errCh <- errors.New("broken sequence")
+					errCh <- InvalidBlockError(errors.New("broken sequence"))
 					return
 				}
 				fromNum++
@@ -99,6 +99,7 @@ func (c *Communicator) download(peer *Peer, fromNum uint32, handler HandleBlockS
 				}
 			})
 
+			c.scorePeer(peer, ScoreUsefulData)
 			for _, blk := range blocks {
 				peer.MarkBlock(blk.Header().ID())
 				select {
@@ -0,0 +1,177 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package consensus
+
+import (
+	"bytes"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dfinlab/meter/block"
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/genesis"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/types"
+	crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+type ApiCommitteeAudit struct {
+	Epoch          uint64
+	KBlockHeight   uint32
+	KBlockID       meter.Bytes32
+	Nonce          uint64
+	DelegateSource string
+	Delegates      []*ApiCommitteeMember // InCommittee is set for the selected ones, with their CsIndex
+	Committee      []*ApiCommitteeMember // sorted by CsIndex
+	LeaderOrder    []int                 // CsIndex of the proposer of each round, repeated every len(LeaderOrder) rounds
+	Verified       bool
+	Mismatches     []string
+}
+
+// AuditCommittee reconstructs the committee of the epoch the same way as CalcCommitteeByNonce, from the
+// delegates at the kblock which starts the epoch and its nonce, then verifies it against the committee
+// info stored in the first mblock of the epoch.
+func (conR *ConsensusReactor) AuditCommittee(epoch uint64) (*ApiCommitteeAudit, error) {
+	kblock, consent, err := conR.findCommitteeBlocks(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := kblock.KBlockData.Nonce
+	if kblock.Header().Number() == 0 {
+		nonce = genesis.GenesisNonce
+	}
+
+	// same as GetConsensusDelegates, but with the delegates at the kblock
+	delegates, fromConfig := conR.getConsensusDelegatesByHeader(kblock.Header())
+	source := "staking"
+	if fromConfig {
+		source = "config"
+	}
+	delegateSize, committeeSize := calcCommitteeSize(len(delegates), conR.config)
+	delegates = delegates[:delegateSize]
+	vals := calcCommitteeValidators(delegates, uint32(committeeSize), nonce)
+
+	system := conR.csCommon.GetSystem()
+	audit := &ApiCommitteeAudit{
+		Epoch:          epoch,
+		KBlockHeight:   kblock.Header().Number(),
+		KBlockID:       kblock.Header().ID(),
+		Nonce:          nonce,
+		DelegateSource: source,
+		Delegates:      make([]*ApiCommitteeMember, 0, len(delegates)),
+		Committee:      make([]*ApiCommitteeMember, 0, len(vals)),
+		LeaderOrder:    make([]int, 0, len(vals)),
+		Mismatches:     make([]string, 0),
+	}
+
+	csIndexes := make(map[string]int)
+	for i, v := range vals {
+		pubKey := b64.StdEncoding.EncodeToString(crypto.FromECDSAPub(&v.PubKey))
+		csIndexes[pubKey] = i
+		audit.Committee = append(audit.Committee, &ApiCommitteeMember{
+			Name:        v.Name,
+			Address:     v.Address,
+			PubKey:      pubKey,
+			VotingPower: v.VotingPower,
+			NetAddr:     v.NetAddr.String(),
+			CsPubKey:    hex.EncodeToString(system.PubKeyToBytes(v.BlsPubKey)),
+			CsIndex:     i,
+			InCommittee: true,
+		})
+	}
+	for _, d := range delegates {
+		pubKey := b64.StdEncoding.EncodeToString(crypto.FromECDSAPub(&d.PubKey))
+		m := &ApiCommitteeMember{
+			Name:        string(d.Name),
+			Address:     d.Address,
+			PubKey:      pubKey,
+			VotingPower: d.VotingPower,
+			NetAddr:     d.NetAddr.String(),
+			CsPubKey:    hex.EncodeToString(system.PubKeyToBytes(d.BlsPubKey)),
+			CsIndex:     -1,
+		}
+		if index, ok := csIndexes[pubKey]; ok {
+			m.CsIndex = index
+			m.InCommittee = true
+		}
+		audit.Delegates = append(audit.Delegates, m)
+	}
+
+	// the leader is put first and the others follow in CsIndex order, see UpdateActualCommittee
+	infos := consent.CommitteeInfos.CommitteeInfo
+	if len(infos) > 0 && len(vals) > 0 {
+		leaderIndex := int(infos[0].CSIndex)
+		for i := range vals {
+			audit.LeaderOrder = append(audit.LeaderOrder, (leaderIndex+i)%len(vals))
+		}
+	}
+
+	audit.Mismatches = verifyCommitteeInfo(system, vals, infos)
+	audit.Verified = len(audit.Mismatches) == 0
+	return audit, nil
+}
+
+// IsCommitteeNotFound returns if the error means no committee of the epoch is in the chain.
+func IsCommitteeNotFound(err error) bool {
+	return err == errCommitteeNotFound
+}
+
+// findCommitteeBlocks walks back the kblocks from the best block, and returns the kblock which starts
+// the epoch along with the next block, which carries the committee info.
+func (conR *ConsensusReactor) findCommitteeBlocks(epoch uint64) (*block.Block, *block.Block, error) {
+	best := conR.chain.BestBlock().Header()
+	kHeight := best.LastKBlockHeight()
+	if best.BlockType() == block.BLOCK_TYPE_K_BLOCK {
+		kHeight = best.Number()
+	}
+	for {
+		kblock, err := conR.chain.GetTrunkBlock(kHeight)
+		if err != nil {
+			return nil, nil, err
+		}
+		consent, err := conR.chain.GetTrunkBlock(kHeight + 1)
+		if err != nil && !conR.chain.IsNotFound(err) {
+			return nil, nil, err
+		}
+		if err == nil {
+			e := consent.GetCommitteeEpoch()
+			if e == epoch {
+				return kblock, consent, nil
+			}
+			if e < epoch {
+				return nil, nil, errCommitteeNotFound
+			}
+		}
+		if kHeight == 0 {
+			return nil, nil, errCommitteeNotFound
+		}
+		kHeight = kblock.Header().LastKBlockHeight()
+	}
+}
+
+// verifyCommitteeInfo compares the selected committee with the committee info of the block, and returns the differences.
+func verifyCommitteeInfo(system *bls.System, vals []*types.Validator, infos []block.CommitteeInfo) []string {
+	mismatches := make([]string, 0)
+	if len(infos) != len(vals) {
+		mismatches = append(mismatches, fmt.Sprintf("committee size %v, %v in block", len(vals), len(infos)))
+	}
+	for _, info := range infos {
+		if int(info.CSIndex) >= len(vals) {
+			mismatches = append(mismatches, fmt.Sprintf("csIndex %v of %v out of committee", info.CSIndex, info.Name))
+			continue
+		}
+		v := vals[info.CSIndex]
+		if !bytes.Equal(info.PubKey, crypto.FromECDSAPub(&v.PubKey)) {
+			mismatches = append(mismatches, fmt.Sprintf("csIndex %v: pubKey of %v in block, expected %v", info.CSIndex, info.Name, v.Name))
+		}
+		if !bytes.Equal(info.CSPubKey, system.PubKeyToBytes(v.BlsPubKey)) {
+			mismatches = append(mismatches, fmt.Sprintf("csIndex %v: bls pubKey of %v in block, expected %v", info.CSIndex, info.Name, v.Name))
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package consensus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dfinlab/meter/block"
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCommitteeInfo(t *testing.T) {
+	system, err := types.DefaultBlsSystem()
+	require.Nil(t, err)
+
+	delegates := make([]*types.Delegate, 0)
+	for i := 0; i < 5; i++ {
+		key, err := crypto.GenerateKey()
+		require.Nil(t, err)
+		blsPub, _, err := bls.GenKeys(*system)
+		require.Nil(t, err)
+		name := fmt.Sprintf("delegate%v", i)
+		delegates = append(delegates, types.NewDelegate([]byte(name), meter.BytesToAddress([]byte(name)), key.PublicKey, blsPub, 100, 0))
+	}
+	vals := calcCommitteeValidators(delegates, 4, 1234)
+
+	// committee info as packed in the first mblock of the epoch
+	committeeInfos := func() []block.CommitteeInfo {
+		infos := make([]block.CommitteeInfo, 0)
+		for i, v := range vals {
+			infos = append(infos, *block.NewCommitteeInfo(v.Name, crypto.FromECDSAPub(&v.PubKey), v.NetAddr,
+				system.PubKeyToBytes(v.BlsPubKey), uint32(i)))
+		}
+		return infos
+	}
+	// leader first, see UpdateActualCommittee
+	rotated := func() []block.CommitteeInfo {
+		infos := committeeInfos()
+		return append(infos[2:], infos[:2]...)
+	}
+
+	tests := []struct {
+		name       string
+		infos      []block.CommitteeInfo
+		mismatches int
+	}{
+		{"match", committeeInfos(), 0},
+		{"match with leader first", rotated(), 0},
+		{"missing member", committeeInfos()[:3], 1},
+		{"swapped members", func() []block.CommitteeInfo {
+			infos := committeeInfos()
+			infos[0].CSIndex, infos[1].CSIndex = 1, 0
+			return infos
+		}(), 4},
+		{"wrong bls pubkey", func() []block.CommitteeInfo {
+			infos := committeeInfos()
+			infos[3].CSPubKey = infos[2].CSPubKey
+			return infos
+		}(), 1},
+		{"not selected delegate", func() []block.CommitteeInfo {
+			// 4 out of 5 are selected, put the one left out in the committee
+			infos := committeeInfos()
+			for _, d := range delegates {
+				if !inCommittee(vals, d) {
+					infos[0].PubKey = crypto.FromECDSAPub(&d.PubKey)
+					infos[0].CSPubKey = system.PubKeyToBytes(d.BlsPubKey)
+				}
+			}
+			return infos
+		}(), 2},
+		{"csIndex out of committee", func() []block.CommitteeInfo {
+			infos := committeeInfos()
+			infos[1].CSIndex = 4
+			return infos
+		}(), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches := verifyCommitteeInfo(system, vals, tt.infos)
+			assert.Equal(t, tt.mismatches, len(mismatches), mismatches)
+		})
+	}
+}
+
+func inCommittee(vals []*types.Validator, d *types.Delegate) bool {
+	for _, v := range vals {
+		if v.Name == string(d.Name) {
+			return true
+		}
+	}
+	return false
+}
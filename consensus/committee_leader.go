@@ -178,7 +178,7 @@ func (cl *ConsensusLeader) GenerateAnnounceMsg(height uint32, round uint32) bool
 	}
 
 	// sign message with ecdsa key
-	msgSig, err := cl.csReactor.SignConsensusMsg(msg.SigningHash().Bytes())
+	msgSig, err := cl.csReactor.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		cl.csReactor.logger.Error("Sign message failed", "error", err)
 		return false
@@ -270,7 +270,7 @@ func (cl *ConsensusLeader) GenerateNotaryAnnounceMsg() bool {
 	}
 
 	// sign message with ecdsa key
-	msgSig, err := cl.csReactor.SignConsensusMsg(msg.SigningHash().Bytes())
+	msgSig, err := cl.csReactor.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		cl.csReactor.logger.Error("Sign message failed", "error", err)
 		return false
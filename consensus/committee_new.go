@@ -219,12 +219,16 @@ func (conR *ConsensusReactor) sendNewCommitteeMessage(peer *ConsensusPeer, leade
 
 	// sign message with bls key
 	signMsg := conR.BuildNewCommitteeSignMsg(leaderPubKey, nextEpochID, uint64(conR.curHeight))
-	blsSig, msgHash := conR.csCommon.SignMessage2([]byte(signMsg))
+	blsSig, msgHash, err := conR.csCommon.SignMessage2([]byte(signMsg))
+	if err != nil {
+		conR.logger.Error("Sign message failed", "error", err)
+		return false
+	}
 	msg.BlsSignature = blsSig
 	msg.SignedMsgHash = msgHash
 
 	// sign message with ecdsa key
-	ecdsaSigBytes, err := conR.SignConsensusMsg(msg.SigningHash().Bytes())
+	ecdsaSigBytes, err := conR.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		conR.logger.Error("Sign message failed", "error", err)
 		return false
@@ -1,12 +1,13 @@
 package consensus
 
 import (
+	"github.com/dfinlab/meter/signer"
 	"github.com/dfinlab/meter/types"
 )
 
-func NewConsensusCommonFromBlsCommon(blsCommon *BlsCommon) *types.ConsensusCommon {
+func NewConsensusCommonFromBlsCommon(blsCommon *BlsCommon, signer signer.Signer) *types.ConsensusCommon {
 	return &types.ConsensusCommon{
-		PrivKey:     blsCommon.PrivKey,
+		Signer:      signer,
 		PubKey:      blsCommon.PubKey,
 		System:      blsCommon.system,
 		Params:      blsCommon.params,
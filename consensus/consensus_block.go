@@ -32,6 +32,7 @@ import (
 	"github.com/dfinlab/meter/reward"
 	"github.com/dfinlab/meter/runtime"
 	"github.com/dfinlab/meter/script"
+	"github.com/dfinlab/meter/script/auction"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/tx"
 	"github.com/dfinlab/meter/txpool"
@@ -562,7 +563,7 @@ func (pb *ProposedBlockInfo) String() string {
 }
 
 // Build MBlock
-func (conR *ConsensusReactor) BuildMBlock(parentBlock *block.Block) *ProposedBlockInfo {
+func (conR *ConsensusReactor) BuildMBlock(parentBlock *block.Block, round uint32) *ProposedBlockInfo {
 	best := parentBlock
 	now := uint64(time.Now().Unix())
 	/*
@@ -632,7 +633,7 @@ func (conR *ConsensusReactor) BuildMBlock(parentBlock *block.Block) *ProposedBlo
 		}
 	}
 
-	newBlock, stage, receipts, err := flow.Pack(&conR.myPrivKey, block.BLOCK_TYPE_M_BLOCK, conR.lastKBlockHeight)
+	newBlock, stage, receipts, err := flow.Pack(conR.signer, conR.curEpoch, round, block.BLOCK_TYPE_M_BLOCK, conR.lastKBlockHeight)
 	if err != nil {
 		conR.logger.Error("build block failed", "error", err)
 		return nil
@@ -644,7 +645,7 @@ func (conR *ConsensusReactor) BuildMBlock(parentBlock *block.Block) *ProposedBlo
 	return &ProposedBlockInfo{newBlock, stage, &receipts, txsToRemoved, txsToReturned, checkPoint, MBlockType}
 }
 
-func (conR *ConsensusReactor) BuildKBlock(parentBlock *block.Block, data *block.KBlockData, rewards []powpool.PowReward) *ProposedBlockInfo {
+func (conR *ConsensusReactor) BuildKBlock(parentBlock *block.Block, round uint32, data *block.KBlockData, rewards []powpool.PowReward) *ProposedBlockInfo {
 	best := parentBlock
 	now := uint64(time.Now().Unix())
 	/*
@@ -803,7 +804,7 @@ func (conR *ConsensusReactor) BuildKBlock(parentBlock *block.Block, data *block.
 		}
 	}
 
-	newBlock, stage, receipts, err := flow.Pack(&conR.myPrivKey, block.BLOCK_TYPE_K_BLOCK, conR.lastKBlockHeight)
+	newBlock, stage, receipts, err := flow.Pack(conR.signer, conR.curEpoch, round, block.BLOCK_TYPE_K_BLOCK, conR.lastKBlockHeight)
 	if err != nil {
 		conR.logger.Error("build block failed...", "error", err)
 		return nil
@@ -818,7 +819,7 @@ func (conR *ConsensusReactor) BuildKBlock(parentBlock *block.Block, data *block.
 	return &ProposedBlockInfo{newBlock, stage, &receipts, txsToRemoved, txsToReturned, checkPoint, KBlockType}
 }
 
-func (conR *ConsensusReactor) BuildStopCommitteeBlock(parentBlock *block.Block) *ProposedBlockInfo {
+func (conR *ConsensusReactor) BuildStopCommitteeBlock(parentBlock *block.Block, round uint32) *ProposedBlockInfo {
 	best := parentBlock
 	now := uint64(time.Now().Unix())
 
@@ -853,7 +854,7 @@ func (conR *ConsensusReactor) BuildStopCommitteeBlock(parentBlock *block.Block)
 		return nil
 	}
 
-	newBlock, stage, receipts, err := flow.Pack(&conR.myPrivKey, block.BLOCK_TYPE_S_BLOCK, conR.lastKBlockHeight)
+	newBlock, stage, receipts, err := flow.Pack(conR.signer, conR.curEpoch, round, block.BLOCK_TYPE_S_BLOCK, conR.lastKBlockHeight)
 	if err != nil {
 		conR.logger.Error("build block failed", "error", err)
 		return nil
@@ -1009,6 +1010,15 @@ func (conR *ConsensusReactor) FinalizeCommitBlock(blkInfo *ProposedBlockInfo, be
 	}
 
 	*****/
+	// the parent state tells contracts created internally from existing accounts
+	var accounts logdb.Accounts
+	if parentHeader, err := conR.chain.GetBlockHeader(blk.Header().ParentID()); err != nil {
+		conR.logger.Warn("get parent header failed, contracts created internally are not indexed", "err", err)
+	} else if parentState, err := conR.stateCreator.NewState(parentHeader.StateRoot()); err != nil {
+		conR.logger.Warn("open parent state failed, contracts created internally are not indexed", "err", err)
+	} else {
+		accounts = parentState
+	}
 	batch := logdb.GetGlobalLogDBInstance().Prepare(blk.Header())
 	for i, tx := range blk.Transactions() {
 		origin, _ := tx.Signer()
@@ -1016,6 +1026,10 @@ func (conR *ConsensusReactor) FinalizeCommitBlock(blkInfo *ProposedBlockInfo, be
 		for _, output := range (*(*receipts)[i]).Outputs {
 			txBatch.Insert(output.Events, output.Transfers)
 		}
+		batch.InsertTx(tx, origin, (*receipts)[i], accounts)
+	}
+	if err := auction.IndexClosedAuction(batch, blk.Header()); err != nil {
+		conR.logger.Warn("index closed auction failed ...", "err", err)
 	}
 
 	if err := batch.Commit(); err != nil {
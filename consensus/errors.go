@@ -17,6 +17,7 @@ var (
 	errParentHeaderMissing      = errors.New("parent header is missing")
 	errDecodeParentFailed       = errors.New("decode parent failed")
 	errRestartPaceMakerRequired = errors.New("restart pacemaker required")
+	errCommitteeNotFound        = errors.New("committee of the epoch not found")
 )
 
 type consensusError string
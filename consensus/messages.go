@@ -15,6 +15,7 @@ import (
 	"github.com/dfinlab/meter/block"
 	cmn "github.com/dfinlab/meter/libs/common"
 	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/signer"
 	"github.com/dfinlab/meter/types"
 	crypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -95,10 +96,34 @@ type ConsensusMsgCommonHeader struct {
 	Signature []byte // ecdsa signature of whole consensus message
 }
 
-func (ch ConsensusMsgCommonHeader) fields() []interface{} {
-	return []interface{}{
-		ch.Height, ch.Round, ch.Sender, ch.Timestamp, ch.MsgType, ch.MsgSubType, ch.EpochID,
+// signingMessage builds the message to sign from the header and the other fields.
+func (ch ConsensusMsgCommonHeader) signingMessage(fields ...interface{}) *signer.Message {
+	m := &signer.Message{
+		Height:     ch.Height,
+		Round:      ch.Round,
+		Sender:     ch.Sender,
+		Timestamp:  ch.Timestamp,
+		MsgType:    ch.MsgType,
+		MsgSubType: ch.MsgSubType,
+		EpochID:    ch.EpochID,
+		Body:       make([]rlp.RawValue, 0, len(fields)),
 	}
+	for _, f := range fields {
+		raw, err := rlp.EncodeToBytes(f)
+		if err != nil {
+			fmt.Println("RLP Encode Error: ", err)
+		}
+		m.Body = append(m.Body, raw)
+	}
+	return m
+}
+
+func signingHash(m *signer.Message) meter.Bytes32 {
+	msg, err := m.Encode()
+	if err != nil {
+		fmt.Println("RLP Encode Error: ", err)
+	}
+	return meter.Blake2b(msg)
 }
 
 func (cmh *ConsensusMsgCommonHeader) SetMsgSignature(sig []byte) {
@@ -138,20 +163,18 @@ type AnnounceCommitteeMessage struct {
 	VotingAggSig   []byte   // aggregate signature of voterSig above
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *AnnounceCommitteeMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *AnnounceCommitteeMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.AnnouncerID, m.AnnouncerBlsPK,
 		m.CommitteeSize, m.Nonce, m.KBlockHeight, m.POWBlockHeight,
 		m.VotingBitArray, m.VotingMsgHash, m.VotingAggSig,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *AnnounceCommitteeMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -183,19 +206,17 @@ type CommitCommitteeMessage struct {
 	SignedMsgHash [32]byte //bls signed message hash
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *CommitCommitteeMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *CommitCommitteeMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.CommitterID, m.CommitterBlsPK, m.CommitterIndex,
 		m.BlsSignature, m.SignedMsgHash,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *CommitCommitteeMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -237,21 +258,19 @@ type NotaryAnnounceMessage struct {
 	CommitteeMembers []block.CommitteeInfo
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *NotaryAnnounceMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *NotaryAnnounceMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.AnnouncerID, m.AnnouncerBlsPK,
 		m.VotingBitArray, m.VotingMsgHash, m.VotingAggSig,
 		m.NotarizeBitArray, m.NotarizeMsgHash, m.NotarizeAggSig,
 		m.CommitteeSize, m.CommitteeMembers,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *NotaryAnnounceMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -289,19 +308,17 @@ type NewCommitteeMessage struct {
 	BlsSignature  []byte   // BLS signed signature
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *NewCommitteeMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *NewCommitteeMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.NewLeaderID, m.ValidatorID, m.ValidatorBlsPK,
 		m.NextEpochID, m.Nonce, m.KBlockHeight, m.SignedMsgHash, m.BlsSignature,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *NewCommitteeMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -338,21 +355,19 @@ type PMProposalMessage struct {
 	TimeoutCert *PMTimeoutCert
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *PMProposalMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *PMProposalMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.ParentHeight, m.ParentRound,
 		m.ProposerID, m.ProposerBlsPK,
 		m.ProposedSize, m.ProposedBlock, m.ProposedBlockType,
 		m.KBlockHeight, m.TimeoutCert,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *PMProposalMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -393,19 +408,17 @@ type PMVoteMessage struct {
 	SignedMessageHash [32]byte
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *PMVoteMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *PMVoteMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.VoterIndex, m.VoterID, m.VoterBlsPK,
 		m.BlsSignature, m.SignedMessageHash,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *PMVoteMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -443,21 +456,19 @@ type PMNewViewMessage struct {
 	PeerSignature     []byte
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *PMNewViewMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *PMNewViewMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.QCHeight, m.QCRound, m.QCHigh, m.Reason,
 		m.TimeoutHeight, m.TimeoutRound, m.TimeoutCounter,
 		m.PeerID, m.PeerIndex,
 		m.SignedMessageHash, m.PeerSignature,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *PMNewViewMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -484,21 +495,19 @@ type PMQueryProposalMessage struct {
 	ReturnAddr        types.NetAddress
 }
 
-// SigningHash computes hash of all header fields excluding signature.
-func (m *PMQueryProposalMessage) SigningHash() (hash meter.Bytes32) {
-	hw := meter.NewBlake2b()
-	data := append(m.CSMsgCommonHeader.fields(),
+// SigningMessage returns the message signed by the sender, all fields excluding signature.
+func (m *PMQueryProposalMessage) SigningMessage() *signer.Message {
+	return m.CSMsgCommonHeader.signingMessage(
 		m.FromHeight,
 		m.ToHeight,
 		m.Round,
 		m.ReturnAddr,
 	)
-	err := rlp.Encode(hw, data)
-	if err != nil {
-		fmt.Println("RLP Encode Error: ", err)
-	}
-	hw.Sum(hash[:0])
-	return
+}
+
+// SigningHash computes hash of all header fields excluding signature.
+func (m *PMQueryProposalMessage) SigningHash() meter.Bytes32 {
+	return signingHash(m.SigningMessage())
 }
 
 // String returns a string representation.
@@ -486,7 +486,7 @@ func (p *Pacemaker) OnPropose(b *pmBlock, qc *pmQuorumCert, height, round uint32
 	id := blk.Header().ID()
 	txsRoot := blk.Header().TxsRoot()
 	stateRoot := blk.Header().StateRoot()
-	signMsg := p.csReactor.BuildProposalBlockSignMsg(uint32(info.BlockType), uint64(blk.Header().Number()), &id, &txsRoot, &stateRoot)
+	signMsg := p.csReactor.BuildProposalBlockSignMsg(p.csReactor.curEpoch, round, uint32(info.BlockType), uint64(blk.Header().Number()), &id, &txsRoot, &stateRoot)
 	msgHash := p.csReactor.csCommon.Hash256Msg([]byte(signMsg))
 	p.sigAggregator = newSignatureAggregator(p.csReactor.committeeSize, *p.csReactor.csCommon.GetSystem(), msgHash, p.csReactor.curCommittee.Validators)
 
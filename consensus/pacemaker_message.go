@@ -37,9 +37,9 @@ func (p *Pacemaker) proposeBlock(parentBlock *block.Block, height, round uint32,
 	if proposalKBlock {
 		data := &block.KBlockData{uint64(powResults.Nonce), powResults.Raw}
 		rewards := powResults.Rewards
-		blkInfo = p.csReactor.BuildKBlock(parentBlock, data, rewards)
+		blkInfo = p.csReactor.BuildKBlock(parentBlock, round, data, rewards)
 	} else {
-		blkInfo = p.csReactor.BuildMBlock(parentBlock)
+		blkInfo = p.csReactor.BuildMBlock(parentBlock, round)
 		lastKBlockHeight := blkInfo.ProposedBlock.Header().LastKBlockHeight()
 		blockNumber := blkInfo.ProposedBlock.Header().Number()
 		if round == 0 || blockNumber == lastKBlockHeight+1 {
@@ -58,7 +58,7 @@ func (p *Pacemaker) proposeStopCommitteeBlock(parentBlock *block.Block, height,
 	var blockBytes []byte
 	var blkInfo *ProposedBlockInfo
 
-	blkInfo = p.csReactor.BuildStopCommitteeBlock(parentBlock)
+	blkInfo = p.csReactor.BuildStopCommitteeBlock(parentBlock, round)
 	p.packQuorumCert(blkInfo.ProposedBlock, qc)
 	blockBytes = block.BlockEncodeBytes(blkInfo.ProposedBlock)
 
@@ -119,7 +119,7 @@ func (p *Pacemaker) BuildProposalMessage(height, round uint32, bnew *pmBlock, tc
 	}
 
 	// sign message
-	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningHash().Bytes())
+	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		p.logger.Error("Sign message failed", "error", err)
 		return nil, err
@@ -136,9 +136,22 @@ func (p *Pacemaker) BuildVoteForProposalMessage(proposalMsg *PMProposalMessage,
 
 	ch := proposalMsg.CSMsgCommonHeader
 
-	signMsg := p.csReactor.BuildProposalBlockSignMsg(uint32(proposalMsg.ProposedBlockType), uint64(ch.Height), &blockID, &txsRoot, &stateRoot)
-	sign, msgHash := p.csReactor.csCommon.SignMessage([]byte(signMsg))
-	p.logger.Debug("Built PMVoteMessage", "signMsg", signMsg)
+	vote := &types.Vote{
+		Epoch:     p.csReactor.curEpoch,
+		Round:     ch.Round,
+		Height:    uint64(ch.Height),
+		BlockType: uint32(proposalMsg.ProposedBlockType),
+		BlockID:   blockID,
+		TxsRoot:   txsRoot,
+		StateRoot: stateRoot,
+	}
+	sign, err := p.csReactor.signer.SignVote(vote)
+	if err != nil {
+		p.logger.Error("Sign message failed", "error", err)
+		return nil, err
+	}
+	msgHash := vote.SigningHash()
+	p.logger.Debug("Built PMVoteMessage", "signMsg", vote.SignMsg())
 
 	cmnHdr := ConsensusMsgCommonHeader{
 		Height:    ch.Height,
@@ -156,13 +169,13 @@ func (p *Pacemaker) BuildVoteForProposalMessage(proposalMsg *PMProposalMessage,
 
 		VoterID:           crypto.FromECDSAPub(&p.csReactor.myPubKey),
 		VoterBlsPK:        p.csReactor.csCommon.GetSystem().PubKeyToBytes(*p.csReactor.csCommon.GetPublicKey()),
-		BlsSignature:      p.csReactor.csCommon.GetSystem().SigToBytes(sign),
+		BlsSignature:      sign,
 		VoterIndex:        uint32(index),
 		SignedMessageHash: msgHash,
 	}
 
 	// sign message
-	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningHash().Bytes())
+	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		p.logger.Error("Sign message failed", "error", err)
 		return nil, err
@@ -189,7 +202,11 @@ func (p *Pacemaker) BuildNewViewMessage(nextHeight, nextRound uint32, qcHigh *pm
 
 	signMsg := p.BuildNewViewSignMsg(p.csReactor.myPubKey, reason, nextHeight, nextRound, qcHigh.QC)
 
-	sign, msgHash := p.csReactor.csCommon.SignMessage([]byte(signMsg))
+	sign, msgHash, err := p.csReactor.csCommon.SignMessage([]byte(signMsg))
+	if err != nil {
+		p.logger.Error("Sign message failed", "error", err)
+		return nil, err
+	}
 
 	qcBytes, err := rlp.EncodeToBytes(qcHigh.QC)
 	if err != nil {
@@ -215,7 +232,7 @@ func (p *Pacemaker) BuildNewViewMessage(nextHeight, nextRound uint32, qcHigh *pm
 		msg.TimeoutCounter = ti.counter
 	}
 	// sign message
-	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningHash().Bytes())
+	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		p.logger.Error("Sign message failed", "error", err)
 		return nil, err
@@ -251,7 +268,7 @@ func (p *Pacemaker) BuildQueryProposalMessage(fromHeight, toHeight, round uint32
 	}
 
 	// sign message
-	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningHash().Bytes())
+	msgSig, err := p.csReactor.SignConsensusMsg(msg.SigningMessage())
 	if err != nil {
 		p.logger.Error("Sign message failed", "error", err)
 		return nil, err
@@ -294,7 +311,7 @@ func (p *Pacemaker) BlockMatchQC(b *pmBlock, qc *block.QuorumCert) (bool, error)
 	stateRoot = blk.Header().StateRoot()
 	blkID = blk.Header().ID()
 
-	signMsg := p.csReactor.BuildProposalBlockSignMsg(blkType, uint64(b.Height), &blkID, &txsRoot, &stateRoot)
+	signMsg := p.csReactor.BuildProposalBlockSignMsg(qc.EpochID, qc.QCRound, blkType, uint64(b.Height), &blkID, &txsRoot, &stateRoot)
 	p.logger.Debug("BlockMatchQC", "signMsg", signMsg)
 	msgHash = p.csReactor.csCommon.Hash256Msg([]byte(signMsg))
 	//qc at least has 1 vote signature and they are the same, so compare [0] is good enough
@@ -40,6 +40,7 @@ import (
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/powpool"
 	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/signer"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/types"
 	crypto "github.com/ethereum/go-ethereum/crypto"
@@ -114,8 +115,8 @@ type ConsensusReactor struct {
 	SyncDone bool
 
 	// copy of master/node
-	myPubKey      ecdsa.PublicKey  // this is my public identification !!
-	myPrivKey     ecdsa.PrivateKey // copy of private key
+	myPubKey      ecdsa.PublicKey // this is my public identification !!
+	signer        signer.Signer   // signs with my private keys
 	myBeneficiary meter.Address
 
 	// still references above consensuStae, reactor if this node is
@@ -179,7 +180,7 @@ func SetConsensusGlobInst(inst *ConsensusReactor) {
 
 // NewConsensusReactor returns a new ConsensusReactor with the given
 // consensusState.
-func NewConsensusReactor(ctx *cli.Context, chain *chain.Chain, state *state.Creator, privKey *ecdsa.PrivateKey, pubKey *ecdsa.PublicKey, magic [4]byte, blsCommon *BlsCommon, initDelegates []*types.Delegate) *ConsensusReactor {
+func NewConsensusReactor(ctx *cli.Context, chain *chain.Chain, state *state.Creator, signer signer.Signer, magic [4]byte, blsCommon *BlsCommon, initDelegates []*types.Delegate) *ConsensusReactor {
 	conR := &ConsensusReactor{
 		chain:        chain,
 		stateCreator: state,
@@ -217,7 +218,7 @@ func NewConsensusReactor(ctx *cli.Context, chain *chain.Chain, state *state.Crea
 	conR.curHeight = chain.BestBlock().Header().Number()
 
 	// initialize consensus common
-	conR.csCommon = NewConsensusCommonFromBlsCommon(blsCommon)
+	conR.csCommon = NewConsensusCommonFromBlsCommon(blsCommon, signer)
 
 	// initialize pacemaker
 	conR.csPacemaker = NewPaceMaker(conR)
@@ -245,8 +246,8 @@ func NewConsensusReactor(ctx *cli.Context, chain *chain.Chain, state *state.Crea
 
 	conR.rcvdNewCommittee = make(map[NewCommitteeKey]*NewCommittee, 10)
 
-	conR.myPrivKey = *privKey
-	conR.myPubKey = *pubKey
+	conR.signer = signer
+	conR.myPubKey = *signer.PublicKey()
 
 	SetConsensusGlobInst(conR)
 	return conR
@@ -474,28 +475,7 @@ func (conR *ConsensusReactor) NewValidatorSetByNonce(nonce uint64) (uint, bool)
 //it is used for temp calculate committee set by a given nonce in the fly.
 // also return the committee
 func (conR *ConsensusReactor) CalcCommitteeByNonce(nonce uint64) (*types.ValidatorSet, uint, int, bool) {
-	buf := make([]byte, binary.MaxVarintLen64)
-	binary.PutUvarint(buf, nonce)
-
-	vals := make([]*types.Validator, 0)
-	for _, d := range conR.curDelegates.Delegates {
-		v := &types.Validator{
-			Name:        string(d.Name),
-			Address:     d.Address,
-			PubKey:      d.PubKey,
-			BlsPubKey:   d.BlsPubKey,
-			VotingPower: d.VotingPower,
-			NetAddr:     d.NetAddr,
-			CommitKey:   crypto.Keccak256(append(crypto.FromECDSAPub(&d.PubKey), buf...)),
-		}
-		vals = append(vals, v)
-	}
-
-	sort.SliceStable(vals, func(i, j int) bool {
-		return (bytes.Compare(vals[i].CommitKey, vals[j].CommitKey) <= 0)
-	})
-
-	vals = vals[:conR.committeeSize]
+	vals := calcCommitteeValidators(conR.curDelegates.Delegates, conR.committeeSize, nonce)
 	// the full list is stored in currCommittee, sorted.
 	// To become a validator (real member in committee), must repond the leader's
 	// announce. Validators are stored in conR.conS.Vlidators
@@ -519,6 +499,33 @@ func (conR *ConsensusReactor) CalcCommitteeByNonce(nonce uint64) (*types.Validat
 	return Committee, CONSENSUS_COMMIT_ROLE_NONE, 0, false
 }
 
+// calcCommitteeValidators selects the committee of committeeSize from the delegates, sorted by the
+// commit key derived from each public key and the nonce.
+func calcCommitteeValidators(delegates []*types.Delegate, committeeSize uint32, nonce uint64) []*types.Validator {
+	buf := make([]byte, binary.MaxVarintLen64)
+	binary.PutUvarint(buf, nonce)
+
+	vals := make([]*types.Validator, 0)
+	for _, d := range delegates {
+		v := &types.Validator{
+			Name:        string(d.Name),
+			Address:     d.Address,
+			PubKey:      d.PubKey,
+			BlsPubKey:   d.BlsPubKey,
+			VotingPower: d.VotingPower,
+			NetAddr:     d.NetAddr,
+			CommitKey:   crypto.Keccak256(append(crypto.FromECDSAPub(&d.PubKey), buf...)),
+		}
+		vals = append(vals, v)
+	}
+
+	sort.SliceStable(vals, func(i, j int) bool {
+		return (bytes.Compare(vals[i].CommitKey, vals[j].CommitKey) <= 0)
+	})
+
+	return vals[:committeeSize]
+}
+
 func (conR *ConsensusReactor) GetCommitteeMemberIndex(pubKey ecdsa.PublicKey) int {
 	for i, v := range conR.curCommittee.Validators {
 		if bytes.Equal(crypto.FromECDSAPub(&v.PubKey), crypto.FromECDSAPub(&pubKey)) == true {
@@ -1027,8 +1034,8 @@ func (conR *ConsensusReactor) GetLatestCommitteeList() ([]*ApiCommitteeMember, e
 }
 
 //============================================
-func (conR *ConsensusReactor) SignConsensusMsg(msgHash []byte) (sig []byte, err error) {
-	sig, err = crypto.Sign(msgHash, &conR.myPrivKey)
+func (conR *ConsensusReactor) SignConsensusMsg(msg *signer.Message) (sig []byte, err error) {
+	sig, err = conR.signer.SignMessage(msg)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -1069,19 +1076,17 @@ func (conR *ConsensusReactor) BuildAnnounceSignMsg(pubKey ecdsa.PublicKey, epoch
 
 // Sign Propopal Message
 // "Proposal Block Message: BlockType <8 bytes> Height <16 (8x2) bytes> Round <8 (4x2) bytes>
-func (conR *ConsensusReactor) BuildProposalBlockSignMsg(blockType uint32, height uint64, id, txsRoot, stateRoot *meter.Bytes32) string {
-	c := make([]byte, binary.MaxVarintLen32)
-	binary.BigEndian.PutUint32(c, blockType)
-
-	h := make([]byte, binary.MaxVarintLen64)
-	binary.BigEndian.PutUint64(h, height)
-
-	return fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s",
-		"BlockType", hex.EncodeToString(c),
-		"Height", hex.EncodeToString(h),
-		"BlockID", id.String(),
-		"TxRoot", txsRoot.String(),
-		"StateRoot", stateRoot.String())
+func (conR *ConsensusReactor) BuildProposalBlockSignMsg(epoch uint64, round uint32, blockType uint32, height uint64, id, txsRoot, stateRoot *meter.Bytes32) string {
+	vote := &types.Vote{
+		Epoch:     epoch,
+		Round:     round,
+		Height:    height,
+		BlockType: blockType,
+		BlockID:   *id,
+		TxsRoot:   *txsRoot,
+		StateRoot: *stateRoot,
+	}
+	return vote.SignMsg()
 }
 
 // Sign Notary Announce Message
@@ -1581,24 +1586,11 @@ func calcCommitteeSize(delegateSize int, config ConsensusConfig) (int, int) {
 // return with delegates list, delegateSize, committeeSize
 // maxDelegateSize >= maxCommiteeSize >= minCommitteeSize
 func (conR *ConsensusReactor) GetConsensusDelegates() ([]*types.Delegate, int, int) {
-	forceDelegates := conR.config.InitCfgdDelegates
-
-	// special handle for flag --init-configured-delegates
-	var delegates []*types.Delegate
-	if forceDelegates == true {
-		delegates = conR.config.InitDelegates
+	delegates, _ := conR.getConsensusDelegatesByHeader(nil)
+	if conR.config.InitCfgdDelegates == true {
 		conR.sourceDelegates = fromDelegatesFile
-		fmt.Println("Load delegates from delegates.json")
 	} else {
-		delegatesIntern, err := staking.GetInternalDelegateList()
-		delegates = conR.convertFromIntern(delegatesIntern)
-		fmt.Println("Load delegates from staking candidates")
 		conR.sourceDelegates = fromStaking
-		if err != nil || len(delegates) < conR.config.MinCommitteeSize {
-			delegates = conR.config.InitDelegates
-			fmt.Println("Load delegates from delegates.json as fallback, error loading staking candiates")
-			conR.sourceDelegates = fromStaking
-		}
 	}
 
 	delegateSize, committeeSize := calcCommitteeSize(len(delegates), conR.config)
@@ -1608,6 +1600,25 @@ func (conR *ConsensusReactor) GetConsensusDelegates() ([]*types.Delegate, int, i
 	return delegates, delegateSize, committeeSize
 }
 
+// getConsensusDelegatesByHeader loads the delegates at the given block, the best block if header is nil.
+// fromConfig tells whether the delegates are from delegates.json, either forced or as fallback.
+func (conR *ConsensusReactor) getConsensusDelegatesByHeader(header *block.Header) (delegates []*types.Delegate, fromConfig bool) {
+	// special handle for flag --init-configured-delegates
+	if conR.config.InitCfgdDelegates == true {
+		fmt.Println("Load delegates from delegates.json")
+		return conR.config.InitDelegates, true
+	}
+
+	delegatesIntern, err := staking.GetInternalDelegateListByHeader(header)
+	delegates = conR.convertFromIntern(delegatesIntern)
+	fmt.Println("Load delegates from staking candidates")
+	if err != nil || len(delegates) < conR.config.MinCommitteeSize {
+		fmt.Println("Load delegates from delegates.json as fallback, error loading staking candiates")
+		return conR.config.InitDelegates, true
+	}
+	return delegates, false
+}
+
 func (conR *ConsensusReactor) GetDelegateNameByIP(ip net.IP) string {
 	for _, d := range conR.allDelegates {
 		if d.NetAddr.IP.String() == ip.String() {
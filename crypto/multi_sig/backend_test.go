@@ -0,0 +1,121 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build cgo,!purego
+
+package bls_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/crypto/multi_sig/purebls"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCrossBackend checks that keys and signatures are interchangeable between the PBC and the pure Go backends.
+func TestCrossBackend(t *testing.T) {
+	params := bls.GenParamsTypeA(160, 512)
+	paramsBytes, err := params.ToBytes()
+	assert.Nil(t, err)
+	system, err := bls.GenSystem(bls.GenPairing(params))
+	assert.Nil(t, err)
+	pureParams, err := purebls.ParamsFromBytes(paramsBytes)
+	assert.Nil(t, err)
+	pureSystem, err := purebls.SystemFromBytes(purebls.GenPairing(pureParams), system.ToBytes())
+	assert.Nil(t, err)
+
+	n := 8
+	var (
+		sigs     []bls.Signature
+		pureSigs []purebls.Signature
+		keys     []bls.PublicKey
+		pureKeys []purebls.PublicKey
+		hashes   [][sha256.Size]byte
+	)
+	for i := 0; i < n; i++ {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("message %v", i%3)))
+
+		// keys generated by one backend, used by the other
+		var (
+			pub      bls.PublicKey
+			priv     bls.PrivateKey
+			purePub  purebls.PublicKey
+			purePriv purebls.PrivateKey
+		)
+		if i%2 == 0 {
+			pub, priv, err = bls.GenKeys(system)
+			assert.Nil(t, err)
+			purePriv, err = pureSystem.PrivKeyFromBytes(system.PrivKeyToBytes(priv))
+			assert.Nil(t, err)
+			purePub, err = pureSystem.PubKeyFromBytes(system.PubKeyToBytes(pub))
+			assert.Nil(t, err)
+		} else {
+			purePub, purePriv, err = purebls.GenKeys(pureSystem)
+			assert.Nil(t, err)
+			priv, err = system.PrivKeyFromBytes(pureSystem.PrivKeyToBytes(purePriv))
+			assert.Nil(t, err)
+			pub, err = system.PubKeyFromBytes(pureSystem.PubKeyToBytes(purePub))
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, system.PrivKeyToBytes(priv), pureSystem.PrivKeyToBytes(purePriv))
+		assert.Equal(t, system.PubKeyToBytes(pub), pureSystem.PubKeyToBytes(purePub))
+
+		sig := bls.Sign(hash, priv)
+		pureSig := purebls.Sign(hash, purePriv)
+		sigBytes := system.SigToBytes(sig)
+		assert.Equal(t, sigBytes, pureSystem.SigToBytes(pureSig), "same signature")
+
+		fromPure, err := system.SigFromBytes(pureSystem.SigToBytes(pureSig))
+		assert.Nil(t, err)
+		assert.True(t, bls.Verify(fromPure, hash, pub))
+		fromCgo, err := pureSystem.SigFromBytes(sigBytes)
+		assert.Nil(t, err)
+		assert.True(t, purebls.Verify(fromCgo, hash, purePub))
+
+		sigs = append(sigs, sig)
+		pureSigs = append(pureSigs, pureSig)
+		keys = append(keys, pub)
+		pureKeys = append(pureKeys, purePub)
+		hashes = append(hashes, hash)
+	}
+
+	agg, err := bls.Aggregate(sigs, system)
+	assert.Nil(t, err)
+	pureAgg, err := purebls.Aggregate(pureSigs, pureSystem)
+	assert.Nil(t, err)
+	assert.Equal(t, system.SigToBytes(agg), pureSystem.SigToBytes(pureAgg), "same aggregate")
+
+	ok, err := bls.AggregateVerify(agg, hashes, keys)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = purebls.AggregateVerify(pureAgg, hashes, pureKeys)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// tampered aggregate is rejected by both
+	hashes[0][0]++
+	ok, _ = bls.AggregateVerify(agg, hashes, keys)
+	assert.False(t, ok)
+	ok, _ = purebls.AggregateVerify(pureAgg, hashes, pureKeys)
+	assert.False(t, ok)
+}
+
+func TestCrossParams(t *testing.T) {
+	params := bls.GenParamsTypeA(160, 512)
+	data, err := params.ToBytes()
+	assert.Nil(t, err)
+	pureParams, err := purebls.ParamsFromBytes(data)
+	assert.Nil(t, err)
+	pureData, err := pureParams.ToBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, string(data), string(pureData))
+
+	pureData, _ = purebls.GenParamsTypeA(160, 512).ToBytes()
+	_, err = bls.ParamsFromBytes(pureData)
+	assert.Nil(t, err)
+}
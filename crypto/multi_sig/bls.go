@@ -3,6 +3,8 @@
 
 // file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
 
+// +build cgo,!purego
+
 /**
  * File        : bls.go
  * Description : Boneh-Lynn-Shacham signature scheme.
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// +build !cgo purego
+
+package bls
+
+import (
+	"crypto/sha256"
+
+	"github.com/dfinlab/meter/crypto/multi_sig/purebls"
+)
+
+// The pure Go backend, used when cgo is disabled or with the purego build tag.
+// It's byte-compatible with the PBC backend in bls.go, see purebls for details.
+
+type Element = purebls.Element
+type Params = purebls.Params
+type Pairing = purebls.Pairing
+type System = purebls.System
+type PublicKey = purebls.PublicKey
+type PrivateKey = purebls.PrivateKey
+type Hex32 = purebls.Hex32
+type Signature = purebls.Signature
+
+// Generate type A pairing parameters.
+func GenParamsTypeA(rbits int, qbits int) Params {
+	return purebls.GenParamsTypeA(rbits, qbits)
+}
+
+// ParamsFromBytes imports Params from the provided byte slice.
+func ParamsFromBytes(bytes []byte) (Params, error) {
+	return purebls.ParamsFromBytes(bytes)
+}
+
+// Generate a pairing from the given parameters.
+func GenPairing(params Params) Pairing {
+	return purebls.GenPairing(params)
+}
+
+// Generate a cryptosystem from the given pairing.
+func GenSystem(pairing Pairing) (System, error) {
+	return purebls.GenSystem(pairing)
+}
+
+// SystemFromBytes imports a System from the provided byte slice.
+func SystemFromBytes(pairing Pairing, bytes []byte) (System, error) {
+	return purebls.SystemFromBytes(pairing, bytes)
+}
+
+// Generate a key pair from the given cryptosystem.
+func GenKeys(system System) (PublicKey, PrivateKey, error) {
+	return purebls.GenKeys(system)
+}
+
+// Generate a key pair from the given cryptosystem and divide each key into n
+// shares such that t shares can combine signatures to recover a threshold
+// signature.
+func GenKeyShares(t int, n int, system System) (PublicKey, []PublicKey, PrivateKey, []PrivateKey, error) {
+	return purebls.GenKeyShares(t, n, system)
+}
+
+// Sign a message digest using a private key.
+func Sign(hash [sha256.Size]byte, secret PrivateKey) Signature {
+	return purebls.Sign(hash, secret)
+}
+
+// Verify a signature on the message digest using the public key of the signer.
+func Verify(signature Signature, hash [sha256.Size]byte, key PublicKey) bool {
+	return purebls.Verify(signature, hash, key)
+}
+
+// Aggregate signatures using the cryptosystem.
+func Aggregate(signatures []Signature, system System) (Signature, error) {
+	return purebls.Aggregate(signatures, system)
+}
+
+// Verify an aggregate signature on the message digests using the public keys of
+// the signers.
+func AggregateVerify(signature Signature, hashes [][sha256.Size]byte, keys []PublicKey) (bool, error) {
+	return purebls.AggregateVerify(signature, hashes, keys)
+}
+
+// Recover a threshold signature from the signature shares provided by the group
+// members using the cryptosystem.
+func Threshold(shares []Signature, memberIds []int, system System) (Signature, error) {
+	return purebls.Threshold(shares, memberIds, system)
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package purebls is a pure Go implementation of the Boneh-Lynn-Shacham signature scheme
+// over type A pairings, with the same API as the cgo binding of the PBC library in crypto/multi_sig.
+// Keys, signatures and params are byte-compatible with the PBC ones.
+package purebls
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Element is either a curve point of G1/G2, or a scalar of Zr.
+type Element struct {
+	curve *curve
+	p     point
+	k     *big.Int
+}
+
+type System struct {
+	pairing Pairing
+	g       Element
+}
+
+type PublicKey struct {
+	system System
+	gx     Element
+}
+
+type PrivateKey struct {
+	system System
+	x      Element
+}
+
+type Hex32 struct {
+	Input [32]byte
+}
+
+type Signature = Element
+
+func randomHash() ([sha256.Size]byte, error) {
+	var hash [sha256.Size]byte
+	_, err := rand.Read(hash[:])
+	return hash, err
+}
+
+// ToString formats the public key in hex of the compressed point.
+func (key PublicKey) ToString() string {
+	return key.gx.ToString()
+}
+
+// ToString formats the private key in hex.
+func (secret PrivateKey) ToString() string {
+	return secret.x.ToString()
+}
+
+// ToString formats the hash in hex.
+func (h Hex32) ToString() string {
+	str := ""
+	for j := 0; j < len(h.Input); j++ {
+		str += fmt.Sprintf("%x", h.Input[j])
+	}
+	return str
+}
+
+// ToString formats the element in hex of its bytes.
+func (temp Element) ToString() string {
+	if temp.curve == nil {
+		return ""
+	}
+	if temp.k != nil {
+		return fmt.Sprintf("%x", temp.curve.scalarToBytes(temp.k))
+	}
+	return fmt.Sprintf("%x", temp.curve.compress(temp.p))
+}
+
+// GenSystem generates a cryptosystem from the given pairing, with a random generator.
+func GenSystem(pairing Pairing) (System, error) {
+	hash, err := randomHash()
+	if err != nil {
+		return System{}, err
+	}
+	g := pairing.curve.hashToPoint(hash[:])
+	return System{pairing, Element{curve: pairing.curve, p: g}}, nil
+}
+
+// SystemFromBytes imports a System from the provided byte slice.
+func SystemFromBytes(pairing Pairing, bytes []byte) (System, error) {
+	if pairing.curve == nil || pairing.curve.compressedLen() != len(bytes) {
+		return System{}, errors.New("bls.FromBytes: System length mismatch.")
+	}
+	g, err := pairing.curve.decompress(bytes)
+	if err != nil {
+		return System{}, err
+	}
+	return System{pairing, Element{curve: pairing.curve, p: g}}, nil
+}
+
+// GenKeys generates a key pair from the given cryptosystem.
+func GenKeys(system System) (PublicKey, PrivateKey, error) {
+	hash, err := randomHash()
+	if err != nil {
+		return PublicKey{}, PrivateKey{}, err
+	}
+	c := system.pairing.curve
+	x := c.scalarFromHash(hash[:])
+	gx := c.mul(system.g.p, x)
+	return PublicKey{system, Element{curve: c, p: gx}}, PrivateKey{system, Element{curve: c, k: x}}, nil
+}
+
+// GenKeyShares generates a key pair from the given cryptosystem and divide each key into n
+// shares such that t shares can combine signatures to recover a threshold signature.
+func GenKeyShares(t int, n int, system System) (PublicKey, []PublicKey, PrivateKey, []PrivateKey, error) {
+	if t < 1 || n < t {
+		return PublicKey{}, nil, PrivateKey{}, nil, errors.New("bls.GenKeyShares: Bad threshold parameters.")
+	}
+	c := system.pairing.curve
+
+	coeff := make([]*big.Int, t)
+	for j := range coeff {
+		hash, err := randomHash()
+		if err != nil {
+			return PublicKey{}, nil, PrivateKey{}, nil, err
+		}
+		coeff[j] = c.scalarFromHash(hash[:])
+	}
+
+	keys := make([]PublicKey, n+1)
+	secrets := make([]PrivateKey, n+1)
+	for i := 0; i < n+1; i++ {
+		x := new(big.Int)
+		for j := 0; j < t; j++ {
+			ij := new(big.Int).Exp(big.NewInt(int64(i)), big.NewInt(int64(j)), nil)
+			x.Add(x, ij.Mul(ij, coeff[j]))
+		}
+		x.Mod(x, c.r)
+		secrets[i] = PrivateKey{system, Element{curve: c, k: x}}
+		keys[i] = PublicKey{system, Element{curve: c, p: c.mul(system.g.p, x)}}
+	}
+	return keys[0], keys[1:], secrets[0], secrets[1:], nil
+}
+
+// Sign a message digest using a private key.
+func Sign(hash [sha256.Size]byte, secret PrivateKey) Signature {
+	c := secret.system.pairing.curve
+	if c == nil || secret.x.k == nil {
+		return Element{}
+	}
+	h := c.hashToPoint(hash[:])
+	return Element{curve: c, p: c.mul(h, secret.x.k)}
+}
+
+// Verify a signature on the message digest using the public key of the signer.
+func Verify(signature Signature, hash [sha256.Size]byte, key PublicKey) bool {
+	c := key.system.pairing.curve
+	if c == nil || signature.curve == nil {
+		return false
+	}
+	h := c.hashToPoint(hash[:])
+	return c.pairingEqual(signature.p, key.system.g.p, []point{h}, []point{key.gx.p})
+}
+
+// Aggregate signatures using the cryptosystem.
+func Aggregate(signatures []Signature, system System) (Signature, error) {
+	if len(signatures) == 0 {
+		return Element{}, errors.New("bls.Aggregate: Empty list.")
+	}
+	c := system.pairing.curve
+	sigma := c.toJac(signatures[0].p)
+	for i := 1; i < len(signatures); i++ {
+		sigma = c.jacAdd(sigma, c.toJac(signatures[i].p))
+	}
+	return Element{curve: c, p: c.toAffine(sigma)}, nil
+}
+
+// AggregateVerify verifies an aggregate signature on the message digests using the public keys of the signers.
+func AggregateVerify(signature Signature, hashes [][sha256.Size]byte, keys []PublicKey) (bool, error) {
+	if len(hashes) == 0 {
+		return false, errors.New("bls.AggregateVerify: Empty list.")
+	}
+	if len(hashes) != len(keys) {
+		return false, errors.New("bls.AggregateVerify: List length mismatch.")
+	}
+	system := keys[0].system
+	c := system.pairing.curve
+	if c == nil || signature.curve == nil {
+		return false, nil
+	}
+
+	// keys signing the same digest are summed, so that one pairing is computed per distinct digest
+	var (
+		hs  []point
+		gxs []jacPoint
+	)
+	index := make(map[[sha256.Size]byte]int)
+	for i, hash := range hashes {
+		j, ok := index[hash]
+		if !ok {
+			j = len(hs)
+			index[hash] = j
+			hs = append(hs, c.hashToPoint(hash[:]))
+			gxs = append(gxs, c.toJac(point{}))
+		}
+		gxs[j] = c.jacAdd(gxs[j], c.toJac(keys[i].gx.p))
+	}
+	qs := make([]point, len(gxs))
+	for i := range gxs {
+		qs[i] = c.toAffine(gxs[i])
+	}
+	return c.pairingEqual(signature.p, system.g.p, hs, qs), nil
+}
+
+// Threshold recovers a threshold signature from the signature shares provided by the group
+// members using the cryptosystem.
+func Threshold(shares []Signature, memberIds []int, system System) (Signature, error) {
+	if len(shares) == 0 {
+		return Element{}, errors.New("bls.Recover: Empty list.")
+	}
+	if len(shares) != len(memberIds) {
+		return Element{}, errors.New("bls.Recover: List length mismatch.")
+	}
+	c := system.pairing.curve
+	r := c.r
+
+	sigma := c.toJac(point{})
+	for i := range memberIds {
+		p := big.NewInt(1)
+		q := big.NewInt(1)
+		for j := range memberIds {
+			if memberIds[i] != memberIds[j] {
+				p.Mul(p, big.NewInt(-int64(memberIds[j]+1)))
+				q.Mul(q, big.NewInt(int64(memberIds[i]-memberIds[j])))
+			}
+		}
+		lambda := new(big.Int).Mod(p, r)
+		lambda.Mul(lambda, new(big.Int).ModInverse(q.Mod(q, r), r)).Mod(lambda, r)
+		sigma = c.jacAdd(sigma, c.jacMul(c.toJac(shares[i].p), lambda))
+	}
+	return Element{curve: c, p: c.toAffine(sigma)}, nil
+}
+
+// SigToBytes converts a signature to a byte slice.
+func (system System) SigToBytes(signature Signature) []byte {
+	c := system.pairing.curve
+	if c == nil {
+		return nil
+	}
+	return c.compress(signature.p)
+}
+
+// PrivSigToBytes converts a Zr element to a byte slice.
+func (system System) PrivSigToBytes(signature Signature) []byte {
+	c := system.pairing.curve
+	if c == nil || signature.k == nil {
+		return nil
+	}
+	return c.scalarToBytes(signature.k)
+}
+
+// SigFromBytes converts a byte slice to a signature.
+func (system System) SigFromBytes(bytes []byte) (Signature, error) {
+	c := system.pairing.curve
+	if c == nil {
+		return Element{}, errors.New("bls.FromBytes: Empty system.")
+	}
+	p, err := c.decompress(bytes)
+	if err != nil {
+		return Element{}, err
+	}
+	return Element{curve: c, p: p}, nil
+}
+
+// PrivSigFromBytes converts a byte slice to a Zr element.
+func (system System) PrivSigFromBytes(bytes []byte) (Signature, error) {
+	c := system.pairing.curve
+	if c == nil {
+		return Element{}, errors.New("bls.FromBytes: Empty system.")
+	}
+	k, err := c.scalarFromBytes(bytes)
+	if err != nil {
+		return Element{}, err
+	}
+	return Element{curve: c, k: k}, nil
+}
+
+// PubKeyToBytes converts a PublicKey to a byte slice.
+func (system System) PubKeyToBytes(pubKey PublicKey) []byte {
+	return system.SigToBytes(pubKey.gx)
+}
+
+// PubKeyFromBytes converts a byte slice to a PublicKey.
+func (system System) PubKeyFromBytes(bytes []byte) (PublicKey, error) {
+	gx, err := system.SigFromBytes(bytes)
+	if err != nil {
+		return PublicKey{}, errors.New("bls.FromBytes: get PublicKey failed.")
+	}
+	return PublicKey{system, gx}, nil
+}
+
+// PrivKeyToBytes converts a PrivateKey to a byte slice.
+func (system System) PrivKeyToBytes(privKey PrivateKey) []byte {
+	return system.PrivSigToBytes(privKey.x)
+}
+
+// PrivKeyFromBytes converts a byte slice to a PrivateKey.
+func (system System) PrivKeyFromBytes(bytes []byte) (PrivateKey, error) {
+	x, err := system.PrivSigFromBytes(bytes)
+	if err != nil {
+		return PrivateKey{}, errors.New("bls.FromBytes: get PrivateKey failed.")
+	}
+	return PrivateKey{system, x}, nil
+}
+
+// ToBytes exports the System to a byte slice.
+func (system System) ToBytes() []byte {
+	return system.SigToBytes(system.g)
+}
+
+// Free is a no-op, elements are garbage collected.
+func (element Element) Free() {}
+
+// Free is a no-op, the cryptosystem is garbage collected.
+func (system System) Free() {}
+
+// Free is a no-op, the public key is garbage collected.
+func (key PublicKey) Free() {}
+
+// Free is a no-op, the private key is garbage collected.
+func (secret PrivateKey) Free() {}
@@ -0,0 +1,323 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package purebls
+
+import (
+	"errors"
+	"math/big"
+)
+
+var big1 = big.NewInt(1)
+
+// curve is y^2 = x^3 + x over Fq, with the subgroup of order r.
+type curve struct {
+	q, r, h *big.Int
+	params  Params
+
+	qLen    int      // bytes of an Fq element
+	rLen    int      // bytes of a Zr element
+	sqrtExp *big.Int // (q + 1) / 4, q = 3 mod 4
+}
+
+func newCurve(params Params) *curve {
+	return &curve{
+		q:       params.q,
+		r:       params.r,
+		h:       params.h,
+		params:  params,
+		qLen:    (params.q.BitLen() + 7) / 8,
+		rLen:    (params.r.BitLen() + 7) / 8,
+		sqrtExp: new(big.Int).Rsh(new(big.Int).Add(params.q, big1), 2),
+	}
+}
+
+// point is an affine point, nil x stands for the point at infinity.
+type point struct {
+	x, y *big.Int
+}
+
+func (p point) isInf() bool {
+	return p.x == nil
+}
+
+// rhs returns x^3 + x.
+func (c *curve) rhs(x *big.Int) *big.Int {
+	t := new(big.Int).Mul(x, x)
+	t.Add(t, big1)
+	t.Mul(t, x)
+	return t.Mod(t, c.q)
+}
+
+// sqrt returns the square root of a, or nil if a is not a square.
+func (c *curve) sqrt(a *big.Int) *big.Int {
+	y := new(big.Int).Exp(a, c.sqrtExp, c.q)
+	if new(big.Int).Mod(new(big.Int).Mul(y, y), c.q).Cmp(a) != 0 {
+		return nil
+	}
+	return y
+}
+
+// sign follows the PBC sign of Fq elements, 1 for odd, -1 for even and 0 for zero.
+func sign(a *big.Int) int {
+	if a.Sign() == 0 {
+		return 0
+	}
+	if a.Bit(0) == 1 {
+		return 1
+	}
+	return -1
+}
+
+func (c *curve) isOnCurve(p point) bool {
+	if p.isInf() {
+		return true
+	}
+	if p.x.Sign() < 0 || p.x.Cmp(c.q) >= 0 || p.y.Sign() < 0 || p.y.Cmp(c.q) >= 0 {
+		return false
+	}
+	y2 := new(big.Int).Mul(p.y, p.y)
+	return y2.Mod(y2, c.q).Cmp(c.rhs(p.x)) == 0
+}
+
+func (c *curve) neg(p point) point {
+	if p.isInf() {
+		return p
+	}
+	y := new(big.Int).Sub(c.q, p.y)
+	return point{p.x, y.Mod(y, c.q)}
+}
+
+// add returns p1 + p2, the group operation written as element_mul in PBC.
+func (c *curve) add(p1, p2 point) point {
+	return c.toAffine(c.jacAdd(c.toJac(p1), c.toJac(p2)))
+}
+
+// mul returns k * p, the element_pow_zn in PBC.
+func (c *curve) mul(p point, k *big.Int) point {
+	return c.toAffine(c.jacMul(c.toJac(p), k))
+}
+
+// hashToPoint maps the hash to a point of order r, same as element_from_hash in G1 of PBC.
+func (c *curve) hashToPoint(data []byte) point {
+	x := mpzFromHash(c.q, data)
+	x.Mod(x, c.q)
+	var t, y *big.Int
+	for {
+		t = c.rhs(x)
+		if y = c.sqrt(t); y != nil {
+			break
+		}
+		// x <- x^2 + 1 and try again
+		x.Mul(x, x)
+		x.Add(x, big1)
+		x.Mod(x, c.q)
+	}
+	if sign(y) < 0 {
+		y.Sub(c.q, y)
+	}
+	return c.mul(point{x, y}, c.h)
+}
+
+// scalarFromHash maps the hash to Zr, same as element_from_hash in Zr of PBC.
+func (c *curve) scalarFromHash(data []byte) *big.Int {
+	x := mpzFromHash(c.r, data)
+	return x.Mod(x, c.r)
+}
+
+// mpzFromHash is pbc_mpz_from_hash, the hash is repeated as H || 0 || H || 1 || ...
+// to fill the bytes of limit, then halved until not exceeding limit.
+func mpzFromHash(limit *big.Int, data []byte) *big.Int {
+	count := (limit.BitLen() + 7) / 8
+	buf := make([]byte, 0, count)
+	for counter := byte(0); ; counter++ {
+		if len(data) >= count-len(buf) {
+			buf = append(buf, data[:count-len(buf)]...)
+			break
+		}
+		buf = append(buf, data...)
+		buf = append(buf, counter)
+		if len(buf) == count {
+			break
+		}
+	}
+	z := new(big.Int).SetBytes(buf)
+	for z.Cmp(limit) > 0 {
+		z.Rsh(z, 1)
+	}
+	return z
+}
+
+// compressedLen is the length of the compressed point, pairing_length_in_bytes_compressed_G1 in PBC.
+func (c *curve) compressedLen() int {
+	return c.qLen + 1
+}
+
+// compress encodes the point as x followed by one byte for the sign of y.
+func (c *curve) compress(p point) []byte {
+	data := make([]byte, c.compressedLen())
+	if p.isInf() {
+		return data
+	}
+	x := p.x.Bytes()
+	copy(data[c.qLen-len(x):], x)
+	if sign(p.y) > 0 {
+		data[c.qLen] = 1
+	}
+	return data
+}
+
+// decompress decodes the point encoded by compress, same as element_from_bytes_compressed in PBC,
+// except that x without a point on the curve is rejected.
+func (c *curve) decompress(data []byte) (point, error) {
+	if len(data) != c.compressedLen() {
+		return point{}, errors.New("bls.FromBytes: Signature length mismatch.")
+	}
+	x := new(big.Int).SetBytes(data[:c.qLen])
+	x.Mod(x, c.q)
+	y := c.sqrt(c.rhs(x))
+	if y == nil {
+		return point{}, errors.New("bls.FromBytes: Point not on curve.")
+	}
+	if data[c.qLen] != 0 {
+		if sign(y) < 0 {
+			y.Sub(c.q, y)
+		}
+	} else if sign(y) > 0 {
+		y.Sub(c.q, y)
+	}
+	return point{x, y}, nil
+}
+
+// scalarToBytes encodes the Zr element in fixed length big endian.
+func (c *curve) scalarToBytes(k *big.Int) []byte {
+	data := make([]byte, c.rLen)
+	b := k.Bytes()
+	copy(data[c.rLen-len(b):], b)
+	return data
+}
+
+func (c *curve) scalarFromBytes(data []byte) (*big.Int, error) {
+	if len(data) != c.rLen {
+		return nil, errors.New("bls.FromBytes: Signature length mismatch.")
+	}
+	k := new(big.Int).SetBytes(data)
+	return k.Mod(k, c.r), nil
+}
+
+// jacPoint is a point in Jacobian coordinates (X/Z^2, Y/Z^3), zero Z stands for infinity.
+type jacPoint struct {
+	x, y, z *big.Int
+}
+
+func (c *curve) toJac(p point) jacPoint {
+	if p.isInf() {
+		return jacPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	return jacPoint{new(big.Int).Set(p.x), new(big.Int).Set(p.y), big.NewInt(1)}
+}
+
+func (c *curve) toAffine(p jacPoint) point {
+	if p.z.Sign() == 0 {
+		return point{}
+	}
+	zInv := new(big.Int).ModInverse(p.z, c.q)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+	x := new(big.Int).Mul(p.x, zInv2)
+	x.Mod(x, c.q)
+	y := new(big.Int).Mul(p.y, zInv2.Mul(zInv2, zInv))
+	y.Mod(y, c.q)
+	return point{x, y}
+}
+
+func (c *curve) jacDouble(p jacPoint) jacPoint {
+	if p.z.Sign() == 0 || p.y.Sign() == 0 {
+		return jacPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	q := c.q
+	xx := new(big.Int).Mul(p.x, p.x)
+	yy := new(big.Int).Mul(p.y, p.y)
+	yy.Mod(yy, q)
+	zz := new(big.Int).Mul(p.z, p.z)
+	zz.Mod(zz, q)
+
+	// s = 4 * x * y^2, m = 3 * x^2 + a * z^4 where a = 1
+	s := new(big.Int).Mul(p.x, yy)
+	s.Lsh(s, 2).Mod(s, q)
+	m := new(big.Int).Mul(xx, big.NewInt(3))
+	m.Add(m, zz.Mul(zz, zz)).Mod(m, q)
+
+	x3 := new(big.Int).Mul(m, m)
+	x3.Sub(x3, new(big.Int).Lsh(s, 1)).Mod(x3, q)
+	y3 := new(big.Int).Sub(s, x3)
+	y3.Mul(y3, m)
+	y3.Sub(y3, yy.Mul(yy, yy).Lsh(yy, 3)).Mod(y3, q)
+	z3 := new(big.Int).Mul(p.y, p.z)
+	z3.Lsh(z3, 1).Mod(z3, q)
+	return jacPoint{x3, y3, z3}
+}
+
+func (c *curve) jacAdd(p1, p2 jacPoint) jacPoint {
+	if p1.z.Sign() == 0 {
+		return p2
+	}
+	if p2.z.Sign() == 0 {
+		return p1
+	}
+	q := c.q
+	z1z1 := new(big.Int).Mul(p1.z, p1.z)
+	z1z1.Mod(z1z1, q)
+	z2z2 := new(big.Int).Mul(p2.z, p2.z)
+	z2z2.Mod(z2z2, q)
+	u1 := new(big.Int).Mul(p1.x, z2z2)
+	u1.Mod(u1, q)
+	u2 := new(big.Int).Mul(p2.x, z1z1)
+	u2.Mod(u2, q)
+	s1 := new(big.Int).Mul(p1.y, p2.z)
+	s1.Mul(s1, z2z2).Mod(s1, q)
+	s2 := new(big.Int).Mul(p2.y, p1.z)
+	s2.Mul(s2, z1z1).Mod(s2, q)
+
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, q)
+	r := new(big.Int).Sub(s2, s1)
+	r.Mod(r, q)
+	if h.Sign() == 0 {
+		if r.Sign() == 0 {
+			return c.jacDouble(p1)
+		}
+		return jacPoint{new(big.Int), new(big.Int), new(big.Int)}
+	}
+
+	hh := new(big.Int).Mul(h, h)
+	hh.Mod(hh, q)
+	hhh := new(big.Int).Mul(h, hh)
+	hhh.Mod(hhh, q)
+	v := new(big.Int).Mul(u1, hh)
+	v.Mod(v, q)
+
+	x3 := new(big.Int).Mul(r, r)
+	x3.Sub(x3, hhh).Sub(x3, new(big.Int).Lsh(v, 1)).Mod(x3, q)
+	y3 := new(big.Int).Sub(v, x3)
+	y3.Mul(y3, r).Sub(y3, s1.Mul(s1, hhh)).Mod(y3, q)
+	z3 := new(big.Int).Mul(p1.z, p2.z)
+	z3.Mul(z3, h).Mod(z3, q)
+	return jacPoint{x3, y3, z3}
+}
+
+func (c *curve) jacMul(p jacPoint, k *big.Int) jacPoint {
+	acc := jacPoint{new(big.Int), new(big.Int), new(big.Int)}
+	if k.Sign() < 0 {
+		k = new(big.Int).Neg(k)
+		p = jacPoint{p.x, new(big.Int).Sub(c.q, p.y), p.z}
+	}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		acc = c.jacDouble(acc)
+		if k.Bit(i) == 1 {
+			acc = c.jacAdd(acc, p)
+		}
+	}
+	return acc
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package purebls
+
+import (
+	"math/big"
+)
+
+// fq2 is a + b*i in Fq2 = Fq[i]/(i^2 + 1), where the pairing values live.
+type fq2 struct {
+	a, b *big.Int
+}
+
+func fq2One() fq2 {
+	return fq2{big.NewInt(1), new(big.Int)}
+}
+
+func (f fq2) isOne() bool {
+	return f.a.Cmp(big1) == 0 && f.b.Sign() == 0
+}
+
+func (c *curve) fq2Mul(x, y fq2) fq2 {
+	ac := new(big.Int).Mul(x.a, y.a)
+	bd := new(big.Int).Mul(x.b, y.b)
+	ad := new(big.Int).Mul(x.a, y.b)
+	bc := new(big.Int).Mul(x.b, y.a)
+	a := ac.Sub(ac, bd)
+	b := ad.Add(ad, bc)
+	return fq2{a.Mod(a, c.q), b.Mod(b, c.q)}
+}
+
+func (c *curve) fq2Square(x fq2) fq2 {
+	s := new(big.Int).Add(x.a, x.b)
+	d := new(big.Int).Sub(x.a, x.b)
+	a := s.Mul(s, d)
+	b := new(big.Int).Mul(x.a, x.b)
+	b.Lsh(b, 1)
+	return fq2{a.Mod(a, c.q), b.Mod(b, c.q)}
+}
+
+func (c *curve) fq2Inverse(x fq2) fq2 {
+	// 1 / (a + bi) = (a - bi) / (a^2 + b^2)
+	n := new(big.Int).Mul(x.a, x.a)
+	n.Add(n, new(big.Int).Mul(x.b, x.b))
+	n.ModInverse(n.Mod(n, c.q), c.q)
+	a := new(big.Int).Mul(x.a, n)
+	b := new(big.Int).Mul(x.b, n)
+	b.Neg(b)
+	return fq2{a.Mod(a, c.q), b.Mod(b, c.q)}
+}
+
+func (c *curve) fq2Exp(x fq2, k *big.Int) fq2 {
+	acc := fq2One()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		acc = c.fq2Square(acc)
+		if k.Bit(i) == 1 {
+			acc = c.fq2Mul(acc, x)
+		}
+	}
+	return acc
+}
+
+// lineStep returns t1 + t2, and the line through t1 and t2 evaluated at the distorted point
+// (-x, i*y) of q. Vertical lines are skipped, since they lie in Fq and vanish in the final exponentiation.
+func (c *curve) lineStep(t1, t2, q point) (point, *fq2) {
+	var lambda *big.Int
+	if t1.x.Cmp(t2.x) == 0 {
+		if t1.y.Cmp(t2.y) != 0 || t1.y.Sign() == 0 {
+			return point{}, nil
+		}
+		// tangent, lambda = (3x^2 + 1) / 2y
+		lambda = new(big.Int).Mul(t1.x, t1.x)
+		lambda.Mul(lambda, big.NewInt(3)).Add(lambda, big1)
+		d := new(big.Int).Lsh(t1.y, 1)
+		lambda.Mul(lambda, d.ModInverse(d.Mod(d, c.q), c.q))
+	} else {
+		lambda = new(big.Int).Sub(t2.y, t1.y)
+		d := new(big.Int).Sub(t2.x, t1.x)
+		lambda.Mul(lambda, d.ModInverse(d.Mod(d, c.q), c.q))
+	}
+	lambda.Mod(lambda, c.q)
+
+	x3 := new(big.Int).Mul(lambda, lambda)
+	x3.Sub(x3, t1.x).Sub(x3, t2.x).Mod(x3, c.q)
+	y3 := new(big.Int).Sub(t1.x, x3)
+	y3.Mul(y3, lambda).Sub(y3, t1.y).Mod(y3, c.q)
+
+	// l(X, Y) = Y - y1 - lambda * (X - x1), at X = -xq, Y = i*yq
+	a := new(big.Int).Add(q.x, t1.x)
+	a.Mul(a, lambda).Sub(a, t1.y).Mod(a, c.q)
+	return point{x3, y3}, &fq2{a, new(big.Int).Set(q.y)}
+}
+
+// miller computes the Miller function f_{r,p} at the distorted q, without final exponentiation.
+func (c *curve) miller(p, q point) fq2 {
+	f := fq2One()
+	if p.isInf() || q.isInf() {
+		return f
+	}
+	t := p
+	for i := c.r.BitLen() - 2; i >= 0; i-- {
+		f = c.fq2Square(f)
+		if t.isInf() {
+			continue
+		}
+		var l *fq2
+		t, l = c.lineStep(t, t, q)
+		if l != nil {
+			f = c.fq2Mul(f, *l)
+		}
+		if c.r.Bit(i) == 1 && !t.isInf() {
+			t, l = c.lineStep(t, p, q)
+			if l != nil {
+				f = c.fq2Mul(f, *l)
+			}
+		}
+	}
+	return f
+}
+
+// finalExp raises f to (q^2 - 1) / r = (q - 1) * h.
+func (c *curve) finalExp(f fq2) fq2 {
+	// f^(q-1) = conj(f) / f, since f^q is the conjugate
+	conj := fq2{new(big.Int).Set(f.a), new(big.Int).Sub(c.q, f.b)}
+	conj.b.Mod(conj.b, c.q)
+	f = c.fq2Mul(conj, c.fq2Inverse(f))
+	return c.fq2Exp(f, c.h)
+}
+
+// pairingEqual checks e(p1, q1) == prod e(p2[i], q2[i]).
+func (c *curve) pairingEqual(p1, q1 point, p2, q2 []point) bool {
+	// e(p1, q1) / prod e(p2[i], q2[i]) = e(p1, q1) * prod e(-p2[i], q2[i]) == 1
+	f := c.miller(p1, q1)
+	for i := range p2 {
+		f = c.fq2Mul(f, c.miller(c.neg(p2[i]), q2[i]))
+	}
+	if f.a.Sign() == 0 && f.b.Sign() == 0 {
+		return false
+	}
+	return c.finalExp(f).isOne()
+}
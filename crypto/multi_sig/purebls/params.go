@@ -0,0 +1,169 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package purebls
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Params are the type A pairing parameters, same as the PBC library.
+// The curve is y^2 = x^3 + x over Fq, q = h * r - 1, and r = 2^exp2 + sign1 * 2^exp1 + sign0.
+type Params struct {
+	q, h, r      *big.Int
+	exp2, exp1   int
+	sign1, sign0 int
+}
+
+// Pairing is the symmetric pairing over the curve of type A params.
+type Pairing struct {
+	curve *curve
+}
+
+// GenParamsTypeA generates type A pairing parameters with a rbits bits group order and
+// qbits bits base field, following the search of the PBC library.
+func GenParamsTypeA(rbits int, qbits int) Params {
+	for {
+		var p Params
+		p.r = new(big.Int)
+		if randInt(2) == 1 {
+			p.exp2, p.sign1 = rbits-1, 1
+		} else {
+			p.exp2, p.sign1 = rbits, -1
+		}
+		p.r.SetBit(p.r, p.exp2, 1)
+		p.exp1 = randInt(p.exp2-1) + 1
+		t := new(big.Int).SetBit(new(big.Int), p.exp1, 1)
+		if p.sign1 > 0 {
+			p.r.Add(p.r, t)
+		} else {
+			p.r.Sub(p.r, t)
+		}
+		if randInt(2) == 1 {
+			p.sign0 = 1
+			p.r.Add(p.r, big1)
+		} else {
+			p.sign0 = -1
+			p.r.Sub(p.r, big1)
+		}
+		if !p.r.ProbablyPrime(10) {
+			continue
+		}
+
+		bit := qbits - rbits - 4 + 1
+		if bit < 3 {
+			bit = 3
+		}
+		limit := new(big.Int).SetBit(new(big.Int), bit, 1)
+		for i := 0; i < 10; i++ {
+			h, err := rand.Int(rand.Reader, limit)
+			if err != nil {
+				panic(err)
+			}
+			p.h = h.Mul(h, big.NewInt(12))
+			p.q = new(big.Int).Mul(p.h, p.r)
+			p.q.Sub(p.q, big1)
+			if p.q.Sign() > 0 && p.q.ProbablyPrime(10) {
+				return p
+			}
+		}
+	}
+}
+
+func randInt(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(err)
+	}
+	return int(v.Int64())
+}
+
+// ParamsFromBytes imports Params in the format exported by ToBytes, which is the
+// same as pbc_param_out_str of the PBC library. Only type A params are supported.
+func ParamsFromBytes(data []byte) (Params, error) {
+	tab := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) == 0 || fields[0][0] == '#' {
+			continue
+		}
+		if len(fields) != 2 {
+			return Params{}, errors.New("bls.FromBytes: Failed to create Params from bytes.")
+		}
+		tab[string(fields[0])] = string(fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return Params{}, err
+	}
+	if tab["type"] != "a" {
+		return Params{}, errors.New("bls.FromBytes: Only type A params are supported.")
+	}
+
+	var p Params
+	for _, v := range []struct {
+		key string
+		z   **big.Int
+	}{{"q", &p.q}, {"h", &p.h}, {"r", &p.r}} {
+		z, ok := new(big.Int).SetString(tab[v.key], 0)
+		if !ok {
+			return Params{}, fmt.Errorf("bls.FromBytes: Invalid param %v.", v.key)
+		}
+		*v.z = z
+	}
+	for _, v := range []struct {
+		key string
+		i   *int
+	}{{"exp2", &p.exp2}, {"exp1", &p.exp1}, {"sign1", &p.sign1}, {"sign0", &p.sign0}} {
+		i, err := strconv.Atoi(tab[v.key])
+		if err != nil {
+			return Params{}, fmt.Errorf("bls.FromBytes: Invalid param %v.", v.key)
+		}
+		*v.i = i
+	}
+	if new(big.Int).Mul(p.h, p.r).Cmp(new(big.Int).Add(p.q, big1)) != 0 {
+		return Params{}, errors.New("bls.FromBytes: Params mismatch, q + 1 != h * r.")
+	}
+	if p.q.Bit(0) != 1 || p.q.Bit(1) != 1 {
+		return Params{}, errors.New("bls.FromBytes: Params mismatch, q is not 3 mod 4.")
+	}
+	return p, nil
+}
+
+// ToBytes exports Params to a byte slice.
+// The output format is a custom set of ASCII key-value pairs. Pairs are
+// separated by the space character (` `), and delimited by a newline (`\n`).
+func (params Params) ToBytes() ([]byte, error) {
+	if params.q == nil {
+		return nil, errors.New("bls.ToBytes: Empty params.")
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "type a\n")
+	fmt.Fprintf(&buf, "q %v\n", params.q)
+	fmt.Fprintf(&buf, "h %v\n", params.h)
+	fmt.Fprintf(&buf, "r %v\n", params.r)
+	fmt.Fprintf(&buf, "exp2 %v\n", params.exp2)
+	fmt.Fprintf(&buf, "exp1 %v\n", params.exp1)
+	fmt.Fprintf(&buf, "sign1 %v\n", params.sign1)
+	fmt.Fprintf(&buf, "sign0 %v\n", params.sign0)
+	return buf.Bytes(), nil
+}
+
+// Free is a no-op, params are garbage collected.
+func (params Params) Free() {}
+
+// GenPairing generates a pairing from the given parameters.
+func GenPairing(params Params) Pairing {
+	return Pairing{newCurve(params)}
+}
+
+// Free is a no-op, pairing is garbage collected.
+func (pairing Pairing) Free() {}
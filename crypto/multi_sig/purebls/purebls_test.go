@@ -0,0 +1,131 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package purebls_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dfinlab/meter/crypto/multi_sig/purebls"
+	"github.com/stretchr/testify/assert"
+)
+
+// params and system of the mainnet, same as cmd/meter.
+const (
+	testParams = "type a\n" +
+		"q 9885848114785392419932911634638239272593601745470310976334133500620898897063252861810419210525614689977831837298751367047028747711038248862463975457027691\n" +
+		"h 13528337086097476069235801102835662219262834981363103076561067638335615514516519746606066443143590817302932\n" +
+		"r 730751167114595186142829002853739519958614802431\n" +
+		"exp2 159\n" +
+		"exp1 138\n" +
+		"sign1 1\n" +
+		"sign0 -1\n"
+	testSystem = "2db8cb49c44a1c7ba19fdaf6947425a7c0191c710b64fd89cdc8b573881d98d814e377bb5a158c90a93e077b6ec1c3c92ae51f53fb22ef42d117b95f84c2dfec00"
+)
+
+func testSystemFromBytes(t *testing.T) (purebls.Params, purebls.System) {
+	params, err := purebls.ParamsFromBytes([]byte(testParams))
+	assert.Nil(t, err)
+	systemBytes, _ := hex.DecodeString(testSystem)
+	system, err := purebls.SystemFromBytes(purebls.GenPairing(params), systemBytes)
+	assert.Nil(t, err)
+	return params, system
+}
+
+func TestParams(t *testing.T) {
+	params, system := testSystemFromBytes(t)
+	data, err := params.ToBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, testParams, string(data))
+	assert.Equal(t, testSystem, hex.EncodeToString(system.ToBytes()))
+
+	_, err = purebls.ParamsFromBytes([]byte("type d\nq 7\n"))
+	assert.NotNil(t, err)
+}
+
+// signature generated by the PBC library.
+func TestKnownSignature(t *testing.T) {
+	_, system := testSystemFromBytes(t)
+	privBytes, _ := hex.DecodeString("0102030405060708090a0b0c0d0e0f1011121314")
+	priv, err := system.PrivKeyFromBytes(privBytes)
+	assert.Nil(t, err)
+	assert.Equal(t, privBytes, system.PrivKeyToBytes(priv))
+
+	hash := sha256.Sum256([]byte("meter"))
+	sig := purebls.Sign(hash, priv)
+	assert.Equal(t, "4a3c0aebb1bef5c2d888c2f607277e2991137c6368e256035912c30eb08dd257340b107f32c4fc4e2188d944690607c826c3ff9a4099afa91cfd95024eaf667500", hex.EncodeToString(system.SigToBytes(sig)))
+}
+
+func TestSignAndAggregate(t *testing.T) {
+	_, system := testSystemFromBytes(t)
+
+	n := 4
+	keys := make([]purebls.PublicKey, n)
+	sigs := make([]purebls.Signature, n)
+	hashes := make([][sha256.Size]byte, n)
+	hash := sha256.Sum256([]byte("block"))
+	for i := 0; i < n; i++ {
+		pub, priv, err := purebls.GenKeys(system)
+		assert.Nil(t, err)
+
+		// keys survive encoding
+		pub, err = system.PubKeyFromBytes(system.PubKeyToBytes(pub))
+		assert.Nil(t, err)
+		priv, err = system.PrivKeyFromBytes(system.PrivKeyToBytes(priv))
+		assert.Nil(t, err)
+
+		sig, err := system.SigFromBytes(system.SigToBytes(purebls.Sign(hash, priv)))
+		assert.Nil(t, err)
+		assert.True(t, purebls.Verify(sig, hash, pub))
+		assert.False(t, purebls.Verify(sig, sha256.Sum256([]byte("other")), pub))
+
+		keys[i], sigs[i], hashes[i] = pub, sig, hash
+	}
+
+	agg, err := purebls.Aggregate(sigs, system)
+	assert.Nil(t, err)
+	ok, err := purebls.AggregateVerify(agg, hashes, keys)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, _ = purebls.AggregateVerify(agg, hashes[1:], keys[1:])
+	assert.False(t, ok, "missing signer")
+
+	// distinct messages
+	_, priv, _ := purebls.GenKeys(system)
+	other := sha256.Sum256([]byte("other"))
+	agg, _ = purebls.Aggregate(append(sigs, purebls.Sign(other, priv)), system)
+	_, err = purebls.AggregateVerify(agg, hashes, nil)
+	assert.NotNil(t, err)
+	pub, _ := system.PubKeyFromBytes(system.PubKeyToBytes(keys[0]))
+	ok, _ = purebls.AggregateVerify(agg, append(hashes, other), append(keys, pub))
+	assert.False(t, ok, "wrong key")
+
+	_, err = system.SigFromBytes([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+}
+
+func TestThreshold(t *testing.T) {
+	params := purebls.GenParamsTypeA(160, 512)
+	system, err := purebls.GenSystem(purebls.GenPairing(params))
+	assert.Nil(t, err)
+
+	key, _, _, secrets, err := purebls.GenKeyShares(3, 5, system)
+	assert.Nil(t, err)
+	hash := sha256.Sum256([]byte("threshold"))
+	ids := []int{0, 2, 4}
+	shares := make([]purebls.Signature, len(ids))
+	for i, id := range ids {
+		shares[i] = purebls.Sign(hash, secrets[id])
+	}
+	sig, err := purebls.Threshold(shares, ids, system)
+	assert.Nil(t, err)
+	assert.True(t, purebls.Verify(sig, hash, key))
+
+	sig, _ = purebls.Threshold(shares[:2], ids[:2], system)
+	assert.False(t, purebls.Verify(sig, hash, key), "not enough shares")
+}
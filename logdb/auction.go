@@ -0,0 +1,320 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+
+	"github.com/dfinlab/meter/meter"
+)
+
+// InsertAuctionSummary adds the summary of an auction closed in this block, along with its bids.
+func (bb *BlockBatch) InsertAuctionSummary(summary *AuctionSummary) *BlockBatch {
+	summary.BlockID = bb.header.ID()
+	summary.BlockNumber = bb.header.Number()
+	summary.BlockTime = bb.header.Timestamp()
+	for i, t := range summary.Txs {
+		t.BlockID = summary.BlockID
+		t.Index = uint32(i)
+		t.BlockNumber = summary.BlockNumber
+		t.BlockTime = summary.BlockTime
+		t.AuctionID = summary.AuctionID
+	}
+	bb.auctions = append(bb.auctions, summary)
+	return bb
+}
+
+func insertAuctions(tx *sql.Tx, summaries []*AuctionSummary) error {
+	for _, s := range summaries {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO auctionSummary(blockID, blockNumber, blockTime, auctionID, startHeight, startEpoch, endHeight, endEpoch, sequence, releasedMTRG, reservedMTRG, reservedPrice, createTime, receivedMTR, actualPrice, leftoverMTRG) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			s.BlockID.Bytes(),
+			s.BlockNumber,
+			s.BlockTime,
+			s.AuctionID.Bytes(),
+			s.StartHeight,
+			s.StartEpoch,
+			s.EndHeight,
+			s.EndEpoch,
+			s.Sequence,
+			bigValue(s.RlsdMTRG),
+			bigValue(s.RsvdMTRG),
+			bigValue(s.RsvdPrice),
+			s.CreateTime,
+			bigValue(s.RcvdMTR),
+			bigValue(s.ActualPrice),
+			bigValue(s.LeftoverMTRG),
+		); err != nil {
+			return err
+		}
+		for _, t := range s.Txs {
+			if _, err := tx.Exec("INSERT OR REPLACE INTO auctionTx(blockID, auctionTxIndex, blockNumber, blockTime, auctionID, txID, bidder, amount, type, timestamp, nonce, distMTRG) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+				t.BlockID.Bytes(),
+				t.Index,
+				t.BlockNumber,
+				t.BlockTime,
+				t.AuctionID.Bytes(),
+				t.TxID.Bytes(),
+				t.Bidder.Bytes(),
+				bigValue(t.Amount),
+				t.Type,
+				t.Timestamp,
+				new(big.Int).SetUint64(t.Nonce).Bytes(),
+				bigValue(t.DistMTRG),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteAuctions(tx *sql.Tx, blockID meter.Bytes32) error {
+	if _, err := tx.Exec("DELETE FROM auctionSummary WHERE blockID = ?;", blockID.Bytes()); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM auctionTx WHERE blockID = ?;", blockID.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FilterAuctionSummaries returns the summaries of closed auctions, each with its bids.
+func (db *LogDB) FilterAuctionSummaries(ctx context.Context, filter *AuctionSummaryFilter) ([]*AuctionSummary, error) {
+	var args []interface{}
+	stmt := "SELECT * FROM auctionSummary WHERE 1"
+	if filter == nil {
+		filter = &AuctionSummaryFilter{}
+	}
+	condition := "blockNumber"
+	if filter.Range != nil {
+		if filter.Range.Unit == Time {
+			condition = "blockTime"
+		}
+		args = append(args, filter.Range.From)
+		stmt += " AND " + condition + " >= ? "
+		if filter.Range.To >= filter.Range.From {
+			args = append(args, filter.Range.To)
+			stmt += " AND " + condition + " <= ? "
+		}
+	}
+	if filter.AuctionID != nil {
+		args = append(args, filter.AuctionID.Bytes())
+		stmt += " AND auctionID = ? "
+	}
+	if filter.Order == DESC {
+		stmt += " ORDER BY blockNumber DESC "
+	} else {
+		stmt += " ORDER BY blockNumber ASC "
+	}
+	if filter.Options != nil {
+		stmt += " limit ?, ? "
+		args = append(args, filter.Options.Offset, filter.Options.Limit)
+	}
+
+	summaries, err := db.queryAuctionSummaries(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range summaries {
+		id := s.AuctionID
+		txs, err := db.FilterAuctionTxs(ctx, &AuctionTxFilter{AuctionID: &id})
+		if err != nil {
+			return nil, err
+		}
+		s.Txs = txs
+	}
+	return summaries, nil
+}
+
+// FilterAuctionTxs returns the bids of closed auctions.
+func (db *LogDB) FilterAuctionTxs(ctx context.Context, filter *AuctionTxFilter) ([]*AuctionTx, error) {
+	var args []interface{}
+	stmt := "SELECT * FROM auctionTx WHERE 1"
+	if filter == nil {
+		filter = &AuctionTxFilter{}
+	}
+	condition := "blockNumber"
+	if filter.Range != nil {
+		if filter.Range.Unit == Time {
+			condition = "blockTime"
+		}
+		args = append(args, filter.Range.From)
+		stmt += " AND " + condition + " >= ? "
+		if filter.Range.To >= filter.Range.From {
+			args = append(args, filter.Range.To)
+			stmt += " AND " + condition + " <= ? "
+		}
+	}
+	if filter.AuctionID != nil {
+		args = append(args, filter.AuctionID.Bytes())
+		stmt += " AND auctionID = ? "
+	}
+	if filter.Bidder != nil {
+		args = append(args, filter.Bidder.Bytes())
+		stmt += " AND bidder = ? "
+	}
+	if filter.Order == DESC {
+		stmt += " ORDER BY blockNumber DESC,auctionTxIndex DESC "
+	} else {
+		stmt += " ORDER BY blockNumber ASC,auctionTxIndex ASC "
+	}
+	if filter.Options != nil {
+		stmt += " limit ?, ? "
+		args = append(args, filter.Options.Offset, filter.Options.Limit)
+	}
+	return db.queryAuctionTxs(ctx, stmt, args...)
+}
+
+func (db *LogDB) queryAuctionSummaries(ctx context.Context, stmt string, args ...interface{}) ([]*AuctionSummary, error) {
+	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []*AuctionSummary
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockID      []byte
+			blockNumber  uint32
+			blockTime    uint64
+			auctionID    []byte
+			startHeight  uint64
+			startEpoch   uint64
+			endHeight    uint64
+			endEpoch     uint64
+			sequence     uint64
+			rlsdMTRG     []byte
+			rsvdMTRG     []byte
+			rsvdPrice    []byte
+			createTime   uint64
+			rcvdMTR      []byte
+			actualPrice  []byte
+			leftoverMTRG []byte
+		)
+		if err := rows.Scan(
+			&blockID,
+			&blockNumber,
+			&blockTime,
+			&auctionID,
+			&startHeight,
+			&startEpoch,
+			&endHeight,
+			&endEpoch,
+			&sequence,
+			&rlsdMTRG,
+			&rsvdMTRG,
+			&rsvdPrice,
+			&createTime,
+			&rcvdMTR,
+			&actualPrice,
+			&leftoverMTRG,
+		); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, &AuctionSummary{
+			BlockID:      meter.BytesToBytes32(blockID),
+			BlockNumber:  blockNumber,
+			BlockTime:    blockTime,
+			AuctionID:    meter.BytesToBytes32(auctionID),
+			StartHeight:  startHeight,
+			StartEpoch:   startEpoch,
+			EndHeight:    endHeight,
+			EndEpoch:     endEpoch,
+			Sequence:     sequence,
+			RlsdMTRG:     new(big.Int).SetBytes(rlsdMTRG),
+			RsvdMTRG:     new(big.Int).SetBytes(rsvdMTRG),
+			RsvdPrice:    new(big.Int).SetBytes(rsvdPrice),
+			CreateTime:   createTime,
+			RcvdMTR:      new(big.Int).SetBytes(rcvdMTR),
+			ActualPrice:  new(big.Int).SetBytes(actualPrice),
+			LeftoverMTRG: new(big.Int).SetBytes(leftoverMTRG),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (db *LogDB) queryAuctionTxs(ctx context.Context, stmt string, args ...interface{}) ([]*AuctionTx, error) {
+	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []*AuctionTx
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockID     []byte
+			index       uint32
+			blockNumber uint32
+			blockTime   uint64
+			auctionID   []byte
+			txID        []byte
+			bidder      []byte
+			amount      []byte
+			typ         uint32
+			timestamp   uint64
+			nonce       []byte
+			distMTRG    []byte
+		)
+		if err := rows.Scan(
+			&blockID,
+			&index,
+			&blockNumber,
+			&blockTime,
+			&auctionID,
+			&txID,
+			&bidder,
+			&amount,
+			&typ,
+			&timestamp,
+			&nonce,
+			&distMTRG,
+		); err != nil {
+			return nil, err
+		}
+		txs = append(txs, &AuctionTx{
+			BlockID:     meter.BytesToBytes32(blockID),
+			Index:       index,
+			BlockNumber: blockNumber,
+			BlockTime:   blockTime,
+			AuctionID:   meter.BytesToBytes32(auctionID),
+			TxID:        meter.BytesToBytes32(txID),
+			Bidder:      meter.BytesToAddress(bidder),
+			Amount:      new(big.Int).SetBytes(amount),
+			Type:        typ,
+			Timestamp:   timestamp,
+			Nonce:       new(big.Int).SetBytes(nonce).Uint64(),
+			DistMTRG:    new(big.Int).SetBytes(distMTRG),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+func bigValue(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+	return v.Bytes()
+}
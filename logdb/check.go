@@ -0,0 +1,77 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/dfinlab/meter/meter"
+)
+
+// tables with logs indexed by block.
+var blockTables = []string{"event", "transfer", "auctionSummary", "auctionTx", "txRecord", "contractCreation", "tokenTransfer", "jail"}
+
+// LogCount is the number of logs indexed for a block.
+type LogCount struct {
+	BlockID   meter.Bytes32
+	Events    int
+	Transfers int
+}
+
+// CountLogs returns the number of events and transfers indexed at the block number, per block id.
+func (db *LogDB) CountLogs(ctx context.Context, blockNumber uint32) ([]*LogCount, error) {
+	var counts []*LogCount
+	find := func(id meter.Bytes32) *LogCount {
+		for _, c := range counts {
+			if c.BlockID == id {
+				return c
+			}
+		}
+		c := &LogCount{BlockID: id}
+		counts = append(counts, c)
+		return c
+	}
+	for _, table := range []string{"event", "transfer"} {
+		rows, err := db.db.QueryContext(ctx, "SELECT blockID, COUNT(*) FROM "+table+" WHERE blockNumber = ? GROUP BY blockID", blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var (
+				blockID []byte
+				n       int
+			)
+			if err := rows.Scan(&blockID, &n); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if table == "event" {
+				find(meter.BytesToBytes32(blockID)).Events = n
+			} else {
+				find(meter.BytesToBytes32(blockID)).Transfers = n
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rows.Close()
+	}
+	return counts, nil
+}
+
+// Truncate deletes all logs indexed after the block number.
+func (db *LogDB) Truncate(blockNumber uint32) error {
+	return (&BlockBatch{db: db.db}).execInTx(func(tx *sql.Tx) error {
+		for _, table := range blockTables {
+			if _, err := tx.Exec("DELETE FROM "+table+" WHERE blockNumber > ?;", blockNumber); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+
+	"github.com/dfinlab/meter/meter"
+	setypes "github.com/dfinlab/meter/script/types"
+	"github.com/dfinlab/meter/tx"
+)
+
+// the events emitted by the staking module when delegates are put into and released from jail
+var (
+	jailedEvent, _   = setypes.ScriptEngine.ABI.EventByName("Jailed")
+	unjailedEvent, _ = setypes.ScriptEngine.ABI.EventByName("Unjailed")
+)
+
+// newJailRecord recognises the Jailed or Unjailed event of the staking module, and converts it
+// to JailRecord. nil is returned if the event is not a jail event.
+func newJailRecord(txEvent *tx.Event) *JailRecord {
+	if txEvent.Address != meter.StakingModuleAddr || len(txEvent.Topics) != 2 {
+		return nil
+	}
+	var action JailAction
+	switch txEvent.Topics[0] {
+	case jailedEvent.ID():
+		action = JailIn
+	case unjailedEvent.ID():
+		action = JailOut
+	default:
+		return nil
+	}
+	var data struct {
+		Name        []byte
+		TotalPoints *big.Int
+		BailAmount  *big.Int
+		JailedTime  *big.Int
+	}
+	if err := jailedEvent.Decode(txEvent.Data, &data); err != nil {
+		return nil
+	}
+	return &JailRecord{
+		Address:     meter.BytesToAddress(txEvent.Topics[1].Bytes()),
+		Name:        data.Name,
+		Action:      action,
+		TotalPoints: data.TotalPoints.Uint64(),
+		BailAmount:  data.BailAmount,
+		JailedTime:  data.JailedTime.Uint64(),
+	}
+}
+
+// InsertJailEvents adds the delegates put into or released from jail by the events.
+func (bb *BlockBatch) InsertJailEvents(events tx.Events) *BlockBatch {
+	for _, event := range events {
+		if r := newJailRecord(event); r != nil {
+			bb.InsertJailRecord(r)
+		}
+	}
+	return bb
+}
+
+// InsertJailRecord adds a delegate put into or released from jail in this block.
+func (bb *BlockBatch) InsertJailRecord(record *JailRecord) *BlockBatch {
+	record.BlockID = bb.header.ID()
+	record.Index = uint32(len(bb.jails))
+	record.BlockNumber = bb.header.Number()
+	record.BlockTime = bb.header.Timestamp()
+	bb.jails = append(bb.jails, record)
+	return bb
+}
+
+func insertJailRecords(tx *sql.Tx, records []*JailRecord) error {
+	for _, r := range records {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO jail(blockID, jailIndex, blockNumber, blockTime, address, name, action, totalPoints, bailAmount, jailedTime) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			r.BlockID.Bytes(),
+			r.Index,
+			r.BlockNumber,
+			r.BlockTime,
+			r.Address.Bytes(),
+			r.Name,
+			r.Action,
+			r.TotalPoints,
+			bigValue(r.BailAmount),
+			r.JailedTime,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteJailRecords(tx *sql.Tx, blockID meter.Bytes32) error {
+	_, err := tx.Exec("DELETE FROM jail WHERE blockID = ?;", blockID.Bytes())
+	return err
+}
+
+// FilterJailRecords returns the history of delegates put into and released from jail.
+func (db *LogDB) FilterJailRecords(ctx context.Context, filter *JailFilter) ([]*JailRecord, error) {
+	var args []interface{}
+	stmt := "SELECT * FROM jail WHERE 1"
+	if filter == nil {
+		filter = &JailFilter{}
+	}
+	condition := "blockNumber"
+	if filter.Range != nil {
+		if filter.Range.Unit == Time {
+			condition = "blockTime"
+		}
+		args = append(args, filter.Range.From)
+		stmt += " AND " + condition + " >= ? "
+		if filter.Range.To >= filter.Range.From {
+			args = append(args, filter.Range.To)
+			stmt += " AND " + condition + " <= ? "
+		}
+	}
+	if filter.Address != nil {
+		args = append(args, filter.Address.Bytes())
+		stmt += " AND address = ? "
+	}
+	if filter.Order == DESC {
+		stmt += " ORDER BY blockNumber DESC,jailIndex DESC "
+	} else {
+		stmt += " ORDER BY blockNumber ASC,jailIndex ASC "
+	}
+	if filter.Options != nil {
+		stmt += " limit ?, ? "
+		args = append(args, filter.Options.Offset, filter.Options.Limit)
+	}
+	return db.queryJailRecords(ctx, stmt, args...)
+}
+
+func (db *LogDB) queryJailRecords(ctx context.Context, stmt string, args ...interface{}) ([]*JailRecord, error) {
+	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*JailRecord
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockID     []byte
+			index       uint32
+			blockNumber uint32
+			blockTime   uint64
+			address     []byte
+			name        []byte
+			action      uint32
+			totalPoints uint64
+			bailAmount  []byte
+			jailedTime  uint64
+		)
+		if err := rows.Scan(
+			&blockID,
+			&index,
+			&blockNumber,
+			&blockTime,
+			&address,
+			&name,
+			&action,
+			&totalPoints,
+			&bailAmount,
+			&jailedTime,
+		); err != nil {
+			return nil, err
+		}
+		records = append(records, &JailRecord{
+			BlockID:     meter.BytesToBytes32(blockID),
+			Index:       index,
+			BlockNumber: blockNumber,
+			BlockTime:   blockTime,
+			Address:     meter.BytesToAddress(address),
+			Name:        name,
+			Action:      JailAction(action),
+			TotalPoints: totalPoints,
+			BailAmount:  new(big.Int).SetBytes(bailAmount),
+			JailedTime:  jailedTime,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
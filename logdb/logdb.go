@@ -49,7 +49,7 @@ func New(path string) (logDB *LogDB, err error) {
 			}
 		}
 	}()
-	if _, err := db.Exec(eventTableSchema + transferTableSchema); err != nil {
+	if _, err := db.Exec(eventTableSchema + transferTableSchema + auctionTableSchema + txTableSchema + contractCreationTableSchema + tokenTransferTableSchema + jailTableSchema); err != nil {
 		return nil, err
 	}
 
@@ -336,6 +336,12 @@ type BlockBatch struct {
 	header    *block.Header
 	events    []*Event
 	transfers []*Transfer
+	auctions  []*AuctionSummary
+	txs       []*TxRecord
+	creations []*ContractCreation
+	created   map[meter.Address]bool
+	tokens    []*TokenTransfer
+	jails     []*JailRecord
 }
 
 func (bb *BlockBatch) execInTx(proc func(*sql.Tx) error) (err error) {
@@ -392,6 +398,19 @@ func (bb *BlockBatch) Commit(abandonedBlocks ...meter.Bytes32) error {
 				return err
 			}
 		}
+
+		if err := insertAuctions(tx, bb.auctions); err != nil {
+			return err
+		}
+		if err := insertTxRecords(tx, bb.txs, bb.creations); err != nil {
+			return err
+		}
+		if err := insertTokenTransfers(tx, bb.tokens); err != nil {
+			return err
+		}
+		if err := insertJailRecords(tx, bb.jails); err != nil {
+			return err
+		}
 		for _, id := range abandonedBlocks {
 			if _, err := tx.Exec("DELETE FROM event WHERE blockID = ?;", id.Bytes()); err != nil {
 				return err
@@ -399,11 +418,34 @@ func (bb *BlockBatch) Commit(abandonedBlocks ...meter.Bytes32) error {
 			if _, err := tx.Exec("DELETE FROM transfer WHERE blockID = ?;", id.Bytes()); err != nil {
 				return err
 			}
+			if err := deleteAuctions(tx, id); err != nil {
+				return err
+			}
+			if err := deleteTxRecords(tx, id); err != nil {
+				return err
+			}
+			if err := deleteTokenTransfers(tx, id); err != nil {
+				return err
+			}
+			if err := deleteJailRecords(tx, id); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 }
 
+// InsertTokenTransfers adds only the token transfers recognised from the events of the tx,
+// it's used to index token transfers of blocks whose events are already indexed.
+func (bb *BlockBatch) InsertTokenTransfers(txID meter.Bytes32, txOrigin meter.Address, events tx.Events) *BlockBatch {
+	for _, event := range events {
+		if tt := newTokenTransfer(bb.header, uint32(len(bb.tokens)), txID, txOrigin, event); tt != nil {
+			bb.tokens = append(bb.tokens, tt)
+		}
+	}
+	return bb
+}
+
 func (bb *BlockBatch) ForTransaction(txID meter.Bytes32, txOrigin meter.Address) struct {
 	Insert func(tx.Events, tx.Transfers) *BlockBatch
 } {
@@ -413,6 +455,12 @@ func (bb *BlockBatch) ForTransaction(txID meter.Bytes32, txOrigin meter.Address)
 		func(events tx.Events, transfers tx.Transfers) *BlockBatch {
 			for _, event := range events {
 				bb.events = append(bb.events, newEvent(bb.header, uint32(len(bb.events)), txID, txOrigin, event))
+				if tt := newTokenTransfer(bb.header, uint32(len(bb.tokens)), txID, txOrigin, event); tt != nil {
+					bb.tokens = append(bb.tokens, tt)
+				}
+				if r := newJailRecord(event); r != nil {
+					bb.InsertJailRecord(r)
+				}
 			}
 			for _, transfer := range transfers {
 				bb.transfers = append(bb.transfers, newTransfer(bb.header, uint32(len(bb.transfers)), txID, txOrigin, transfer))
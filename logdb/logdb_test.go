@@ -15,7 +15,9 @@ import (
 	"github.com/dfinlab/meter/block"
 	"github.com/dfinlab/meter/logdb"
 	"github.com/dfinlab/meter/meter"
+	setypes "github.com/dfinlab/meter/script/types"
 	"github.com/dfinlab/meter/tx"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,6 +136,452 @@ func TestTransfers(t *testing.T) {
 	assert.Equal(t, len(ts), count, "transfers searched")
 }
 
+func TestAuctions(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	bidder := meter.BytesToAddress([]byte("bidder"))
+	other := meter.BytesToAddress([]byte("other"))
+	header := new(block.Builder).Build().Header()
+	count := 10
+	for i := 0; i < count; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		summary := &logdb.AuctionSummary{
+			AuctionID:    meter.BytesToBytes32([]byte{byte(i + 1)}),
+			Sequence:     uint64(i + 1),
+			RlsdMTRG:     big.NewInt(1000),
+			RsvdMTRG:     big.NewInt(0),
+			RsvdPrice:    big.NewInt(5e17),
+			RcvdMTR:      big.NewInt(30),
+			ActualPrice:  big.NewInt(6e17),
+			LeftoverMTRG: big.NewInt(0),
+			Txs: []*logdb.AuctionTx{
+				{Bidder: bidder, Amount: big.NewInt(10), Nonce: ^uint64(0), DistMTRG: big.NewInt(20)},
+				{Bidder: other, Amount: big.NewInt(20), Nonce: 1, DistMTRG: big.NewInt(40)},
+			},
+		}
+		if err := db.Prepare(header).InsertAuctionSummary(summary).Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	summaries, err := db.FilterAuctionSummaries(context.Background(), &logdb.AuctionSummaryFilter{Order: logdb.DESC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count, len(summaries), "summaries searched")
+	assert.Equal(t, uint64(count), summaries[0].Sequence, "latest summary first")
+	assert.Equal(t, 2, len(summaries[0].Txs), "bids of summary")
+
+	txs, err := db.FilterAuctionTxs(context.Background(), &logdb.AuctionTxFilter{Bidder: &bidder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count, len(txs), "bids searched")
+	assert.Equal(t, ^uint64(0), txs[0].Nonce, "nonce")
+	assert.Equal(t, big.NewInt(20), txs[0].DistMTRG, "distributed MTRG")
+
+	// abandon the last block
+	if err := db.Prepare(header).Commit(header.ID()); err != nil {
+		t.Fatal(err)
+	}
+	summaries, err = db.FilterAuctionSummaries(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count-1, len(summaries), "summaries after abandoned block")
+}
+
+func TestJailRecords(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	addr := meter.BytesToAddress([]byte("delegate"))
+	other := meter.BytesToAddress([]byte("other"))
+	header := new(block.Builder).Build().Header()
+	count := 10
+	for i := 0; i < count; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		action := logdb.JailIn
+		if i%2 == 1 {
+			action = logdb.JailOut
+		}
+		batch := db.Prepare(header).
+			InsertJailRecord(&logdb.JailRecord{Address: addr, Name: []byte("delegate"), Action: action, TotalPoints: 40, BailAmount: big.NewInt(1e18), JailedTime: uint64(i)}).
+			InsertJailRecord(&logdb.JailRecord{Address: other, Action: logdb.JailIn, BailAmount: big.NewInt(0)})
+		if err := batch.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := db.FilterJailRecords(context.Background(), &logdb.JailFilter{Address: &addr, Order: logdb.DESC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count, len(records), "records searched")
+	assert.Equal(t, logdb.JailOut, records[0].Action, "latest record first")
+	assert.Equal(t, uint64(count-1), records[0].JailedTime, "jailed time")
+	assert.Equal(t, big.NewInt(1e18), records[0].BailAmount, "bail amount")
+	assert.Equal(t, []byte("delegate"), records[0].Name, "name")
+
+	// abandon the last block
+	if err := db.Prepare(header).Commit(header.ID()); err != nil {
+		t.Fatal(err)
+	}
+	records, err = db.FilterJailRecords(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2*(count-1), len(records), "records after abandoned block")
+}
+
+func TestJailEvents(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	addr := meter.BytesToAddress([]byte("delegate"))
+	jailEvent := func(name string, from meter.Address) *tx.Event {
+		event, _ := setypes.ScriptEngine.ABI.EventByName(name)
+		data, err := event.Encode([]byte("delegate"), big.NewInt(40), big.NewInt(1e18), big.NewInt(100))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &tx.Event{
+			Address: from,
+			Topics:  []meter.Bytes32{event.ID(), meter.BytesToBytes32(addr.Bytes())},
+			Data:    data,
+		}
+	}
+
+	header := new(block.Builder).Build().Header()
+	events := tx.Events{
+		jailEvent("Jailed", meter.StakingModuleAddr),
+		// faked by a contract
+		jailEvent("Jailed", meter.BytesToAddress([]byte("contract"))),
+		jailEvent("Unjailed", meter.StakingModuleAddr),
+	}
+	if err := db.Prepare(header).ForTransaction(meter.Bytes32{}, meter.Address{}).Insert(events, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := db.FilterJailRecords(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(records), "records from the staking module")
+	assert.Equal(t, logdb.JailIn, records[0].Action, "jailed")
+	assert.Equal(t, logdb.JailOut, records[1].Action, "unjailed")
+	assert.Equal(t, addr, records[0].Address, "address")
+	assert.Equal(t, []byte("delegate"), records[0].Name, "name")
+	assert.Equal(t, uint64(40), records[0].TotalPoints, "total points")
+	assert.Equal(t, big.NewInt(1e18), records[0].BailAmount, "bail amount")
+	assert.Equal(t, uint64(100), records[0].JailedTime, "jailed time")
+}
+
+// accounts is a set of existing accounts.
+type accounts map[meter.Address]bool
+
+func (a accounts) Exists(addr meter.Address) bool { return a[addr] }
+
+func TestTxs(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	origin := meter.BytesToAddress([]byte("origin"))
+	to := meter.BytesToAddress([]byte("to"))
+	created := meter.BytesToAddress([]byte("created"))
+	internal := meter.BytesToAddress([]byte("internal"))
+	newMasterEvent := func(addr meter.Address) *tx.Event {
+		return &tx.Event{
+			Address: addr,
+			Topics:  []meter.Bytes32{meter.Bytes32(crypto.Keccak256Hash([]byte("$Master(address)")))},
+		}
+	}
+	existing := accounts{to: true}
+
+	header := new(block.Builder).Build().Header()
+	count := 10
+	for i := 0; i < count; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		trx := new(tx.Builder).Clause(tx.NewClause(&to)).Clause(tx.NewClause(nil)).Nonce(uint64(i)).Build()
+		receipt := &tx.Receipt{Outputs: []*tx.Output{
+			// the called contract creates another one, then sets its own master
+			{Events: tx.Events{newMasterEvent(internal), newMasterEvent(to)}},
+			// the created contract sets its master again in its init code
+			{Events: tx.Events{newMasterEvent(created), newMasterEvent(created)}},
+		}}
+		if err := db.Prepare(header).InsertTx(trx, origin, receipt, existing).Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := db.FilterTxs(context.Background(), &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{{TxOrigin: &origin}},
+		Order:       logdb.DESC,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count*2, len(records), "clauses by origin")
+	assert.Equal(t, uint32(count+1), records[0].BlockNumber, "latest first")
+
+	records, err = db.FilterTxs(context.Background(), &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{{To: &to}, {Created: &created}},
+		Range:       &logdb.Range{Unit: logdb.Block, From: 1, To: 5},
+		Options:     &logdb.Options{Offset: 0, Limit: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(records), "clauses by recipient or created contract")
+	assert.Equal(t, &to, records[0].To, "clause recipient")
+	assert.Equal(t, []meter.Address{internal}, records[0].Created, "contract created internally")
+	assert.Nil(t, records[1].To, "contract creation")
+	assert.Equal(t, []meter.Address{created}, records[1].Created, "created contract")
+
+	records, err = db.FilterTxs(context.Background(), &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{{Created: &internal}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count, len(records), "clauses creating contract internally")
+
+	records, err = db.FilterTxs(context.Background(), &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{{Created: &to}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, records, "master set on existing account")
+
+	records, err = db.FilterTxs(context.Background(), &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{{ContractCreation: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count, len(records), "contract creations")
+
+	// abandon the last block
+	if err := db.Prepare(header).Commit(header.ID()); err != nil {
+		t.Fatal(err)
+	}
+	records, err = db.FilterTxs(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, (count-1)*2, len(records), "clauses after abandoned block")
+	records, err = db.FilterTxs(context.Background(), &logdb.TxFilter{
+		CriteriaSet: []*logdb.TxCriteria{{Created: &internal}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count-1, len(records), "internal creations after abandoned block")
+}
+
+func TestTxsWithoutAccounts(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	to := meter.BytesToAddress([]byte("to"))
+	created := meter.BytesToAddress([]byte("created"))
+	masterEventID := meter.Bytes32(crypto.Keccak256Hash([]byte("$Master(address)")))
+
+	header := new(block.Builder).ParentID(new(block.Builder).Build().Header().ID()).Build().Header()
+	trx := new(tx.Builder).Clause(tx.NewClause(&to)).Clause(tx.NewClause(nil)).Build()
+	receipt := &tx.Receipt{Outputs: []*tx.Output{
+		{Events: tx.Events{{Address: meter.BytesToAddress([]byte("internal")), Topics: []meter.Bytes32{masterEventID}}}},
+		{Events: tx.Events{{Address: created, Topics: []meter.Bytes32{masterEventID}}}},
+	}}
+	if err := db.Prepare(header).InsertTx(trx, meter.Address{}, receipt, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := db.FilterTxs(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(records))
+	assert.Empty(t, records[0].Created, "internal creation needs accounts")
+	assert.Equal(t, []meter.Address{created}, records[1].Created, "contract created by clause")
+}
+
+func TestTokenTransfers(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	token := meter.BytesToAddress([]byte("token"))
+	nft := meter.BytesToAddress([]byte("nft"))
+	holder := meter.BytesToAddress([]byte("holder"))
+	other := meter.BytesToAddress([]byte("other"))
+	transferEventID := meter.Bytes32(crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")))
+	newTransferEvent := func(from, to meter.Address) *tx.Event {
+		return &tx.Event{
+			Address: token,
+			Topics:  []meter.Bytes32{transferEventID, meter.BytesToBytes32(from.Bytes()), meter.BytesToBytes32(to.Bytes())},
+			Data:    meter.BytesToBytes32(big.NewInt(10).Bytes()).Bytes(),
+		}
+	}
+
+	header := new(block.Builder).Build().Header()
+	count := 10
+	for i := 0; i < count; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		events := tx.Events{
+			newTransferEvent(holder, other),
+			newTransferEvent(other, holder),
+			newTransferEvent(other, holder),
+			{
+				Address: nft,
+				Topics:  []meter.Bytes32{transferEventID, meter.BytesToBytes32(other.Bytes()), meter.BytesToBytes32(holder.Bytes()), meter.BytesToBytes32([]byte{byte(i)})},
+			},
+			// not a token transfer
+			{Address: token, Topics: []meter.Bytes32{transferEventID}},
+		}
+		if err := db.Prepare(header).ForTransaction(meter.Bytes32{}, other).Insert(events, nil).Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	transfers, err := db.FilterTokenTransfers(context.Background(), &logdb.TokenTransferFilter{
+		CriteriaSet: []*logdb.TokenTransferCriteria{{Holder: &holder}},
+		Order:       logdb.DESC,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count*4, len(transfers), "transfers by holder")
+	assert.Equal(t, uint32(count+1), transfers[0].BlockNumber, "latest first")
+	assert.Equal(t, logdb.ERC721, transfers[0].Standard)
+	assert.Equal(t, big.NewInt(int64(count-1)), transfers[0].Amount, "token id")
+
+	transfers, err = db.FilterTokenTransfers(context.Background(), &logdb.TokenTransferFilter{
+		CriteriaSet: []*logdb.TokenTransferCriteria{{Token: &token, Sender: &holder}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, count, len(transfers), "transfers by token and sender")
+	assert.Equal(t, logdb.ERC20, transfers[0].Standard)
+	assert.Equal(t, big.NewInt(10), transfers[0].Amount, "value")
+
+	changes, err := db.TokenBalanceChanges(context.Background(), holder, nil, &logdb.Range{Unit: logdb.Block, From: 0, To: 5}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(changes), "balance changes per token")
+	assert.Equal(t, token, changes[0].Token)
+	assert.Equal(t, big.NewInt(80), changes[0].Received)
+	assert.Equal(t, big.NewInt(40), changes[0].Sent)
+	assert.Equal(t, nft, changes[1].Token)
+	assert.Equal(t, big.NewInt(4), changes[1].Received, "number of nfts")
+
+	changes, err = db.TokenBalanceChanges(context.Background(), holder, nil, &logdb.Range{Unit: logdb.Block, From: 0, To: 5}, &logdb.Options{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(changes), "paged by token")
+	assert.Equal(t, nft, changes[0].Token)
+	assert.Equal(t, big.NewInt(4), changes[0].Received, "all transfers of the paged token")
+
+	// abandon the last block
+	if err := db.Prepare(header).Commit(header.ID()); err != nil {
+		t.Fatal(err)
+	}
+	transfers, err = db.FilterTokenTransfers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, (count-1)*4, len(transfers), "transfers after abandoned block")
+
+	// backfill the token transfers of a block whose events are indexed
+	events, err := db.FilterEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Prepare(header).InsertTokenTransfers(meter.Bytes32{}, other, tx.Events{newTransferEvent(holder, other)}).Commit(); err != nil {
+		t.Fatal(err)
+	}
+	transfers, err = db.FilterTokenTransfers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, (count-1)*4+1, len(transfers), "backfilled transfers")
+	backfilled, err := db.FilterEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(events), len(backfilled), "events untouched")
+}
+
+func TestCountAndTruncate(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	addr := meter.BytesToAddress([]byte("addr"))
+	header := new(block.Builder).Build().Header()
+	var headers []*block.Header
+	for i := 0; i < 5; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+		headers = append(headers, header)
+		events := tx.Events{{Address: addr}, {Address: addr}}
+		transfers := tx.Transfers{{Sender: addr, Recipient: addr, Amount: big.NewInt(1)}}
+		if err := db.Prepare(header).ForTransaction(meter.Bytes32{}, addr).Insert(events, transfers).
+			InsertJailRecord(&logdb.JailRecord{Address: addr, Action: logdb.JailIn, BailAmount: big.NewInt(1)}).
+			Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts, err := db.CountLogs(context.Background(), headers[2].Number())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []*logdb.LogCount{{BlockID: headers[2].ID(), Events: 2, Transfers: 1}}, counts)
+
+	if err := db.Truncate(headers[2].Number()); err != nil {
+		t.Fatal(err)
+	}
+	counts, err = db.CountLogs(context.Background(), headers[3].Number())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, counts, "truncated")
+	events, err := db.FilterEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3*2, len(events))
+	jails, err := db.FilterJailRecords(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(jails), "jail records truncated")
+}
+
 func home() (string, error) {
 	// try to get HOME env
 	if home := os.Getenv("HOME"); home != "" {
@@ -54,4 +54,127 @@ CREATE INDEX IF NOT EXISTS blockNumberIndex ON transfer(blockNumber);
 CREATE INDEX IF NOT EXISTS blockTimeIndex ON transfer(blockTime);
 CREATE INDEX IF NOT EXISTS senderIndex ON transfer(sender);
 CREATE INDEX IF NOT EXISTS recipientIndex ON transfer(recipient);`
+
+	// create tables for closed auctions and their bids
+	auctionTableSchema = `CREATE TABLE IF NOT EXISTS auctionSummary (
+	blockID BLOB(32),
+	blockNumber INTEGER,
+	blockTime INTEGER,
+	auctionID BLOB(32),
+	startHeight INTEGER,
+	startEpoch INTEGER,
+	endHeight INTEGER,
+	endEpoch INTEGER,
+	sequence INTEGER,
+	releasedMTRG BLOB,
+	reservedMTRG BLOB,
+	reservedPrice BLOB,
+	createTime INTEGER,
+	receivedMTR BLOB,
+	actualPrice BLOB,
+	leftoverMTRG BLOB
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS auctionSummaryPrim ON auctionSummary(blockID, auctionID);
+
+CREATE INDEX IF NOT EXISTS auctionSummaryBlockNumberIndex ON auctionSummary(blockNumber);
+CREATE INDEX IF NOT EXISTS auctionSummaryIDIndex ON auctionSummary(auctionID);
+
+CREATE TABLE IF NOT EXISTS auctionTx (
+	blockID BLOB(32),
+	auctionTxIndex INTEGER,
+	blockNumber INTEGER,
+	blockTime INTEGER,
+	auctionID BLOB(32),
+	txID BLOB(32),
+	bidder BLOB(20),
+	amount BLOB,
+	type INTEGER,
+	timestamp INTEGER,
+	nonce BLOB,
+	distMTRG BLOB
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS auctionTxPrim ON auctionTx(blockID, auctionTxIndex);
+
+CREATE INDEX IF NOT EXISTS auctionTxBlockNumberIndex ON auctionTx(blockNumber);
+CREATE INDEX IF NOT EXISTS auctionTxAuctionIDIndex ON auctionTx(auctionID);
+CREATE INDEX IF NOT EXISTS auctionTxBidderIndex ON auctionTx(bidder);`
+
+	// create a table for clauses of txs, indexed by tx origin and clause recipient
+	txTableSchema = `CREATE TABLE IF NOT EXISTS txRecord (
+	blockID BLOB(32),
+	txRecordIndex INTEGER,
+	blockNumber INTEGER,
+	blockTime INTEGER,
+	txID BLOB(32),
+	txOrigin BLOB(20),
+	clauseIndex INTEGER,
+	clauseTo BLOB(20),
+	reverted BOOLEAN
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS txRecordPrim ON txRecord(blockID, txRecordIndex);
+
+CREATE INDEX IF NOT EXISTS txRecordBlockNumberIndex ON txRecord(blockNumber);
+CREATE INDEX IF NOT EXISTS txRecordBlockTimeIndex ON txRecord(blockTime);
+CREATE INDEX IF NOT EXISTS txRecordTxOriginIndex ON txRecord(txOrigin);
+CREATE INDEX IF NOT EXISTS txRecordClauseToIndex ON txRecord(clauseTo);`
+
+	// create a table for contracts created by clauses of txs, directly or internally
+	contractCreationTableSchema = `CREATE TABLE IF NOT EXISTS contractCreation (
+	blockID BLOB(32),
+	creationIndex INTEGER,
+	blockNumber INTEGER,
+	txRecordIndex INTEGER,
+	address BLOB(20)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS contractCreationPrim ON contractCreation(blockID, creationIndex);
+
+CREATE INDEX IF NOT EXISTS contractCreationBlockNumberIndex ON contractCreation(blockNumber);
+CREATE INDEX IF NOT EXISTS contractCreationTxRecordIndex ON contractCreation(blockID, txRecordIndex);
+CREATE INDEX IF NOT EXISTS contractCreationAddressIndex ON contractCreation(address);`
+
+	// create a table for ERC20 and ERC721 token transfers
+	tokenTransferTableSchema = `CREATE TABLE IF NOT EXISTS tokenTransfer (
+	blockID BLOB(32),
+	tokenTransferIndex INTEGER,
+	blockNumber INTEGER,
+	blockTime INTEGER,
+	txID BLOB(32),
+	txOrigin BLOB(20),
+	token BLOB(20),
+	sender BLOB(20),
+	recipient BLOB(20),
+	amount BLOB,
+	standard INTEGER
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tokenTransferPrim ON tokenTransfer(blockID, tokenTransferIndex);
+
+CREATE INDEX IF NOT EXISTS tokenTransferBlockNumberIndex ON tokenTransfer(blockNumber);
+CREATE INDEX IF NOT EXISTS tokenTransferBlockTimeIndex ON tokenTransfer(blockTime);
+CREATE INDEX IF NOT EXISTS tokenTransferTokenIndex ON tokenTransfer(token);
+CREATE INDEX IF NOT EXISTS tokenTransferSenderIndex ON tokenTransfer(sender);
+CREATE INDEX IF NOT EXISTS tokenTransferRecipientIndex ON tokenTransfer(recipient);`
+
+	// create a table for delegates put into and released from jail
+	jailTableSchema = `CREATE TABLE IF NOT EXISTS jail (
+	blockID BLOB(32),
+	jailIndex INTEGER,
+	blockNumber INTEGER,
+	blockTime INTEGER,
+	address BLOB(20),
+	name BLOB,
+	action INTEGER,
+	totalPoints INTEGER,
+	bailAmount BLOB,
+	jailedTime INTEGER
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS jailPrim ON jail(blockID, jailIndex);
+
+CREATE INDEX IF NOT EXISTS jailBlockNumberIndex ON jail(blockNumber);
+CREATE INDEX IF NOT EXISTS jailAddressIndex ON jail(address);`
 )
@@ -0,0 +1,264 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+
+	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/tx"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// transferEventID is the id of event Transfer(address,address,uint256), shared by
+// ERC20 and ERC721 tokens.
+var transferEventID = meter.Bytes32(crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")))
+
+// newTokenTransfer recognises the ERC20 or ERC721 Transfer event, and converts it
+// to TokenTransfer. nil is returned if the event is not a token transfer.
+func newTokenTransfer(header *block.Header, index uint32, txID meter.Bytes32, txOrigin meter.Address, txEvent *tx.Event) *TokenTransfer {
+	if len(txEvent.Topics) == 0 || txEvent.Topics[0] != transferEventID {
+		return nil
+	}
+	tt := &TokenTransfer{
+		BlockID:     header.ID(),
+		Index:       index,
+		BlockNumber: header.Number(),
+		BlockTime:   header.Timestamp(),
+		TxID:        txID,
+		TxOrigin:    txOrigin,
+		Token:       txEvent.Address,
+	}
+	switch {
+	case len(txEvent.Topics) == 3 && len(txEvent.Data) == 32:
+		// ERC20: Transfer(address indexed from, address indexed to, uint256 value)
+		tt.Amount = new(big.Int).SetBytes(txEvent.Data)
+		tt.Standard = ERC20
+	case len(txEvent.Topics) == 4 && len(txEvent.Data) == 0:
+		// ERC721: Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+		tt.Amount = new(big.Int).SetBytes(txEvent.Topics[3].Bytes())
+		tt.Standard = ERC721
+	default:
+		return nil
+	}
+	tt.Sender = meter.BytesToAddress(txEvent.Topics[1].Bytes())
+	tt.Recipient = meter.BytesToAddress(txEvent.Topics[2].Bytes())
+	return tt
+}
+
+func insertTokenTransfers(tx *sql.Tx, transfers []*TokenTransfer) error {
+	for _, t := range transfers {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO tokenTransfer(blockID, tokenTransferIndex, blockNumber, blockTime, txID, txOrigin, token, sender, recipient, amount, standard) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			t.BlockID.Bytes(),
+			t.Index,
+			t.BlockNumber,
+			t.BlockTime,
+			t.TxID.Bytes(),
+			t.TxOrigin.Bytes(),
+			t.Token.Bytes(),
+			t.Sender.Bytes(),
+			t.Recipient.Bytes(),
+			bigValue(t.Amount),
+			t.Standard,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteTokenTransfers(tx *sql.Tx, blockID meter.Bytes32) error {
+	_, err := tx.Exec("DELETE FROM tokenTransfer WHERE blockID = ?;", blockID.Bytes())
+	return err
+}
+
+// FilterTokenTransfers returns the ERC20 and ERC721 token transfers matching the filter.
+func (db *LogDB) FilterTokenTransfers(ctx context.Context, filter *TokenTransferFilter) ([]*TokenTransfer, error) {
+	if filter == nil {
+		return db.queryTokenTransfers(ctx, "SELECT * FROM tokenTransfer")
+	}
+	var args []interface{}
+	stmt := "SELECT * FROM tokenTransfer WHERE 1"
+	condition := "blockNumber"
+	if filter.Range != nil {
+		if filter.Range.Unit == Time {
+			condition = "blockTime"
+		}
+		args = append(args, filter.Range.From)
+		stmt += " AND " + condition + " >= ? "
+		if filter.Range.To >= filter.Range.From {
+			args = append(args, filter.Range.To)
+			stmt += " AND " + condition + " <= ? "
+		}
+	}
+	if filter.TxID != nil {
+		args = append(args, filter.TxID.Bytes())
+		stmt += " AND txID = ? "
+	}
+	for i, criteria := range filter.CriteriaSet {
+		if i == 0 {
+			stmt += " AND (( 1 "
+		} else {
+			stmt += " OR ( 1 "
+		}
+		if criteria.Token != nil {
+			args = append(args, criteria.Token.Bytes())
+			stmt += " AND token = ? "
+		}
+		if criteria.Sender != nil {
+			args = append(args, criteria.Sender.Bytes())
+			stmt += " AND sender = ? "
+		}
+		if criteria.Recipient != nil {
+			args = append(args, criteria.Recipient.Bytes())
+			stmt += " AND recipient = ? "
+		}
+		if criteria.Holder != nil {
+			args = append(args, criteria.Holder.Bytes(), criteria.Holder.Bytes())
+			stmt += " AND (sender = ? OR recipient = ?) "
+		}
+		stmt += " ) "
+	}
+	if len(filter.CriteriaSet) > 0 {
+		stmt += " ) "
+	}
+	if filter.Order == DESC {
+		stmt += " ORDER BY blockNumber DESC,tokenTransferIndex DESC "
+	} else {
+		stmt += " ORDER BY blockNumber ASC,tokenTransferIndex ASC "
+	}
+	if filter.Options != nil {
+		stmt += " limit ?, ? "
+		args = append(args, filter.Options.Offset, filter.Options.Limit)
+	}
+	return db.queryTokenTransfers(ctx, stmt, args...)
+}
+
+// TokenBalanceChanges sums up the tokens received and sent by the holder, grouped by
+// token contract. For ERC721 tokens, the number of tokens is summed up. The options
+// page the token contracts, ordered by their first transfer to or from the holder.
+func (db *LogDB) TokenBalanceChanges(ctx context.Context, holder meter.Address, token *meter.Address, rng *Range, opts *Options) ([]*TokenBalanceChange, error) {
+	args := []interface{}{holder.Bytes(), holder.Bytes()}
+	condition := " (sender = ? OR recipient = ?) "
+	if token != nil {
+		args = append(args, token.Bytes())
+		condition += " AND token = ? "
+	}
+	if rng != nil {
+		column := "blockNumber"
+		if rng.Unit == Time {
+			column = "blockTime"
+		}
+		args = append(args, rng.From)
+		condition += " AND " + column + " >= ? "
+		if rng.To >= rng.From {
+			args = append(args, rng.To)
+			condition += " AND " + column + " <= ? "
+		}
+	}
+	tokens := "SELECT token FROM tokenTransfer WHERE" + condition + " GROUP BY token ORDER BY MIN(blockNumber * 4294967296 + tokenTransferIndex) ASC "
+	stmtArgs := append(append([]interface{}{}, args...), args...)
+	if opts != nil {
+		tokens += " limit ?, ? "
+		stmtArgs = append(stmtArgs, opts.Offset, opts.Limit)
+	}
+	stmt := "SELECT * FROM tokenTransfer WHERE" + condition + " AND token IN (" + tokens + ") ORDER BY blockNumber ASC,tokenTransferIndex ASC "
+	transfers, err := db.queryTokenTransfers(ctx, stmt, stmtArgs...)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*TokenBalanceChange
+	index := make(map[meter.Address]*TokenBalanceChange)
+	for _, t := range transfers {
+		change, ok := index[t.Token]
+		if !ok {
+			change = &TokenBalanceChange{
+				Token:    t.Token,
+				Standard: t.Standard,
+				Received: new(big.Int),
+				Sent:     new(big.Int),
+			}
+			index[t.Token] = change
+			changes = append(changes, change)
+		}
+		amount := t.Amount
+		if t.Standard == ERC721 {
+			amount = big.NewInt(1)
+		}
+		if t.Recipient == holder {
+			change.Received.Add(change.Received, amount)
+		}
+		if t.Sender == holder {
+			change.Sent.Add(change.Sent, amount)
+		}
+	}
+	return changes, nil
+}
+
+func (db *LogDB) queryTokenTransfers(ctx context.Context, stmt string, args ...interface{}) ([]*TokenTransfer, error) {
+	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []*TokenTransfer
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockID     []byte
+			index       uint32
+			blockNumber uint32
+			blockTime   uint64
+			txID        []byte
+			txOrigin    []byte
+			token       []byte
+			sender      []byte
+			recipient   []byte
+			amount      []byte
+			standard    uint32
+		)
+		if err := rows.Scan(
+			&blockID,
+			&index,
+			&blockNumber,
+			&blockTime,
+			&txID,
+			&txOrigin,
+			&token,
+			&sender,
+			&recipient,
+			&amount,
+			&standard,
+		); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, &TokenTransfer{
+			BlockID:     meter.BytesToBytes32(blockID),
+			Index:       index,
+			BlockNumber: blockNumber,
+			BlockTime:   blockTime,
+			TxID:        meter.BytesToBytes32(txID),
+			TxOrigin:    meter.BytesToAddress(txOrigin),
+			Token:       meter.BytesToAddress(token),
+			Sender:      meter.BytesToAddress(sender),
+			Recipient:   meter.BytesToAddress(recipient),
+			Amount:      new(big.Int).SetBytes(amount),
+			Standard:    TokenStandard(standard),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
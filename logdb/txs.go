@@ -0,0 +1,255 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/tx"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// masterEventID is the id of prototype event $Master(address), which is emitted by
+// the created contract before its init code runs.
+var masterEventID = meter.Bytes32(crypto.Keccak256Hash([]byte("$Master(address)")))
+
+const txRecordColumns = "blockID, txRecordIndex, blockNumber, blockTime, txID, txOrigin, clauseIndex, clauseTo, reverted"
+
+// Accounts tells whether an account exists, it's the state of the parent block when
+// indexing the txs of a block.
+type Accounts interface {
+	Exists(addr meter.Address) bool
+}
+
+// InsertTx adds the origin, clause recipients and created contracts of the tx.
+// Every CREATE and CREATE2, internal ones included, makes the created contract emit a
+// $Master event, but so does setting the master of an account. The first event of a
+// clause without recipient is always a creation, other events are creations only if
+// the account does not exist in accounts. When accounts is nil, only the contracts
+// created by clauses directly are added.
+func (bb *BlockBatch) InsertTx(t *tx.Transaction, txOrigin meter.Address, receipt *tx.Receipt, accounts Accounts) *BlockBatch {
+	if bb.created == nil {
+		bb.created = make(map[meter.Address]bool)
+	}
+	for i, clause := range t.Clauses() {
+		record := &TxRecord{
+			BlockID:     bb.header.ID(),
+			Index:       uint32(len(bb.txs)),
+			BlockNumber: bb.header.Number(),
+			BlockTime:   bb.header.Timestamp(),
+			TxID:        t.ID(),
+			TxOrigin:    txOrigin,
+			ClauseIndex: uint32(i),
+			To:          clause.To(),
+			Reverted:    receipt.Reverted,
+		}
+		if !receipt.Reverted && i < len(receipt.Outputs) {
+			for j, event := range receipt.Outputs[i].Events {
+				if len(event.Topics) == 0 || event.Topics[0] != masterEventID || bb.created[event.Address] {
+					continue
+				}
+				if (j == 0 && record.To == nil) || (accounts != nil && !accounts.Exists(event.Address)) {
+					bb.created[event.Address] = true
+					bb.creations = append(bb.creations, &ContractCreation{
+						BlockID:       record.BlockID,
+						Index:         uint32(len(bb.creations)),
+						BlockNumber:   record.BlockNumber,
+						TxRecordIndex: record.Index,
+						Address:       event.Address,
+					})
+					record.Created = append(record.Created, event.Address)
+				}
+			}
+		}
+		bb.txs = append(bb.txs, record)
+	}
+	return bb
+}
+
+func insertTxRecords(tx *sql.Tx, records []*TxRecord, creations []*ContractCreation) error {
+	for _, r := range records {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO txRecord("+txRecordColumns+") VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			r.BlockID.Bytes(),
+			r.Index,
+			r.BlockNumber,
+			r.BlockTime,
+			r.TxID.Bytes(),
+			r.TxOrigin.Bytes(),
+			r.ClauseIndex,
+			addressValue(r.To),
+			r.Reverted,
+		); err != nil {
+			return err
+		}
+	}
+	for _, c := range creations {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO contractCreation(blockID, creationIndex, blockNumber, txRecordIndex, address) VALUES ( ?, ?, ?, ?, ?);",
+			c.BlockID.Bytes(),
+			c.Index,
+			c.BlockNumber,
+			c.TxRecordIndex,
+			c.Address.Bytes(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteTxRecords(tx *sql.Tx, blockID meter.Bytes32) error {
+	if _, err := tx.Exec("DELETE FROM txRecord WHERE blockID = ?;", blockID.Bytes()); err != nil {
+		return err
+	}
+	_, err := tx.Exec("DELETE FROM contractCreation WHERE blockID = ?;", blockID.Bytes())
+	return err
+}
+
+// FilterTxs returns the clauses of txs matching the filter, which can be used to list
+// txs originated by an account, called it or created contracts.
+func (db *LogDB) FilterTxs(ctx context.Context, filter *TxFilter) ([]*TxRecord, error) {
+	if filter == nil {
+		filter = &TxFilter{}
+	}
+	var args []interface{}
+	stmt := "SELECT " + txRecordColumns + " FROM txRecord WHERE 1"
+	condition := "blockNumber"
+	if filter.Range != nil {
+		if filter.Range.Unit == Time {
+			condition = "blockTime"
+		}
+		args = append(args, filter.Range.From)
+		stmt += " AND " + condition + " >= ? "
+		if filter.Range.To >= filter.Range.From {
+			args = append(args, filter.Range.To)
+			stmt += " AND " + condition + " <= ? "
+		}
+	}
+	for i, criteria := range filter.CriteriaSet {
+		if i == 0 {
+			stmt += " AND (( 1 "
+		} else {
+			stmt += " OR ( 1 "
+		}
+		if criteria.TxOrigin != nil {
+			args = append(args, criteria.TxOrigin.Bytes())
+			stmt += " AND txOrigin = ? "
+		}
+		if criteria.To != nil {
+			args = append(args, criteria.To.Bytes())
+			stmt += " AND clauseTo = ? "
+		}
+		if criteria.Created != nil {
+			args = append(args, criteria.Created.Bytes())
+			stmt += " AND EXISTS (SELECT 1 FROM contractCreation c WHERE c.blockID = txRecord.blockID AND c.txRecordIndex = txRecord.txRecordIndex AND c.address = ?) "
+		}
+		if criteria.ContractCreation {
+			stmt += " AND clauseTo IS NULL "
+		}
+		stmt += " ) "
+	}
+	if len(filter.CriteriaSet) > 0 {
+		stmt += " ) "
+	}
+	order := " ASC"
+	if filter.Order == DESC {
+		order = " DESC"
+	}
+	stmt += " ORDER BY blockNumber" + order + ",txRecordIndex" + order
+	if filter.Options != nil {
+		stmt += " limit ?, ? "
+		args = append(args, filter.Options.Offset, filter.Options.Limit)
+	}
+	// join the created contracts after paging, so that a clause is counted once
+	stmt = "SELECT r.*, c.address FROM (" + stmt + ") r LEFT JOIN contractCreation c ON c.blockID = r.blockID AND c.txRecordIndex = r.txRecordIndex" +
+		" ORDER BY r.blockNumber" + order + ",r.txRecordIndex" + order + ",c.creationIndex ASC"
+	return db.queryTxRecords(ctx, stmt, args...)
+}
+
+func (db *LogDB) queryTxRecords(ctx context.Context, stmt string, args ...interface{}) ([]*TxRecord, error) {
+	rows, err := db.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*TxRecord
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		var (
+			blockID     []byte
+			index       uint32
+			blockNumber uint32
+			blockTime   uint64
+			txID        []byte
+			txOrigin    []byte
+			clauseIndex uint32
+			clauseTo    []byte
+			reverted    bool
+			created     []byte
+		)
+		if err := rows.Scan(
+			&blockID,
+			&index,
+			&blockNumber,
+			&blockTime,
+			&txID,
+			&txOrigin,
+			&clauseIndex,
+			&clauseTo,
+			&reverted,
+			&created,
+		); err != nil {
+			return nil, err
+		}
+		// rows of the same clause are adjacent, one for each created contract
+		if n := len(records); n > 0 && records[n-1].BlockID == meter.BytesToBytes32(blockID) && records[n-1].Index == index {
+			if len(created) > 0 {
+				records[n-1].Created = append(records[n-1].Created, meter.BytesToAddress(created))
+			}
+			continue
+		}
+		record := &TxRecord{
+			BlockID:     meter.BytesToBytes32(blockID),
+			Index:       index,
+			BlockNumber: blockNumber,
+			BlockTime:   blockTime,
+			TxID:        meter.BytesToBytes32(txID),
+			TxOrigin:    meter.BytesToAddress(txOrigin),
+			ClauseIndex: clauseIndex,
+			To:          addressFromValue(clauseTo),
+			Reverted:    reverted,
+		}
+		if len(created) > 0 {
+			record.Created = append(record.Created, meter.BytesToAddress(created))
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func addressValue(addr *meter.Address) []byte {
+	if addr == nil {
+		return nil
+	}
+	return addr.Bytes()
+}
+
+func addressFromValue(v []byte) *meter.Address {
+	if len(v) == 0 {
+		return nil
+	}
+	addr := meter.BytesToAddress(v)
+	return &addr
+}
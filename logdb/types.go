@@ -125,3 +125,167 @@ type TransferFilter struct {
 	Options     *Options
 	Order       Order //default asc
 }
+
+//TxRecord represents a clause of tx that can be stored in db.
+type TxRecord struct {
+	BlockID     meter.Bytes32
+	Index       uint32
+	BlockNumber uint32
+	BlockTime   uint64
+	TxID        meter.Bytes32
+	TxOrigin    meter.Address
+	ClauseIndex uint32
+	To          *meter.Address  // nil for contract creation
+	Created     []meter.Address // contracts created by the clause, internal creations included
+	Reverted    bool
+}
+
+//ContractCreation represents a contract created by a clause of tx, directly or internally.
+type ContractCreation struct {
+	BlockID       meter.Bytes32
+	Index         uint32
+	BlockNumber   uint32
+	TxRecordIndex uint32
+	Address       meter.Address
+}
+
+type TxCriteria struct {
+	TxOrigin         *meter.Address //who send transaction
+	To               *meter.Address //who the clause called
+	Created          *meter.Address //which contract the clause created
+	ContractCreation bool           //only clauses creating contract
+}
+
+type TxFilter struct {
+	CriteriaSet []*TxCriteria
+	Range       *Range
+	Options     *Options
+	Order       Order //default asc
+}
+
+//TokenStandard is the standard a token contract implements.
+type TokenStandard uint32
+
+const (
+	ERC20  TokenStandard = 20
+	ERC721 TokenStandard = 721
+)
+
+//TokenTransfer represents an ERC20 or ERC721 Transfer event that can be stored in db.
+type TokenTransfer struct {
+	BlockID     meter.Bytes32
+	Index       uint32
+	BlockNumber uint32
+	BlockTime   uint64
+	TxID        meter.Bytes32
+	TxOrigin    meter.Address
+	Token       meter.Address // the token contract
+	Sender      meter.Address
+	Recipient   meter.Address
+	Amount      *big.Int // value for ERC20, token id for ERC721
+	Standard    TokenStandard
+}
+
+type TokenTransferCriteria struct {
+	Token     *meter.Address //the token contract
+	Sender    *meter.Address //who transferred tokens
+	Recipient *meter.Address //who recieved tokens
+	Holder    *meter.Address //either sender or recipient
+}
+
+type TokenTransferFilter struct {
+	TxID        *meter.Bytes32
+	CriteriaSet []*TokenTransferCriteria
+	Range       *Range
+	Options     *Options
+	Order       Order //default asc
+}
+
+//TokenBalanceChange is the sum of tokens received and sent by a holder.
+type TokenBalanceChange struct {
+	Token    meter.Address
+	Standard TokenStandard
+	Received *big.Int
+	Sent     *big.Int
+}
+
+//AuctionSummary represents a closed auction that can be stored in db.
+type AuctionSummary struct {
+	BlockID      meter.Bytes32
+	BlockNumber  uint32
+	BlockTime    uint64
+	AuctionID    meter.Bytes32
+	StartHeight  uint64
+	StartEpoch   uint64
+	EndHeight    uint64
+	EndEpoch     uint64
+	Sequence     uint64
+	RlsdMTRG     *big.Int
+	RsvdMTRG     *big.Int
+	RsvdPrice    *big.Int
+	CreateTime   uint64
+	RcvdMTR      *big.Int
+	ActualPrice  *big.Int
+	LeftoverMTRG *big.Int
+	Txs          []*AuctionTx
+}
+
+//AuctionTx represents a bid of a closed auction, along with the MTRG distributed for it.
+type AuctionTx struct {
+	BlockID     meter.Bytes32
+	Index       uint32
+	BlockNumber uint32
+	BlockTime   uint64
+	AuctionID   meter.Bytes32
+	TxID        meter.Bytes32
+	Bidder      meter.Address
+	Amount      *big.Int
+	Type        uint32
+	Timestamp   uint64
+	Nonce       uint64
+	DistMTRG    *big.Int
+}
+
+type AuctionSummaryFilter struct {
+	AuctionID *meter.Bytes32
+	Range     *Range
+	Options   *Options
+	Order     Order //default asc
+}
+
+type AuctionTxFilter struct {
+	AuctionID *meter.Bytes32
+	Bidder    *meter.Address
+	Range     *Range
+	Options   *Options
+	Order     Order //default asc
+}
+
+//JailAction is the change of a delegate in the jail list.
+type JailAction uint32
+
+const (
+	JailIn JailAction = iota
+	JailOut
+)
+
+//JailRecord represents a delegate put into or released from jail in a block.
+type JailRecord struct {
+	BlockID     meter.Bytes32
+	Index       uint32
+	BlockNumber uint32
+	BlockTime   uint64
+	Address     meter.Address
+	Name        []byte
+	Action      JailAction
+	TotalPoints uint64
+	BailAmount  *big.Int
+	JailedTime  uint64
+}
+
+type JailFilter struct {
+	Address *meter.Address
+	Range   *Range
+	Options *Options
+	Order   Order //default asc
+}
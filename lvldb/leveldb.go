@@ -28,7 +28,8 @@ var readOpt = opt.ReadOptions{}
 
 // LevelDB wraps level db impls.
 type LevelDB struct {
-	db *leveldb.DB
+	db        *leveldb.DB
+	recovered bool
 }
 
 // New create a persistent level db instance.
@@ -50,14 +51,22 @@ func New(path string, opts Options) (*LevelDB, error) {
 		Filter:                 filter.NewBloomFilter(10),
 	})
 
+	recovered := false
 	if _, corrupted := err.(*dberrors.ErrCorrupted); corrupted {
 		db, err = leveldb.RecoverFile(path, nil)
+		recovered = true
 	}
 
 	if err != nil {
 		return nil, err
 	}
-	return &LevelDB{db: db}, nil
+	return &LevelDB{db: db, recovered: recovered}, nil
+}
+
+// Recovered returns whether the db was corrupted and recovered when opened.
+// Entries in the corrupted tables may be lost during recovery.
+func (ldb *LevelDB) Recovered() bool {
+	return ldb.recovered
 }
 
 // NewMem create a level db in memory.
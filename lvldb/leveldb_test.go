@@ -6,6 +6,9 @@
 package lvldb
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -108,3 +111,30 @@ func TestLevelDBBach(t *testing.T) {
 		assert.Equal(t, tt.expected, tt.ret)
 	}
 }
+
+func TestLevelDBRecovered(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lvldbRecovered")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	lvldb, err := New(dir, Options{16, 16})
+	assert.Nil(t, err)
+	assert.False(t, lvldb.Recovered())
+	assert.Nil(t, lvldb.Put([]byte("123"), []byte("456")))
+	lvldb.Close()
+
+	// corrupt the manifest
+	manifests, err := filepath.Glob(filepath.Join(dir, "MANIFEST-*"))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, manifests)
+	assert.Nil(t, ioutil.WriteFile(manifests[0], []byte("corrupted manifest"), 0644))
+
+	lvldb, err = New(dir, Options{16, 16})
+	assert.Nil(t, err)
+	defer lvldb.Close()
+	assert.True(t, lvldb.Recovered())
+
+	value, err := lvldb.Get([]byte("123"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("456"), value)
+}
@@ -7,6 +7,8 @@ package meter
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/inconshreveable/log15"
 )
@@ -38,6 +40,22 @@ const (
 
 	TeslaFork2_MainnetStartNum = 10382000 // around 4/16/2021 11:00 AM
 	TeslaFork2_TestnetStartNum = 682000   // around 4/16/2021 11:00 AM
+
+	// Tesla Fork3: EVM upgrade
+	// enables instructions, precompiles and gas rules of ethereum Istanbul, Berlin and London forks
+	TeslaFork3_MainnetStartNum = math.MaxUint32 // not scheduled yet
+	TeslaFork3_TestnetStartNum = math.MaxUint32 // not scheduled yet
+
+	// Tesla Fork4: double sign slashing
+	// votes sign epoch and round, enables double sign evidence in staking
+	TeslaFork4_MainnetStartNum = math.MaxUint32 // not scheduled yet
+	TeslaFork4_TestnetStartNum = math.MaxUint32 // not scheduled yet
+)
+
+// Chain IDs returned by the CHAINID instruction
+const (
+	MainnetChainID = 82
+	TestnetChainID = 83
 )
 
 // start block number support sys-contract
@@ -47,6 +65,8 @@ var (
 	TeslaStartNum       uint32 = TeslaMainnetStartNum
 
 	TeslaFork2StartNum uint32 = TeslaFork2_MainnetStartNum
+	TeslaFork3StartNum uint32 = TeslaFork3_MainnetStartNum
+	TeslaFork4StartNum uint32 = TeslaFork4_MainnetStartNum
 
 	// Genesis hashes to enforce below configs on.
 	GenesisHash = MustParseBytes32("0x00000000733c970e6a7d68c7db54e3705eee865a97a07bf7e695c63b238f5e52")
@@ -138,6 +158,22 @@ func (p *ChainConfig) IsTeslaFork2(blockNum uint32) bool {
 	return blockNum >= TeslaFork2StartNum
 }
 
+func (p *ChainConfig) IsTeslaFork3(blockNum uint32) bool {
+	return blockNum >= TeslaFork3StartNum
+}
+
+func (p *ChainConfig) IsTeslaFork4(blockNum uint32) bool {
+	return blockNum >= TeslaFork4StartNum
+}
+
+// ChainID returns the ethereum compatible chain ID.
+func (p *ChainConfig) ChainID() *big.Int {
+	if p.IsInitialized() && p.IsMainnet() {
+		return big.NewInt(MainnetChainID)
+	}
+	return big.NewInt(TestnetChainID)
+}
+
 func InitBlockChainConfig(genesisID Bytes32, chainFlag string) {
 	BlockChainConfig.ChainGenesisID = genesisID
 	BlockChainConfig.ChainFlag = chainFlag
@@ -150,11 +186,15 @@ func InitBlockChainConfig(genesisID Bytes32, chainFlag string) {
 		EdisonStartNum = EdisonMainnetStartNum
 		TeslaStartNum = TeslaMainnetStartNum
 		TeslaFork2StartNum = TeslaFork2_MainnetStartNum
+		TeslaFork3StartNum = TeslaFork3_MainnetStartNum
+		TeslaFork4StartNum = TeslaFork4_MainnetStartNum
 	} else {
 		SysContractStartNum = TestnetSysContractStartNum
 		EdisonStartNum = EdisonTestnetStartNum
 		TeslaStartNum = TeslaTestnetStartNum
 		TeslaFork2StartNum = TeslaFork2_TestnetStartNum
+		TeslaFork3StartNum = TeslaFork3_TestnetStartNum
+		TeslaFork4StartNum = TeslaFork4_TestnetStartNum
 	}
 }
 
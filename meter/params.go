@@ -6,7 +6,10 @@
 package meter
 
 import (
+	"bytes"
+	"errors"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/params"
@@ -120,6 +123,24 @@ var (
 	// 0x6e73616374696f6e2d6665652d62656e65666963696172792d61646472657373
 	KeyTransactionFeeAddress = BytesToBytes32([]byte("transaction-fee-beneficiary-address"))
 
+	// slashing and jail, counts are plain integers, ratios are in 1e18
+	// unset keys fall back to the defaults in the staking module
+	KeyDoubleSignPoints              = BytesToBytes32([]byte("double-sign-points"))
+	KeyMissingLeaderPoints           = BytesToBytes32([]byte("missing-leader-points"))
+	KeyMissingProposerPoints         = BytesToBytes32([]byte("missing-proposer-points"))
+	KeyMissingVoterPoints            = BytesToBytes32([]byte("missing-voter-points"))
+	KeyPhaseOutEpochCount            = BytesToBytes32([]byte("phase-out-epoch-count"))
+	KeyObservationEpochCount         = BytesToBytes32([]byte("observation-epoch-count"))
+	KeyMaxMissingProposerPerEpoch    = BytesToBytes32([]byte("max-missing-proposer-per-epoch"))
+	KeyMaxMissingLeaderPerEpoch      = BytesToBytes32([]byte("max-missing-leader-per-epoch"))
+	KeyMaxDoubleSignPerEpoch         = BytesToBytes32([]byte("max-double-sign-per-epoch"))
+	KeyJailMissingProposerViolations = BytesToBytes32([]byte("jail-missing-proposer-violations"))
+	KeyJailMissingLeaderViolations   = BytesToBytes32([]byte("jail-missing-leader-violations"))
+	KeyJailDoubleSignViolations      = BytesToBytes32([]byte("jail-double-sign-violations"))
+	KeyBailForExitJail               = BytesToBytes32([]byte("bail-for-exit-jail"))
+	KeyDoubleSignSlashRatio          = BytesToBytes32([]byte("double-sign-slash-ratio"))
+	KeyDoubleSignReporterRatio       = BytesToBytes32([]byte("double-sign-reporter-ratio"))
+
 	// Initial values
 	InitialRewardRatio         = big.NewInt(3e17) // 30%
 	InitialBaseGasPrice        = big.NewInt(5e11) // each tx gas is about 0.01 meter
@@ -143,6 +164,14 @@ var (
 	// 0x61746f722d62656e656669742d61646472657373
 	ValidatorBenefitAddr = BytesToAddress([]byte("validator-benefit-address"))
 
+	// The staking module account, the staking events are emitted from it
+	// 0x616B696e672D6D6F64756c652d61646472657373
+	StakingModuleAddr = BytesToAddress([]byte("staking-module-address"))
+
+	// This account keeps the stake slashed from double signers, less the reward to reporters
+	// 0x6c61736865642d7374616b652d61646472657373
+	SlashedStakeAddr = BytesToAddress([]byte("slashed-stake-address"))
+
 	AuctionLeftOverAccount = MustParseAddress("0xe852f654dfaee0e2b60842657379a56e1cafa292")
 
 	ZeroAddress = MustParseAddress("0x0000000000000000000000000000000000000000")
@@ -160,3 +189,79 @@ var (
 
 	TeslaValidatorBenefitRatio = big.NewInt(1e18)
 )
+
+// ParamKey describes a key of governance params.
+type ParamKey struct {
+	Name string
+	Key  Bytes32
+}
+
+// ParamKeys lists all known keys of governance params.
+var ParamKeys = []ParamKey{
+	{"KeyExecutorAddress", KeyExecutorAddress},
+	{"KeyRewardRatio", KeyRewardRatio},
+	{"KeyBaseGasPrice", KeyBaseGasPrice},
+	{"KeyProposerEndorsement", KeyProposerEndorsement},
+	{"KeyPowPoolCoef", KeyPowPoolCoef},
+	{"KeyPowPoolCoefFadeDays", KeyPowPoolCoefFadeDays},
+	{"KeyPowPoolCoefFadeRate", KeyPowPoolCoefFadeRate},
+	{"KeyValidatorBenefitRatio", KeyValidatorBenefitRatio},
+	{"KeyValidatorBaseReward", KeyValidatorBaseReward},
+	{"KeyAuctionReservedPrice", KeyAuctionReservedPrice},
+	{"KeyMinRequiredByDelegate", KeyMinRequiredByDelegate},
+	{"KeyAuctionInitRelease", KeyAuctionInitRelease},
+	{"KeyBorrowInterestRate", KeyBorrowInterestRate},
+	{"KeyConsensusCommitteeSize", KeyConsensusCommitteeSize},
+	{"KeyConsensusDelegateSize", KeyConsensusDelegateSize},
+	{"KeyNativeMtrERC20Address", KeyNativeMtrERC20Address},
+	{"KeyNativeMtrgERC20Address", KeyNativeMtrgERC20Address},
+	{"KeySystemContractAddress1", KeySystemContractAddress1},
+	{"KeySystemContractAddress2", KeySystemContractAddress2},
+	{"KeySystemContractAddress3", KeySystemContractAddress3},
+	{"KeySystemContractAddress4", KeySystemContractAddress4},
+	{"KeyEnforceTesla1_1Correction", KeyEnforceTesla1_1Correction},
+	{"KeyTransactionFeeAddress", KeyTransactionFeeAddress},
+	{"KeyDoubleSignPoints", KeyDoubleSignPoints},
+	{"KeyMissingLeaderPoints", KeyMissingLeaderPoints},
+	{"KeyMissingProposerPoints", KeyMissingProposerPoints},
+	{"KeyMissingVoterPoints", KeyMissingVoterPoints},
+	{"KeyPhaseOutEpochCount", KeyPhaseOutEpochCount},
+	{"KeyObservationEpochCount", KeyObservationEpochCount},
+	{"KeyMaxMissingProposerPerEpoch", KeyMaxMissingProposerPerEpoch},
+	{"KeyMaxMissingLeaderPerEpoch", KeyMaxMissingLeaderPerEpoch},
+	{"KeyMaxDoubleSignPerEpoch", KeyMaxDoubleSignPerEpoch},
+	{"KeyJailMissingProposerViolations", KeyJailMissingProposerViolations},
+	{"KeyJailMissingLeaderViolations", KeyJailMissingLeaderViolations},
+	{"KeyJailDoubleSignViolations", KeyJailDoubleSignViolations},
+	{"KeyBailForExitJail", KeyBailForExitJail},
+	{"KeyDoubleSignSlashRatio", KeyDoubleSignSlashRatio},
+	{"KeyDoubleSignReporterRatio", KeyDoubleSignReporterRatio},
+}
+
+// ParamKeyName returns the name of the given governance param key. Unknown keys
+// are decoded as text, since all keys are built from short strings.
+func ParamKeyName(key Bytes32) string {
+	for _, k := range ParamKeys {
+		if k.Key == key {
+			return k.Name
+		}
+	}
+	return string(bytes.TrimLeft(key[:], "\x00"))
+}
+
+// ParseParamKey parses a governance param key given by its name (e.g. KeyBaseGasPrice),
+// its text (e.g. base-gas-price) or its hex form.
+func ParseParamKey(s string) (Bytes32, error) {
+	for _, k := range ParamKeys {
+		if k.Name == s {
+			return k.Key, nil
+		}
+	}
+	if strings.HasPrefix(s, "0x") || len(s) == 64 {
+		return ParseBytes32(s)
+	}
+	if len(s) == 0 || len(s) > 32 {
+		return Bytes32{}, errors.New("invalid param key")
+	}
+	return BytesToBytes32([]byte(s)), nil
+}
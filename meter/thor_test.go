@@ -31,3 +31,16 @@ func TestAddress(t *testing.T) {
 	assert.Nil(t, json.Unmarshal(data, &dec))
 	assert.Equal(t, addr, dec)
 }
+
+func TestParamKey(t *testing.T) {
+	assert.Equal(t, "KeyBaseGasPrice", ParamKeyName(KeyBaseGasPrice))
+	assert.Equal(t, "unknown-key", ParamKeyName(BytesToBytes32([]byte("unknown-key"))))
+
+	for _, s := range []string{"KeyBaseGasPrice", "base-gas-price", KeyBaseGasPrice.String()} {
+		key, err := ParseParamKey(s)
+		assert.Nil(t, err)
+		assert.Equal(t, KeyBaseGasPrice, key)
+	}
+	_, err := ParseParamKey("")
+	assert.NotNil(t, err)
+}
@@ -6,12 +6,12 @@
 package packer
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 
 	"github.com/dfinlab/meter/block"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/runtime"
+	"github.com/dfinlab/meter/signer"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/tx"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -123,11 +123,11 @@ func (f *Flow) Adopt(tx *tx.Transaction) error {
 	return nil
 }
 
-// Pack build and sign the new block.
-func (f *Flow) Pack(privateKey *ecdsa.PrivateKey, blockType uint32, lastKBlock uint32) (*block.Block, *state.Stage, tx.Receipts, error) {
-	if f.packer.nodeMaster != meter.Address(crypto.PubkeyToAddress(privateKey.PublicKey)) {
-		fmt.Println("FATAL! pack error from private key mismatch")
-		return nil, nil, nil, errors.New("private key mismatch")
+// Pack build and sign the new block, which is proposed in the given epoch and round.
+func (f *Flow) Pack(s signer.Signer, epoch uint64, round uint32, blockType uint32, lastKBlock uint32) (*block.Block, *state.Stage, tx.Receipts, error) {
+	if f.packer.nodeMaster != meter.Address(crypto.PubkeyToAddress(*s.PublicKey())) {
+		fmt.Println("FATAL! pack error from signer key mismatch")
+		return nil, nil, nil, errors.New("signer key mismatch")
 	}
 
 	if err := f.runtime.Seeker().Err(); err != nil {
@@ -159,7 +159,7 @@ func (f *Flow) Pack(privateKey *ecdsa.PrivateKey, blockType uint32, lastKBlock u
 	}
 	newBlock := builder.Build()
 
-	sig, err := crypto.Sign(newBlock.Header().SigningHash().Bytes(), privateKey)
+	sig, err := s.SignProposal(&signer.Proposal{Epoch: epoch, Round: round, Header: newBlock.Header()})
 	if err != nil {
 		fmt.Println("FATAL! pack error from crypto sign: ", err)
 		return nil, nil, nil, err
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package reward
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// the distributor of the simulated bucket, never a real account
+var estimateAddress = meter.BytesToAddress([]byte("reward-estimate-address"))
+
+// EstimateReward simulates the epoch reward of a new bucket with the votes (wei, the amount plus the bonus
+// votes) for the candidate, the same way as ComputeRewardMapV3, so only the delegates in the committee
+// are rewarded. It assumes the delegates, committee and epoch rewards stay the same.
+// It returns the distributed and autobid parts of the reward.
+func EstimateReward(baseReward, totalRewards *big.Int, delegates []*types.Delegate, committee []*types.Validator, candidate meter.Address, votes *big.Int, autobid uint8) (*big.Int, *big.Int, error) {
+	if votes.Sign() <= 0 {
+		return nil, nil, errors.New("votes must be positive")
+	}
+
+	// same unit of voting power as the delegates from staking
+	votes = new(big.Int).Div(votes, big.NewInt(1e12))
+	if !votes.IsInt64() {
+		return nil, nil, errors.New("votes too large")
+	}
+
+	found := false
+	simulated := make([]*types.Delegate, 0, len(delegates))
+	for _, d := range delegates {
+		if d.Address != candidate {
+			simulated = append(simulated, d)
+			continue
+		}
+		found = true
+
+		// not rewarded if out of the committee
+		inCommittee := false
+		keyBytes := crypto.FromECDSAPub(&d.PubKey)
+		for _, m := range committee {
+			if bytes.Equal(crypto.FromECDSAPub(&m.PubKey), keyBytes) {
+				inCommittee = true
+				break
+			}
+		}
+		if !inCommittee {
+			return big.NewInt(0), big.NewInt(0), nil
+		}
+
+		// the new bucket dilutes the shares of the existing distributors
+		oldPower := big.NewInt(d.VotingPower)
+		newPower := new(big.Int).Add(oldPower, votes)
+		if newPower.Sign() == 0 {
+			return nil, nil, errors.New("amount too small")
+		}
+		distList := make([]*types.Distributor, 0, len(d.DistList)+1)
+		for _, dist := range d.DistList {
+			shares := new(big.Int).Mul(new(big.Int).SetUint64(dist.Shares), oldPower)
+			shares.Div(shares, newPower)
+			distList = append(distList, &types.Distributor{Address: dist.Address, Autobid: dist.Autobid, Shares: shares.Uint64()})
+		}
+		shares := new(big.Int).Mul(votes, big.NewInt(1e09))
+		shares.Div(shares, newPower)
+		distList = append(distList, &types.Distributor{Address: estimateAddress, Autobid: autobid, Shares: shares.Uint64()})
+
+		c := *d
+		c.VotingPower = newPower.Int64()
+		c.DistList = distList
+		simulated = append(simulated, &c)
+	}
+	if !found {
+		return nil, nil, errors.New("candidate is not in the delegate set")
+	}
+
+	rewardMap, err := ComputeRewardMapV3(baseReward, totalRewards, simulated, committee)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, ok := rewardMap[estimateAddress]
+	if !ok {
+		return big.NewInt(0), big.NewInt(0), nil
+	}
+	return info.DistAmount, info.AutobidAmount, nil
+}
@@ -0,0 +1,54 @@
+package reward_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/reward"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateReward(t *testing.T) {
+	delegates := make([]*types.Delegate, 0)
+	committee := make([]*types.Validator, 0)
+	for i, name := range []string{"delegate1", "delegate2", "delegate3"} {
+		key, err := crypto.GenerateKey()
+		require.Nil(t, err)
+		addr := meter.BytesToAddress([]byte(name))
+		delegates = append(delegates, &types.Delegate{Address: addr, PubKey: key.PublicKey, VotingPower: 100, DistList: []*types.Distributor{{Address: addr, Shares: 1e09}}})
+		// delegate3 is out of the committee
+		if i < 2 {
+			committee = append(committee, &types.Validator{Address: addr, PubKey: key.PublicKey})
+		}
+	}
+	addr1, addr3 := delegates[0].Address, delegates[2].Address
+	votes := new(big.Int).Mul(big.NewInt(100), big.NewInt(1e12))
+
+	// the candidate gets 2/3 of the rewards of the committee after staking, and half of it goes to the new bucket
+	dist, autobid, err := reward.EstimateReward(big.NewInt(0), big.NewInt(1000), delegates, committee, addr1, votes, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(333), dist)
+	assert.Equal(t, big.NewInt(0), autobid)
+
+	dist, autobid, err = reward.EstimateReward(big.NewInt(0), big.NewInt(1000), delegates, committee, addr1, votes, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(300), dist)
+	assert.Equal(t, big.NewInt(33), autobid)
+
+	// delegates are not changed
+	assert.Equal(t, int64(100), delegates[0].VotingPower)
+	assert.Equal(t, 1, len(delegates[0].DistList))
+
+	// no reward out of the committee
+	dist, autobid, err = reward.EstimateReward(big.NewInt(0), big.NewInt(1000), delegates, committee, addr3, votes, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, dist.Sign())
+	assert.Equal(t, 0, autobid.Sign())
+
+	_, _, err = reward.EstimateReward(big.NewInt(0), big.NewInt(1000), delegates, committee, meter.Address{}, votes, 0)
+	assert.NotNil(t, err)
+}
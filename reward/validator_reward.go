@@ -217,9 +217,14 @@ func ComputeEpochTotalReward(benefitRatio *big.Int, nDays int, nAuctionPerDay in
 		logger.Error("get summary list failed", "error", err)
 		return big.NewInt(0), err
 	}
-	size := len(summaryList.Summaries)
+	return ComputeEpochTotalRewardBySummaries(summaryList.Summaries, benefitRatio, nDays, nAuctionPerDay), nil
+}
+
+// ComputeEpochTotalRewardBySummaries is ComputeEpochTotalReward with the given auction summaries, the latest last.
+func ComputeEpochTotalRewardBySummaries(summaries []*auction.AuctionSummary, benefitRatio *big.Int, nDays int, nAuctionPerDay int) *big.Int {
+	size := len(summaries)
 	if size == 0 {
-		return big.NewInt(0), nil
+		return big.NewInt(0)
 	}
 	var d, i int
 	if size <= nDays*nAuctionPerDay {
@@ -231,7 +236,7 @@ func ComputeEpochTotalReward(benefitRatio *big.Int, nDays int, nAuctionPerDay in
 	// sumReward = sum(receivedMTR in last NDays)
 	sumReward := big.NewInt(0)
 	for i = 0; i < d; i++ {
-		s := summaryList.Summaries[size-1-i]
+		s := summaries[size-1-i]
 		fmt.Println("Use auction summary: ", s.AuctionID)
 		sumReward.Add(sumReward, s.RcvdMTR)
 	}
@@ -244,7 +249,7 @@ func ComputeEpochTotalReward(benefitRatio *big.Int, nDays int, nAuctionPerDay in
 	epochTotalRewards.Div(epochTotalRewards, big.NewInt(1e18))
 
 	fmt.Println("Epoch total rewards:", epochTotalRewards)
-	return epochTotalRewards, nil
+	return epochTotalRewards
 }
 
 func getSelfDistributor(delegate *types.Delegate) (*types.Distributor, error) {
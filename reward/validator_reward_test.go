@@ -2,9 +2,35 @@ package reward_test
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
+
+	"github.com/dfinlab/meter/reward"
+	"github.com/dfinlab/meter/script/auction"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestReward(t *testing.T) {
 	fmt.Println("YOYO")
 }
+
+func TestComputeEpochTotalRewardBySummaries(t *testing.T) {
+	mtr := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)) }
+	ratio := big.NewInt(4e17) // 40%
+	summaries := []*auction.AuctionSummary{
+		{Sequence: 1, RcvdMTR: mtr(100)},
+		{Sequence: 2, RcvdMTR: mtr(48)},
+	}
+
+	assert.Equal(t, big.NewInt(0), reward.ComputeEpochTotalRewardBySummaries(nil, ratio, 1, 1))
+
+	// 48 * 40% / 24 epochs
+	assert.Equal(t, big.NewInt(8e17), reward.ComputeEpochTotalRewardBySummaries(summaries, ratio, 1, 1))
+
+	// (100 + 48) * 40% / 2 days / 24 epochs
+	expected, _ := new(big.Int).SetString("1233333333333333333", 10)
+	assert.Equal(t, expected, reward.ComputeEpochTotalRewardBySummaries(summaries, ratio, 2, 1))
+
+	// no more than the summaries
+	assert.Equal(t, expected, reward.ComputeEpochTotalRewardBySummaries(summaries, ratio, 2, 3))
+}
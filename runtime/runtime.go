@@ -64,6 +64,19 @@ var chainConfig = params.ChainConfig{
 	Clique:              nil,
 }
 
+// newChainConfig returns the EVM chain config, with Istanbul, Berlin and London
+// forks activated at the Tesla fork3.
+func newChainConfig() *vm.ChainConfig {
+	config := vm.NewChainConfig(&chainConfig)
+	config.ChainID = meter.BlockChainConfig.ChainID()
+
+	fork3 := new(big.Int).SetUint64(uint64(meter.TeslaFork3StartNum))
+	config.IstanbulBlock = fork3
+	config.BerlinBlock = fork3
+	config.LondonBlock = fork3
+	return config
+}
+
 // Output output of clause execution.
 type Output struct {
 	Data            []byte
@@ -96,6 +109,7 @@ type Runtime struct {
 	state      *state.State
 	ctx        *xenv.BlockContext
 	forkConfig meter.ForkConfig
+	baseFee    *big.Int // read on first use, the same for all txs of the block
 }
 
 // New create a Runtime object.
@@ -210,6 +224,15 @@ func (rt *Runtime) SetVMConfig(config vm.Config) *Runtime {
 	return rt
 }
 
+// baseGasPrice returns the base gas price of the block, it's read from the params once per runtime,
+// rather than per clause.
+func (rt *Runtime) baseGasPrice() *big.Int {
+	if rt.baseFee == nil {
+		rt.baseFee = builtin.Params.Native(rt.state).Get(meter.KeyBaseGasPrice)
+	}
+	return rt.baseFee
+}
+
 func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *xenv.TransactionContext) *vm.EVM {
 	var lastNonNativeCallGas uint64
 	return vm.NewEVM(vm.Context{
@@ -396,7 +419,8 @@ func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *x
 		BlockNumber: new(big.Int).SetUint64(uint64(rt.ctx.Number)),
 		Time:        new(big.Int).SetUint64(rt.ctx.Time),
 		Difficulty:  &big.Int{},
-	}, stateDB, &chainConfig, rt.vmConfig)
+		BaseFee:     rt.baseGasPrice(),
+	}, stateDB, newChainConfig(), rt.vmConfig)
 }
 
 // ExecuteClause executes single clause.
@@ -486,6 +510,10 @@ func (rt *Runtime) PrepareClause(
 			return output, false
 		}
 
+		if evm.ChainConfig().IsBerlin(evm.BlockNumber) {
+			stateDB.PrepareAccessList(common.Address(txCtx.Origin), (*common.Address)(clause.To()), evm.ActivePrecompiles())
+		}
+
 		if clause.To() == nil {
 			var caddr common.Address
 			data, caddr, leftOverGas, vmErr = evm.Create(vm.AccountRef(txCtx.Origin), clause.Data(), gas, clause.Value(), clause.Token())
@@ -568,8 +596,13 @@ func (rt *Runtime) PrepareTransaction(tx *tx.Transaction) (*TransactionExecutor,
 			gasUsed = leftOverGas - output.LeftOverGas
 			leftOverGas = output.LeftOverGas
 
-			// Apply refund counter, capped to half of the used gas.
-			refund := gasUsed / 2
+			// Apply refund counter, capped to half of the used gas,
+			// or a fifth since EIP-3529.
+			refundQuotient := uint64(2)
+			if meter.BlockChainConfig.IsTeslaFork3(rt.ctx.Number) {
+				refundQuotient = 5
+			}
+			refund := gasUsed / refundQuotient
 			if refund > output.RefundGas {
 				refund = output.RefundGas
 			}
@@ -23,8 +23,9 @@ var codeSizeCache, _ = lru.New(32 * 1024)
 
 // StateDB implements evm.StateDB, only adapt to evm.
 type StateDB struct {
-	state *state.State
-	repo  *stackedmap.StackedMap
+	state     *state.State
+	repo      *stackedmap.StackedMap
+	originals map[storageKey]common.Hash // storage values before the first write
 }
 
 type (
@@ -34,8 +35,16 @@ type (
 	eventKey       struct{}
 	transferKey    struct{}
 	stateRevKey    struct{}
+
+	accessAddressKey common.Address
+	accessSlotKey    storageKey
 )
 
+type storageKey struct {
+	addr common.Address
+	slot common.Hash
+}
+
 // New create a statedb object.
 func New(state *state.State) *StateDB {
 	getter := func(k interface{}) (interface{}, bool) {
@@ -44,6 +53,8 @@ func New(state *state.State) *StateDB {
 			return false, true
 		case refundKey:
 			return uint64(0), true
+		case accessAddressKey, accessSlotKey:
+			return false, true
 		}
 		panic(fmt.Sprintf("unknown type of key %+v", k))
 	}
@@ -52,6 +63,7 @@ func New(state *state.State) *StateDB {
 	return &StateDB{
 		state,
 		repo,
+		make(map[storageKey]common.Hash),
 	}
 }
 
@@ -288,8 +300,20 @@ func (s *StateDB) GetState(addr common.Address, key common.Hash) common.Hash {
 	return common.Hash(s.state.GetStorage(meter.Address(addr), meter.Bytes32(key)))
 }
 
+// GetCommittedState returns the storage value before it's changed by the StateDB.
+func (s *StateDB) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	if v, ok := s.originals[storageKey{addr, key}]; ok {
+		return v
+	}
+	return s.GetState(addr, key)
+}
+
 // SetState stub.
 func (s *StateDB) SetState(addr common.Address, key, value common.Hash) {
+	k := storageKey{addr, key}
+	if _, ok := s.originals[k]; !ok {
+		s.originals[k] = s.GetState(addr, key)
+	}
 	s.state.SetStorage(meter.Address(addr), meter.Bytes32(key), meter.Bytes32(value))
 }
 
@@ -310,6 +334,16 @@ func (s *StateDB) AddRefund(gas uint64) {
 	s.repo.Put(refundKey{}, total)
 }
 
+// SubRefund stub.
+func (s *StateDB) SubRefund(gas uint64) {
+	v, _ := s.repo.Get(refundKey{})
+	total := v.(uint64)
+	if gas > total {
+		panic(fmt.Sprintf("refund counter below zero (gas: %d > refund: %d)", gas, total))
+	}
+	s.repo.Put(refundKey{}, total-gas)
+}
+
 // AddPreimage stub.
 func (s *StateDB) AddPreimage(hash common.Hash, preimage []byte) {
 	s.repo.Put(preimageKey(hash), preimage)
@@ -341,6 +375,45 @@ func (s *StateDB) RevertToSnapshot(rev int) {
 	}
 }
 
+// AddressInAccessList returns true if the address is in the access list.
+func (s *StateDB) AddressInAccessList(addr common.Address) bool {
+	v, _ := s.repo.Get(accessAddressKey(addr))
+	return v.(bool)
+}
+
+// SlotInAccessList returns true if the (address, slot)-tuple is in the access list.
+func (s *StateDB) SlotInAccessList(addr common.Address, slot common.Hash) bool {
+	v, _ := s.repo.Get(accessSlotKey{addr, slot})
+	return v.(bool)
+}
+
+// AddAddressToAccessList adds the address to the access list, it's reverted with the snapshot.
+func (s *StateDB) AddAddressToAccessList(addr common.Address) {
+	if !s.AddressInAccessList(addr) {
+		s.repo.Put(accessAddressKey(addr), true)
+	}
+}
+
+// AddSlotToAccessList adds the (address, slot)-tuple to the access list, it's reverted with the snapshot.
+func (s *StateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	s.AddAddressToAccessList(addr)
+	if !s.SlotInAccessList(addr, slot) {
+		s.repo.Put(accessSlotKey{addr, slot}, true)
+	}
+}
+
+// PrepareAccessList warms up the origin, the destination and the precompiles
+// before the execution, as described by EIP-2929.
+func (s *StateDB) PrepareAccessList(origin common.Address, dest *common.Address, precompiles []common.Address) {
+	s.AddAddressToAccessList(origin)
+	if dest != nil {
+		s.AddAddressToAccessList(*dest)
+	}
+	for _, addr := range precompiles {
+		s.AddAddressToAccessList(addr)
+	}
+}
+
 func ethlogToEvent(ethlog *types.Log) *tx.Event {
 	var topics []meter.Bytes32
 	if len(ethlog.Topics) > 0 {
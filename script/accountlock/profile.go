@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dfinlab/meter/block"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/state"
 )
@@ -38,6 +39,11 @@ func NewProfile(addr meter.Address, memo []byte, lock uint32, release uint32, mt
 	}
 }
 
+// IsLocked returns whether the profile still restricts transfers at the given epoch.
+func (c *Profile) IsLocked(epoch uint32) bool {
+	return epoch < c.ReleaseEpoch
+}
+
 func (c *Profile) ToString() string {
 	return fmt.Sprintf("Profile(%v) Memo=%v, LockEpoch=%v, ReleaseEpoch=%v, MeterAmount=%v, MeterGovAmount=%v",
 		c.Addr, string(c.Memo), c.LockEpoch, c.ReleaseEpoch, c.MeterAmount.String(), c.MeterGovAmount.String())
@@ -163,6 +169,31 @@ func GetLatestProfileList() (*ProfileList, error) {
 	return list, nil
 }
 
+// api routine interface
+func GetProfileListByHeader(header *block.Header) (*ProfileList, error) {
+	accountlock := GetAccountLockGlobInst()
+	if accountlock == nil {
+		log.Warn("accountlock is not initialized...")
+		err := errors.New("accountlock is not initialized...")
+		return NewProfileList(nil), err
+	}
+
+	h := header
+	if header == nil {
+		h = accountlock.chain.BestBlock().Header()
+	}
+	state, err := accountlock.stateCreator.NewState(h.StateRoot())
+	if err != nil {
+		return NewProfileList(nil), err
+	}
+
+	list := accountlock.GetProfileList(state)
+	if list == nil {
+		return NewProfileList(nil), nil
+	}
+	return list, nil
+}
+
 func RestrictByAccountLock(addr meter.Address, state *state.State) (bool, *big.Int, *big.Int) {
 	accountlock := GetAccountLockGlobInst()
 	if accountlock == nil {
@@ -181,7 +212,7 @@ func RestrictByAccountLock(addr meter.Address, state *state.State) (bool, *big.I
 		return false, nil, nil
 	}
 
-	if accountlock.GetCurrentEpoch() >= p.ReleaseEpoch {
+	if !p.IsLocked(accountlock.GetCurrentEpoch()) {
 		return false, nil, nil
 	}
 
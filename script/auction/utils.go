@@ -2,8 +2,10 @@ package auction
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/dfinlab/meter/block"
+	"github.com/dfinlab/meter/logdb"
 )
 
 //  api routine interface
@@ -72,3 +74,85 @@ func GetAuctionSummaryListByHeader(header *block.Header) (*AuctionSummaryList, e
 	}
 	return summaryList, nil
 }
+
+// GetClosedAuctionSummary returns the summary of the auction closed in the given block,
+// nil if the block did not close any auction.
+func GetClosedAuctionSummary(header *block.Header) (*AuctionSummary, error) {
+	auction := GetAuctionGlobInst()
+	if auction == nil {
+		// auction module is not started yet
+		return nil, nil
+	}
+
+	// auctions are only closed by kblock
+	if header.BlockType() != block.BLOCK_TYPE_K_BLOCK || header.Number() == 0 {
+		return nil, nil
+	}
+
+	state, err := auction.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	list := auction.GetSummaryList(state)
+	if list == nil || list.Last() == nil {
+		return nil, nil
+	}
+
+	parent, err := auction.chain.GetBlockHeader(header.ParentID())
+	if err != nil {
+		return nil, err
+	}
+	parentState, err := auction.stateCreator.NewState(parent.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	parentList := auction.GetSummaryList(parentState)
+	if parentList != nil && parentList.Last() != nil && parentList.Last().AuctionID == list.Last().AuctionID {
+		return nil, nil
+	}
+	return list.Last(), nil
+}
+
+// IndexClosedAuction adds the auction closed in the given block, if any, to the logdb batch,
+// so the summary is still available after it is dropped from the summary list.
+func IndexClosedAuction(batch *logdb.BlockBatch, header *block.Header) error {
+	s, err := GetClosedAuctionSummary(header)
+	if err != nil || s == nil {
+		return err
+	}
+
+	txs := make([]*logdb.AuctionTx, 0, len(s.AuctionTxs))
+	for i, t := range s.AuctionTxs {
+		dist := new(big.Int)
+		// MTRG is distributed in the same order as auction txs
+		if i < len(s.DistMTRG) && s.DistMTRG[i].Addr == t.Address {
+			dist = s.DistMTRG[i].Amount
+		}
+		txs = append(txs, &logdb.AuctionTx{
+			TxID:      t.TxID,
+			Bidder:    t.Address,
+			Amount:    t.Amount,
+			Type:      t.Type,
+			Timestamp: t.Timestamp,
+			Nonce:     t.Nonce,
+			DistMTRG:  dist,
+		})
+	}
+	batch.InsertAuctionSummary(&logdb.AuctionSummary{
+		AuctionID:    s.AuctionID,
+		StartHeight:  s.StartHeight,
+		StartEpoch:   s.StartEpoch,
+		EndHeight:    s.EndHeight,
+		EndEpoch:     s.EndEpoch,
+		Sequence:     s.Sequence,
+		RlsdMTRG:     s.RlsdMTRG,
+		RsvdMTRG:     s.RsvdMTRG,
+		RsvdPrice:    s.RsvdPrice,
+		CreateTime:   s.CreateTime,
+		RcvdMTR:      s.RcvdMTR,
+		ActualPrice:  s.ActualPrice,
+		LeftoverMTRG: s.LeftoverMTRG,
+		Txs:          txs,
+	})
+	return nil
+}
@@ -16,6 +16,13 @@ import (
 	"time"
 
 	"github.com/dfinlab/meter/meter"
+	setypes "github.com/dfinlab/meter/script/types"
+)
+
+// the events of delegates put into and released from jail, logdb indexes the jail history from them
+var (
+	jailedEvent, _   = setypes.ScriptEngine.ABI.EventByName("Jailed")
+	unjailedEvent, _ = setypes.ScriptEngine.ABI.EventByName("Unjailed")
 )
 
 // Candidate indicates the structure of a candidate
@@ -166,3 +173,25 @@ func GetLatestInJailList() (*DelegateInJailList, error) {
 	JailList := staking.GetInJailList(state)
 	return JailList, nil
 }
+
+
+// emitJailEvent emits the event of the delegate put into or released from jail, since tesla fork4.
+func emitJailEvent(env *StakingEnv, jailed bool, d *DelegateJailed) {
+	if !meter.BlockChainConfig.IsTeslaFork4(env.GetTxCtx().BlockRef.Number()) {
+		return
+	}
+	event := jailedEvent
+	if !jailed {
+		event = unjailedEvent
+	}
+	topics := []meter.Bytes32{
+		meter.Bytes32(event.ID()),
+		meter.BytesToBytes32(d.Addr.Bytes()),
+	}
+	data, err := event.Encode(d.Name, new(big.Int).SetUint64(d.TotalPts), d.BailAmount, new(big.Int).SetUint64(d.JailedTime))
+	if err != nil {
+		log.Warn("could not encode data for jail event", "address", d.Addr, "err", err)
+		return
+	}
+	env.AddEvent(StakingModuleAddr, topics, data)
+}
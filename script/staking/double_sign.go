@@ -0,0 +1,341 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package staking
+
+import (
+	"bytes"
+	b64 "encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/state"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errInvalidEvidence = errors.New("invalid double sign evidence")
+	errEvidenceUsed    = errors.New("double sign evidence already used")
+	errOutOfGas        = errors.New("not enough gas for double sign evidence")
+)
+
+// DoubleSignSlash records the stake slashed from a double signer, the reward to the reporter and
+// the rest sent to meter.SlashedStakeAddr.
+type DoubleSignSlash struct {
+	Addr        meter.Address // the address of the double signer
+	Name        []byte
+	Height      uint64 // height of the conflicting votes
+	Reporter    meter.Address
+	SlashedMTR  *big.Int
+	SlashedMTRG *big.Int
+	RewardMTR   *big.Int // paid to the reporter out of the slashed stake
+	RewardMTRG  *big.Int
+	Timestamp   uint64
+}
+
+// Kept returns the slashed stake less the reward, which is sent to meter.SlashedStakeAddr.
+func (s *DoubleSignSlash) Kept() (mtr, mtrg *big.Int) {
+	return new(big.Int).Sub(s.SlashedMTR, s.RewardMTR), new(big.Int).Sub(s.SlashedMTRG, s.RewardMTRG)
+}
+
+func (s *DoubleSignSlash) ToString() string {
+	keptMTR, keptMTRG := s.Kept()
+	return fmt.Sprintf("DoubleSignSlash(%v) Addr=%v, Height=%v, Reporter=%v, Slashed=%v MTR %v MTRG, Reward=%v MTR %v MTRG, Kept=%v MTR %v MTRG",
+		string(s.Name), s.Addr, s.Height, s.Reporter, s.SlashedMTR, s.SlashedMTRG, s.RewardMTR, s.RewardMTRG, keptMTR, keptMTRG)
+}
+
+type DoubleSignSlashList struct {
+	slashes []*DoubleSignSlash
+}
+
+func NewDoubleSignSlashList(slashes []*DoubleSignSlash) *DoubleSignSlashList {
+	if slashes == nil {
+		slashes = make([]*DoubleSignSlash, 0)
+	}
+	return &DoubleSignSlashList{slashes: slashes}
+}
+
+// Exist checks whether the double signer has been slashed for the height.
+func (l *DoubleSignSlashList) Exist(addr meter.Address, height uint64) bool {
+	for _, s := range l.slashes {
+		if s.Addr == addr && s.Height == height {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *DoubleSignSlashList) Add(s *DoubleSignSlash) {
+	l.slashes = append(l.slashes, s)
+}
+
+func (l *DoubleSignSlashList) Count() int {
+	return len(l.slashes)
+}
+
+func (l *DoubleSignSlashList) ToString() string {
+	if l == nil || len(l.slashes) == 0 {
+		return "DoubleSignSlashList (size:0)"
+	}
+	s := []string{fmt.Sprintf("DoubleSignSlashList (size:%v) {", len(l.slashes))}
+	for i, c := range l.slashes {
+		s = append(s, fmt.Sprintf("  %d.%v", i, c.ToString()))
+	}
+	s = append(s, "}")
+	return strings.Join(s, "\n")
+}
+
+func (l *DoubleSignSlashList) ToList() []DoubleSignSlash {
+	result := make([]DoubleSignSlash, 0)
+	for _, v := range l.slashes {
+		result = append(result, *v)
+	}
+	return result
+}
+
+func (s *Staking) GetDoubleSignSlashList(state *state.State) (result *DoubleSignSlashList) {
+	state.DecodeStorage(StakingModuleAddr, DoubleSignSlashListKey, func(raw []byte) error {
+		slashes := make([]*DoubleSignSlash, 0)
+
+		if len(strings.TrimSpace(string(raw))) >= 0 {
+			err := rlp.Decode(bytes.NewReader(raw), &slashes)
+			if err != nil {
+				if err.Error() == "EOF" && len(raw) == 0 {
+					// EOF is caused by no value, is not error case, so returns with empty slice
+				} else {
+					log.Warn("Error during decoding double sign slash list.", "err", err)
+					return err
+				}
+			}
+		}
+
+		result = NewDoubleSignSlashList(slashes)
+		return nil
+	})
+	return
+}
+
+func (s *Staking) SetDoubleSignSlashList(list *DoubleSignSlashList, state *state.State) {
+	state.EncodeStorage(StakingModuleAddr, DoubleSignSlashListKey, func() ([]byte, error) {
+		return rlp.EncodeToBytes(list.slashes)
+	})
+}
+
+func UnpackBytesToDoubleSignEvidence(b []byte) (*types.DoubleSignEvidence, error) {
+	evidence := &types.DoubleSignEvidence{}
+	if err := rlp.DecodeBytes(b, evidence); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// candidateBlsPubKey extracts the BLS public key from the combo public key of the candidate.
+func candidateBlsPubKey(system *bls.System, comboPubKey []byte) (bls.PublicKey, error) {
+	pubKey := strings.TrimSuffix(string(comboPubKey), "\n")
+	pubKey = strings.TrimSuffix(pubKey, " ")
+	split := strings.Split(pubKey, ":::")
+	if len(split) != 2 {
+		return bls.PublicKey{}, errInvalidPubkey
+	}
+	decoded, err := b64.StdEncoding.DecodeString(split[1])
+	if err != nil {
+		return bls.PublicKey{}, errInvalidPubkey
+	}
+	return system.PubKeyFromBytes(decoded)
+}
+
+// SlashDoubleSigner takes the ratio (1e18 is 100%) of every bucket voted for the candidate,
+// including the self-bonded ones, to the staking module. The bonus votes of the buckets are cut
+// by the same ratio, so the votes still add up to the value and the bonus.
+func (s *Staking) SlashDoubleSigner(cand *Candidate, ratio *big.Int, bucketList *BucketList, stakeholderList *StakeholderList, state *state.State, env *StakingEnv) (slashedMTR, slashedMTRG *big.Int) {
+	slashedMTR, slashedMTRG = new(big.Int), new(big.Int)
+	for _, id := range cand.Buckets {
+		b := bucketList.Get(id)
+		if b == nil {
+			log.Warn("bucket of candidate not found", "candidate", cand.Addr, "bucket", id)
+			continue
+		}
+		amount := new(big.Int).Mul(b.Value, ratio)
+		amount.Div(amount, big.NewInt(1e18))
+		bonus := new(big.Int).Mul(new(big.Int).SetUint64(b.BonusVotes), ratio)
+		bonus.Div(bonus, big.NewInt(1e18))
+		if amount.Sign() == 0 && bonus.Sign() == 0 {
+			continue
+		}
+
+		switch b.Token {
+		case meter.MTR:
+			bounded := state.GetBoundedEnergy(b.Owner)
+			if bounded.Cmp(amount) < 0 {
+				log.Warn("not enough bounded meter for slashing", "account", b.Owner, "amount", amount)
+				amount = bounded
+			}
+			state.SetBoundedEnergy(b.Owner, new(big.Int).Sub(bounded, amount))
+			state.AddEnergy(StakingModuleAddr, amount)
+			slashedMTR.Add(slashedMTR, amount)
+		case meter.MTRG:
+			bounded := state.GetBoundedBalance(b.Owner)
+			if bounded.Cmp(amount) < 0 {
+				log.Warn("not enough bounded meter-gov for slashing", "account", b.Owner, "amount", amount)
+				amount = bounded
+			}
+			state.SetBoundedBalance(b.Owner, new(big.Int).Sub(bounded, amount))
+			state.AddBalance(StakingModuleAddr, amount)
+			slashedMTRG.Add(slashedMTRG, amount)
+		default:
+			continue
+		}
+		if amount.Sign() > 0 {
+			env.AddTransfer(b.Owner, StakingModuleAddr, amount, b.Token)
+		}
+
+		votes := new(big.Int).Add(amount, bonus)
+		b.Value = new(big.Int).Sub(b.Value, amount)
+		b.BonusVotes -= bonus.Uint64()
+		b.TotalVotes = new(big.Int).Sub(b.TotalVotes, votes)
+		cand.TotalVotes = new(big.Int).Sub(cand.TotalVotes, votes)
+		if holder := stakeholderList.Get(b.Owner); holder != nil {
+			holder.TotalStake = new(big.Int).Sub(holder.TotalStake, amount)
+		}
+	}
+	return
+}
+
+// paySlashed pays the slashed stake from the staking module to the account.
+func (s *Staking) paySlashed(to meter.Address, amount *big.Int, token byte, state *state.State, env *StakingEnv) {
+	if amount.Sign() == 0 {
+		return
+	}
+	switch token {
+	case meter.MTR:
+		state.SubEnergy(StakingModuleAddr, amount)
+		state.AddEnergy(to, amount)
+	case meter.MTRG:
+		state.SubBalance(StakingModuleAddr, amount)
+		state.AddBalance(to, amount)
+	}
+	env.AddTransfer(StakingModuleAddr, to, amount, token)
+}
+
+// DoubleSignEvidenceHandler is open to anyone. The evidence in extra data proves that the candidate
+// signed votes for two different blocks in the same epoch, round and height. The candidate is slashed
+// and jailed, part of the slashed stake goes to the reporter and the rest to meter.SlashedStakeAddr.
+func (sb *StakingBody) DoubleSignEvidenceHandler(env *StakingEnv, gas uint64) (leftOverGas uint64, err error) {
+	var ret []byte
+	defer func() {
+		if err != nil {
+			ret = []byte(err.Error())
+		}
+		env.SetReturnData(ret)
+	}()
+
+	// verifying the evidence takes two pairings
+	if gas < meter.ClauseGas+DOUBLE_SIGN_EVIDENCE_GAS {
+		leftOverGas = 0
+		err = errOutOfGas
+		return
+	}
+	leftOverGas = gas - meter.ClauseGas - DOUBLE_SIGN_EVIDENCE_GAS
+
+	staking := env.GetStaking()
+	state := env.GetState()
+	candidateList := staking.GetCandidateList(state)
+	bucketList := staking.GetBucketList(state)
+	stakeholderList := staking.GetStakeHolderList(state)
+	inJailList := staking.GetInJailList(state)
+	slashList := staking.GetDoubleSignSlashList(state)
+	params := GetSlashingParams(state)
+
+	evidence, err := UnpackBytesToDoubleSignEvidence(sb.ExtraData)
+	if err != nil {
+		log.Error("decode double sign evidence failed", "error", err)
+		err = errInvalidEvidence
+		return
+	}
+
+	cand := candidateList.Get(sb.CandAddr)
+	if cand == nil {
+		err = errCandidateNotListed
+		log.Error("candidate is not listed", "address", sb.CandAddr)
+		return
+	}
+
+	height := evidence.Height()
+	if slashList.Exist(cand.Addr, height) {
+		err = errEvidenceUsed
+		log.Error("double sign evidence already used", "address", cand.Addr, "height", height)
+		return
+	}
+
+	system, err := types.DefaultBlsSystem()
+	if err != nil {
+		log.Error("could not get BLS system", "error", err)
+		return
+	}
+	pubKey, err := candidateBlsPubKey(system, cand.PubKey)
+	if err != nil {
+		log.Error("could not get BLS public key of candidate", "address", cand.Addr, "error", err)
+		return
+	}
+	defer pubKey.Free()
+
+	if err = evidence.Verify(system, pubKey); err != nil {
+		log.Error("double sign evidence verify failed", "address", cand.Addr, "error", err)
+		err = fmt.Errorf("%v: %v", errInvalidEvidence, err)
+		return
+	}
+
+	// evidence is valid, take actions
+	slashedMTR, slashedMTRG := staking.SlashDoubleSigner(cand, params.DoubleSignSlashRatio, bucketList, stakeholderList, state, env)
+
+	rewardMTR := new(big.Int).Mul(slashedMTR, params.DoubleSignReporterRatio)
+	rewardMTR.Div(rewardMTR, big.NewInt(1e18))
+	rewardMTRG := new(big.Int).Mul(slashedMTRG, params.DoubleSignReporterRatio)
+	rewardMTRG.Div(rewardMTRG, big.NewInt(1e18))
+	staking.paySlashed(sb.HolderAddr, rewardMTR, meter.MTR, state, env)
+	staking.paySlashed(sb.HolderAddr, rewardMTRG, meter.MTRG, state, env)
+
+	slash := &DoubleSignSlash{
+		Addr:        cand.Addr,
+		Name:        cand.Name,
+		Height:      height,
+		Reporter:    sb.HolderAddr,
+		SlashedMTR:  slashedMTR,
+		SlashedMTRG: slashedMTRG,
+		RewardMTR:   rewardMTR,
+		RewardMTRG:  rewardMTRG,
+		Timestamp:   sb.Timestamp,
+	}
+	keptMTR, keptMTRG := slash.Kept()
+	staking.paySlashed(meter.SlashedStakeAddr, keptMTR, meter.MTR, state, env)
+	staking.paySlashed(meter.SlashedStakeAddr, keptMTRG, meter.MTRG, state, env)
+	slashList.Add(slash)
+
+	if !inJailList.Exist(cand.Addr) {
+		infraction := Infraction{
+			DoubleSigners: DoubleSigner{
+				Counter: 1,
+				Info:    []*DoubleSignerInfo{&DoubleSignerInfo{Height: uint32(height)}},
+			},
+		}
+		jailed := NewDelegateJailed(cand.Addr, cand.Name, cand.PubKey, params.DoubleSignPts, &infraction, params.BailForExitJail, sb.Timestamp)
+		inJailList.Add(jailed)
+		emitJailEvent(env, true, jailed)
+	}
+
+	log.Info("slashed double signer", "address", cand.Addr, "name", string(cand.Name), "height", height,
+		"slashedMTR", slashedMTR, "slashedMTRG", slashedMTRG, "reporter", sb.HolderAddr)
+	staking.SetCandidateList(candidateList, state)
+	staking.SetBucketList(bucketList, state)
+	staking.SetStakeHolderList(stakeholderList, state)
+	staking.SetInJailList(inJailList, state)
+	staking.SetDoubleSignSlashList(slashList, state)
+	return
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package staking_test
+
+import (
+	b64 "encoding/base64"
+	"math/big"
+	"testing"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/state"
+	"github.com/dfinlab/meter/types"
+	"github.com/dfinlab/meter/xenv"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func e18(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestDoubleSignEvidenceHandler(t *testing.T) {
+	forkNum := meter.TeslaFork4StartNum
+	defer func() { meter.TeslaFork4StartNum = forkNum }()
+	meter.TeslaFork4StartNum = 0
+
+	system, err := types.DefaultBlsSystem()
+	require.Nil(t, err)
+	pubKey, privKey, err := bls.GenKeys(*system)
+	require.Nil(t, err)
+
+	kv, _ := lvldb.NewMem()
+	st, _ := state.New(meter.Bytes32{}, kv)
+	s := &staking.Staking{}
+
+	candAddr := meter.BytesToAddress([]byte("candidate"))
+	reporter := meter.BytesToAddress([]byte("reporter"))
+
+	// 1000 MTRG with 10 bonus votes for the candidate
+	bucket := staking.NewBucket(candAddr, candAddr, e18(1000), meter.MTRG, staking.FOREVER_LOCK, staking.FOREVER_LOCK_RATE, 0, 0, 0)
+	bucket.BonusVotes = e18(10).Uint64()
+	bucket.TotalVotes = new(big.Int).Add(bucket.Value, e18(10))
+	st.SetBoundedBalance(candAddr, e18(1000))
+
+	comboPubKey := "ecdsa:::" + b64.StdEncoding.EncodeToString(system.PubKeyToBytes(pubKey))
+	cand := staking.NewCandidate(candAddr, []byte("cand"), nil, []byte(comboPubKey), nil, 0, 0, 0)
+	cand.AddBucket(bucket)
+	bucketList := s.GetBucketList(st)
+	bucketList.Add(bucket)
+	s.SetBucketList(bucketList, st)
+	s.SetCandidateList(staking.NewCandidateList([]*staking.Candidate{cand}), st)
+
+	sign := func(v *types.SignedVote) {
+		v.Signature = system.SigToBytes(bls.Sign(v.SigningHash(), privKey))
+	}
+	evidence := types.DoubleSignEvidence{
+		Vote1: types.SignedVote{Vote: types.Vote{Epoch: 2, Round: 5, BlockType: 1, Height: 10, BlockID: meter.Bytes32{1}}},
+		Vote2: types.SignedVote{Vote: types.Vote{Epoch: 2, Round: 5, BlockType: 1, Height: 10, BlockID: meter.Bytes32{2}}},
+	}
+	sign(&evidence.Vote1)
+	sign(&evidence.Vote2)
+	extra, err := rlp.EncodeToBytes(&evidence)
+	require.Nil(t, err)
+
+	sb := &staking.StakingBody{
+		Opcode:     staking.OP_DOUBLE_SIGN_EVIDENCE,
+		HolderAddr: reporter,
+		CandAddr:   candAddr,
+		ExtraData:  extra,
+	}
+	env := staking.NewStakingEnv(s, st, &xenv.TransactionContext{Origin: reporter}, &staking.StakingModuleAddr)
+
+	// not enough gas to verify the evidence
+	_, err = sb.DoubleSignEvidenceHandler(env, meter.ClauseGas)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, s.GetDoubleSignSlashList(st).Count())
+
+	leftOverGas, err := sb.DoubleSignEvidenceHandler(env, meter.ClauseGas+staking.DOUBLE_SIGN_EVIDENCE_GAS+1)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), leftOverGas)
+
+	// 5% slashed, 10% of which to the reporter and the rest kept
+	assert.Equal(t, e18(950), st.GetBoundedBalance(candAddr))
+	assert.Equal(t, e18(5), st.GetBalance(reporter))
+	assert.Equal(t, e18(45), st.GetBalance(meter.SlashedStakeAddr))
+	assert.Equal(t, 0, st.GetBalance(staking.StakingModuleAddr).Sign())
+
+	// bonus votes are cut by the same ratio
+	slashed := s.GetBucketList(st).Get(bucket.BucketID)
+	assert.Equal(t, e18(950), slashed.Value)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(95), big.NewInt(1e17)).Uint64(), slashed.BonusVotes)
+	assert.Equal(t, new(big.Int).Add(slashed.Value, new(big.Int).SetUint64(slashed.BonusVotes)), slashed.TotalVotes)
+	assert.Equal(t, slashed.TotalVotes, s.GetCandidateList(st).Get(candAddr).TotalVotes)
+
+	slashes := s.GetDoubleSignSlashList(st).ToList()
+	require.Equal(t, 1, len(slashes))
+	keptMTR, keptMTRG := slashes[0].Kept()
+	assert.Equal(t, 0, keptMTR.Sign())
+	assert.Equal(t, e18(45), keptMTRG)
+	assert.True(t, s.GetInJailList(st).Exist(candAddr))
+
+	// the evidence can't be used twice
+	_, err = sb.DoubleSignEvidenceHandler(env, meter.ClauseGas+staking.DOUBLE_SIGN_EVIDENCE_GAS)
+	assert.NotNil(t, err)
+}
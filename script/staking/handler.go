@@ -988,6 +988,7 @@ func (sb *StakingBody) DelegateStatisticsHandler(env *StakingEnv, gas uint64) (l
 	statisticsList := staking.GetStatisticsList(state)
 	inJailList := staking.GetInJailList(state)
 	phaseOutEpoch := staking.GetStatisticsEpoch(state)
+	params := GetSlashingParams(state)
 
 	log.Debug("in DelegateStatisticsHandler", "phaseOutEpoch", phaseOutEpoch)
 	// handle phase out from the start
@@ -999,7 +1000,7 @@ func (sb *StakingBody) DelegateStatisticsHandler(env *StakingEnv, gas uint64) (l
 			if in := inJailList.Exist(d.Addr); in == true {
 				continue
 			}
-			d.PhaseOut(epoch)
+			d.PhaseOut(epoch, params)
 			if d.TotalPts == 0 {
 				removed = append(removed, d.Addr)
 			}
@@ -1037,16 +1038,16 @@ func (sb *StakingBody) DelegateStatisticsHandler(env *StakingEnv, gas uint64) (l
 	stats := statisticsList.Get(sb.CandAddr)
 	if stats == nil {
 		stats = NewDelegateStatistics(sb.CandAddr, sb.CandName, sb.CandPubKey)
-		stats.Update(IncrInfraction)
+		stats.Update(IncrInfraction, params)
 		statisticsList.Add(stats)
 	} else {
-		stats.Update(IncrInfraction)
+		stats.Update(IncrInfraction, params)
 	}
 
-	proposerViolation := stats.CountMissingProposerViolation(epoch)
-	leaderViolation := stats.CountMissingLeaderViolation(epoch)
-	doubleSignViolation := stats.CountDoubleSignViolation(epoch)
-	jail = proposerViolation >= JailCriteria_MissingProposerViolation || leaderViolation >= JailCriteria_MissingLeaderViolation || doubleSignViolation >= JailCriteria_DoubleSignViolation || (proposerViolation >= 1 && leaderViolation >= 1)
+	proposerViolation := stats.CountMissingProposerViolation(epoch, params)
+	leaderViolation := stats.CountMissingLeaderViolation(epoch, params)
+	doubleSignViolation := stats.CountDoubleSignViolation(epoch, params)
+	jail = proposerViolation >= params.JailMissingProposerViolation || leaderViolation >= params.JailMissingLeaderViolation || doubleSignViolation >= params.JailDoubleSignViolation || (proposerViolation >= 1 && leaderViolation >= 1)
 	log.Info("delegate violation: ", "missProposer", proposerViolation, "missLeader", leaderViolation, "doubleSign", doubleSignViolation, "jail", jail)
 
 	if jail == true {
@@ -1054,8 +1055,10 @@ func (sb *StakingBody) DelegateStatisticsHandler(env *StakingEnv, gas uint64) (l
 
 		// if this candidate already uncandidate, forgive it
 		if cand := candidateList.Get(stats.Addr); cand != nil {
-			bail := BAIL_FOR_EXIT_JAIL
-			inJailList.Add(NewDelegateJailed(stats.Addr, stats.Name, stats.PubKey, stats.TotalPts, &stats.Infractions, bail, sb.Timestamp))
+			bail := params.BailForExitJail
+			jailed := NewDelegateJailed(stats.Addr, stats.Name, stats.PubKey, stats.TotalPts, &stats.Infractions, bail, sb.Timestamp)
+			inJailList.Add(jailed)
+			emitJailEvent(env, true, jailed)
 		} else {
 			log.Warn("delegate already uncandidated, skip ...", "address", stats.Addr, "name", string(stats.Name))
 		}
@@ -1106,6 +1109,7 @@ func (sb *StakingBody) DelegateExitJailHandler(env *StakingEnv, gas uint64) (lef
 	}
 	inJailList.Remove(jailed.Addr)
 	statisticsList.Remove(jailed.Addr)
+	emitJailEvent(env, false, jailed)
 
 	log.Info("removed from jail list ...", "address", jailed.Addr, "name", jailed.Name)
 	staking.SetInJailList(inJailList, state)
@@ -1131,6 +1135,9 @@ func (sb *StakingBody) DelegateStatisticsFlushHandler(env *StakingEnv, gas uint6
 
 	staking := env.GetStaking()
 	state := env.GetState()
+	for _, jailed := range staking.GetInJailList(state).inJails {
+		emitJailEvent(env, false, jailed)
+	}
 	statisticsList := &StatisticsList{}
 	inJailList := &DelegateInJailList{}
 
@@ -12,6 +12,8 @@ const (
 	TESLA1_1_SELF_VOTE_RATIO  = 100               // max candidate total votes / self votes ratio < 100x in Tesla 1.1
 
 	STAKING_TIMESPAN = uint64(720)
+
+	DOUBLE_SIGN_EVIDENCE_GAS = uint64(100000) // extra gas for verifying the evidence
 )
 
 var (
@@ -23,10 +25,16 @@ var (
 
 	// amount to exit from jail 10 MTRGov
 	BAIL_FOR_EXIT_JAIL *big.Int = new(big.Int).Mul(big.NewInt(int64(10)), big.NewInt(int64(1e18)))
+
+	// slashed from each bucket voted for the double signer 5%
+	DOUBLE_SIGN_SLASH_RATIO *big.Int = big.NewInt(5e16)
+
+	// of the slashed stake paid to the reporter 10%
+	DOUBLE_SIGN_REPORTER_RATIO *big.Int = big.NewInt(1e17)
 )
 
 var (
-	StakingModuleAddr      = meter.BytesToAddress([]byte("staking-module-address")) // 0x616B696e672D6D6F64756c652d61646472657373
+	StakingModuleAddr      = meter.StakingModuleAddr
 	DelegateListKey        = meter.Blake2b([]byte("delegate-list-key"))
 	CandidateListKey       = meter.Blake2b([]byte("candidate-list-key"))
 	StakeHolderListKey     = meter.Blake2b([]byte("stake-holder-list-key"))
@@ -35,6 +43,7 @@ var (
 	StatisticsEpochKey     = meter.Blake2b([]byte("delegate-statistics-epoch-key"))
 	InJailListKey          = meter.Blake2b([]byte("delegate-injail-list-key"))
 	ValidatorRewardListKey = meter.Blake2b([]byte("validator-reward-list-key"))
+	DoubleSignSlashListKey = meter.Blake2b([]byte("double-sign-slash-list-key"))
 )
 
 const (
@@ -50,6 +59,7 @@ const (
 	OP_DELEGATE_STATISTICS  = uint32(101)
 	OP_DELEGATE_EXITJAIL    = uint32(102)
 	OP_FLUSH_ALL_STATISTICS = uint32(103)
+	OP_DOUBLE_SIGN_EVIDENCE = uint32(104)
 
 	OP_GOVERNING = uint32(10001)
 )
@@ -78,6 +88,8 @@ func GetOpName(op uint32) string {
 		return "DelegateExitJail"
 	case OP_FLUSH_ALL_STATISTICS:
 		return "FlushAllStatistics"
+	case OP_DOUBLE_SIGN_EVIDENCE:
+		return "DoubleSignEvidence"
 	case OP_GOVERNING:
 		return "Governing"
 	}
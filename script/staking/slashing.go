@@ -122,14 +122,14 @@ func NewDelegateStatistics(addr meter.Address, name []byte, pubKey []byte) *Dele
 	}
 }
 
-func (ds *DelegateStatistics) PhaseOut(curEpoch uint32) {
-	if curEpoch <= PhaseOutEpochCount {
+func (ds *DelegateStatistics) PhaseOut(curEpoch uint32, params *SlashingParams) {
+	if curEpoch <= params.PhaseOutEpochCount {
 		return
 	}
-	phaseOneEpoch := curEpoch - PhaseOutEpochCount
+	phaseOneEpoch := curEpoch - params.PhaseOutEpochCount
 	var phaseTwoEpoch uint32
-	if curEpoch >= WipeOutEpochCount {
-		phaseTwoEpoch = curEpoch - WipeOutEpochCount
+	if curEpoch >= params.WipeOutEpochCount() {
+		phaseTwoEpoch = curEpoch - params.WipeOutEpochCount()
 	} else {
 		phaseTwoEpoch = 0
 	}
@@ -140,10 +140,10 @@ func (ds *DelegateStatistics) PhaseOut(curEpoch uint32) {
 	for _, info := range ds.Infractions.MissingLeaders.Info {
 		if info.Epoch >= phaseOneEpoch {
 			leaderInfo = append(leaderInfo, info)
-			leaderPts = leaderPts + MissingLeaderPts
+			leaderPts = leaderPts + params.MissingLeaderPts
 		} else if info.Epoch >= phaseTwoEpoch {
 			leaderInfo = append(leaderInfo, info)
-			leaderPts = leaderPts + PhaseOutLeaderPts
+			leaderPts = leaderPts + params.MissingLeaderPts/2
 		}
 	}
 	ds.Infractions.MissingLeaders.Counter = uint32(len(leaderInfo))
@@ -155,10 +155,10 @@ func (ds *DelegateStatistics) PhaseOut(curEpoch uint32) {
 	for _, info := range ds.Infractions.MissingProposers.Info {
 		if info.Epoch >= phaseOneEpoch {
 			proposerInfo = append(proposerInfo, info)
-			proposerPts = proposerPts + MissingProposerPts
+			proposerPts = proposerPts + params.MissingProposerPts
 		} else if info.Epoch >= phaseTwoEpoch {
 			proposerInfo = append(proposerInfo, info)
-			proposerPts = proposerPts + PhaseOutProposerPts
+			proposerPts = proposerPts + params.MissingProposerPts/2
 		}
 	}
 	ds.Infractions.MissingProposers.Counter = uint32(len(proposerInfo))
@@ -170,10 +170,10 @@ func (ds *DelegateStatistics) PhaseOut(curEpoch uint32) {
 	for _, info := range ds.Infractions.MissingVoters.Info {
 		if info.Epoch >= phaseOneEpoch {
 			voterInfo = append(voterInfo, info)
-			voterPts = voterPts + MissingVoterPts
+			voterPts = voterPts + params.MissingVoterPts
 		} else if info.Epoch >= phaseTwoEpoch {
 			voterInfo = append(voterInfo, info)
-			voterPts = voterPts + PhaseOutVoterPts
+			voterPts = voterPts + params.MissingVoterPts/2
 		}
 	}
 	ds.Infractions.MissingVoters.Counter = uint32(len(voterInfo))
@@ -185,10 +185,10 @@ func (ds *DelegateStatistics) PhaseOut(curEpoch uint32) {
 	for _, info := range ds.Infractions.DoubleSigners.Info {
 		if info.Epoch >= phaseOneEpoch {
 			dsignInfo = append(dsignInfo, info)
-			dsignPts = dsignPts + DoubleSignPts
+			dsignPts = dsignPts + params.DoubleSignPts
 		} else if info.Epoch >= phaseTwoEpoch {
 			dsignInfo = append(dsignInfo, info)
-			dsignPts = dsignPts + PhaseOutDoubleSignPts
+			dsignPts = dsignPts + params.DoubleSignPts/2
 		}
 	}
 	ds.Infractions.DoubleSigners.Counter = uint32(len(dsignInfo))
@@ -198,7 +198,7 @@ func (ds *DelegateStatistics) PhaseOut(curEpoch uint32) {
 	return
 }
 
-func (ds *DelegateStatistics) Update(incr *Infraction) {
+func (ds *DelegateStatistics) Update(incr *Infraction, params *SlashingParams) {
 
 	infr := &ds.Infractions
 	infr.MissingLeaders.Info = append(infr.MissingLeaders.Info, incr.MissingLeaders.Info...)
@@ -213,18 +213,18 @@ func (ds *DelegateStatistics) Update(incr *Infraction) {
 	infr.DoubleSigners.Info = append(infr.DoubleSigners.Info, incr.DoubleSigners.Info...)
 	infr.DoubleSigners.Counter = infr.DoubleSigners.Counter + incr.DoubleSigners.Counter
 
-	ds.TotalPts = ds.TotalPts + uint64((incr.MissingLeaders.Counter*MissingLeaderPts)+
-		(incr.MissingProposers.Counter*MissingProposerPts)+(incr.MissingVoters.Counter*MissingVoterPts)+(incr.DoubleSigners.Counter*DoubleSignPts))
+	ds.TotalPts = ds.TotalPts + (uint64(incr.MissingLeaders.Counter) * params.MissingLeaderPts) +
+		(uint64(incr.MissingProposers.Counter) * params.MissingProposerPts) + (uint64(incr.MissingVoters.Counter) * params.MissingVoterPts) + (uint64(incr.DoubleSigners.Counter) * params.DoubleSignPts)
 	// if ds.TotalPts >= JailCriteria {
 	// 	return true
 	// }
 	// return false
 }
 
-func (ds *DelegateStatistics) CountMissingProposerViolation(epoch uint32) int {
+func (ds *DelegateStatistics) CountMissingProposerViolation(epoch uint32, params *SlashingParams) int {
 	counter := make(map[uint32]int)
 	for _, inf := range ds.Infractions.MissingProposers.Info {
-		if inf.Epoch < epoch-NObservationEpochs {
+		if inf.Epoch < epoch-params.NObservationEpochs {
 			continue
 		}
 
@@ -238,17 +238,17 @@ func (ds *DelegateStatistics) CountMissingProposerViolation(epoch uint32) int {
 	nViolations := 0
 	for epoch, count := range counter {
 		fmt.Println("epoch: ", epoch, "  count:", count)
-		if count >= MaxMissingProposerPerEpoch {
+		if count >= params.MaxMissingProposerPerEpoch {
 			nViolations = nViolations + 1
 		}
 	}
 	return nViolations
 }
 
-func (ds *DelegateStatistics) CountMissingLeaderViolation(epoch uint32) int {
+func (ds *DelegateStatistics) CountMissingLeaderViolation(epoch uint32, params *SlashingParams) int {
 	counter := make(map[uint32]int)
 	for _, inf := range ds.Infractions.MissingLeaders.Info {
-		if inf.Epoch < epoch-NObservationEpochs {
+		if inf.Epoch < epoch-params.NObservationEpochs {
 			continue
 		}
 		if _, exist := counter[inf.Epoch]; !exist {
@@ -261,17 +261,17 @@ func (ds *DelegateStatistics) CountMissingLeaderViolation(epoch uint32) int {
 	nViolations := 0
 	for epoch, count := range counter {
 		fmt.Println("epoch: ", epoch, "  count:", count)
-		if count >= MaxMissingLeaderPerEpoch {
+		if count >= params.MaxMissingLeaderPerEpoch {
 			nViolations = nViolations + 1
 		}
 	}
 	return nViolations
 }
 
-func (ds *DelegateStatistics) CountDoubleSignViolation(epoch uint32) int {
+func (ds *DelegateStatistics) CountDoubleSignViolation(epoch uint32, params *SlashingParams) int {
 	counter := make(map[uint32]int)
 	for _, inf := range ds.Infractions.DoubleSigners.Info {
-		if inf.Epoch < epoch-NObservationEpochs {
+		if inf.Epoch < epoch-params.NObservationEpochs {
 			continue
 		}
 		if _, exist := counter[inf.Epoch]; !exist {
@@ -284,7 +284,7 @@ func (ds *DelegateStatistics) CountDoubleSignViolation(epoch uint32) int {
 	nViolations := 0
 	for epoch, count := range counter {
 		fmt.Println("epoch: ", epoch, "  count:", count)
-		if count >= MaxDoubleSignPerEpoch {
+		if count >= params.MaxDoubleSignPerEpoch {
 			nViolations = nViolations + 1
 		}
 	}
@@ -0,0 +1,161 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/state"
+)
+
+// SlashingParams are the slashing and jail params. They are governed by the executor
+// with builtin.Params, and the defaults apply to the keys never set.
+type SlashingParams struct {
+	DoubleSignPts      uint64
+	MissingLeaderPts   uint64
+	MissingProposerPts uint64
+	MissingVoterPts    uint64
+
+	PhaseOutEpochCount uint32 // points are halved after these epochs, and wiped out after twice of them
+	NObservationEpochs uint32 // only the last epochs are used to calculate violation count
+
+	MaxMissingProposerPerEpoch int // violation is raised if reaches these infractions in one epoch
+	MaxMissingLeaderPerEpoch   int
+	MaxDoubleSignPerEpoch      int
+
+	JailMissingProposerViolation int // jailed if reaches these violations
+	JailMissingLeaderViolation   int
+	JailDoubleSignViolation      int
+
+	BailForExitJail         *big.Int
+	DoubleSignSlashRatio    *big.Int // of each bucket voted for the double signer, 1e18 is 100%
+	DoubleSignReporterRatio *big.Int // of the slashed stake paid to the reporter, 1e18 is 100%
+}
+
+// DefaultSlashingParams returns the params before any governance change.
+func DefaultSlashingParams() *SlashingParams {
+	return &SlashingParams{
+		DoubleSignPts:      DoubleSignPts,
+		MissingLeaderPts:   MissingLeaderPts,
+		MissingProposerPts: MissingProposerPts,
+		MissingVoterPts:    MissingVoterPts,
+
+		PhaseOutEpochCount: PhaseOutEpochCount,
+		NObservationEpochs: NObservationEpochs,
+
+		MaxMissingProposerPerEpoch: MaxMissingProposerPerEpoch,
+		MaxMissingLeaderPerEpoch:   MaxMissingLeaderPerEpoch,
+		MaxDoubleSignPerEpoch:      MaxDoubleSignPerEpoch,
+
+		JailMissingProposerViolation: JailCriteria_MissingProposerViolation,
+		JailMissingLeaderViolation:   JailCriteria_MissingLeaderViolation,
+		JailDoubleSignViolation:      JailCriteria_DoubleSignViolation,
+
+		BailForExitJail:         new(big.Int).Set(BAIL_FOR_EXIT_JAIL),
+		DoubleSignSlashRatio:    new(big.Int).Set(DOUBLE_SIGN_SLASH_RATIO),
+		DoubleSignReporterRatio: new(big.Int).Set(DOUBLE_SIGN_REPORTER_RATIO),
+	}
+}
+
+// GetSlashingParams reads the params from builtin.Params, unset ones fall back to the defaults.
+// A param set to zero explicitly is zero, e.g. zero slash ratio disables slashing.
+func GetSlashingParams(state *state.State) *SlashingParams {
+	p := DefaultSlashingParams()
+	native := builtin.Params.Native(state)
+	get := func(key meter.Bytes32) *big.Int {
+		if v, ok := native.Lookup(key); ok && v.Sign() >= 0 {
+			return v
+		}
+		return nil
+	}
+
+	for _, v := range []struct {
+		key meter.Bytes32
+		val *uint64
+	}{
+		{meter.KeyDoubleSignPoints, &p.DoubleSignPts},
+		{meter.KeyMissingLeaderPoints, &p.MissingLeaderPts},
+		{meter.KeyMissingProposerPoints, &p.MissingProposerPts},
+		{meter.KeyMissingVoterPoints, &p.MissingVoterPts},
+	} {
+		if x := get(v.key); x != nil && x.IsUint64() {
+			*v.val = x.Uint64()
+		}
+	}
+
+	for _, v := range []struct {
+		key meter.Bytes32
+		val *uint32
+	}{
+		{meter.KeyPhaseOutEpochCount, &p.PhaseOutEpochCount},
+		{meter.KeyObservationEpochCount, &p.NObservationEpochs},
+	} {
+		if x := get(v.key); x != nil && x.IsUint64() && x.Uint64() <= uint64(^uint32(0)/2) {
+			*v.val = uint32(x.Uint64())
+		}
+	}
+
+	for _, v := range []struct {
+		key meter.Bytes32
+		val *int
+	}{
+		{meter.KeyMaxMissingProposerPerEpoch, &p.MaxMissingProposerPerEpoch},
+		{meter.KeyMaxMissingLeaderPerEpoch, &p.MaxMissingLeaderPerEpoch},
+		{meter.KeyMaxDoubleSignPerEpoch, &p.MaxDoubleSignPerEpoch},
+		{meter.KeyJailMissingProposerViolations, &p.JailMissingProposerViolation},
+		{meter.KeyJailMissingLeaderViolations, &p.JailMissingLeaderViolation},
+		{meter.KeyJailDoubleSignViolations, &p.JailDoubleSignViolation},
+	} {
+		if x := get(v.key); x != nil && x.IsInt64() && x.Int64() <= int64(^uint32(0)>>1) {
+			*v.val = int(x.Int64())
+		}
+	}
+
+	if x := get(meter.KeyBailForExitJail); x != nil {
+		p.BailForExitJail = x
+	}
+	// ratios over 100% are ignored
+	if x := get(meter.KeyDoubleSignSlashRatio); x != nil && x.Cmp(big.NewInt(1e18)) <= 0 {
+		p.DoubleSignSlashRatio = x
+	}
+	if x := get(meter.KeyDoubleSignReporterRatio); x != nil && x.Cmp(big.NewInt(1e18)) <= 0 {
+		p.DoubleSignReporterRatio = x
+	}
+	return p
+}
+
+// WipeOutEpochCount is the epochs after which the infractions no longer count.
+func (p *SlashingParams) WipeOutEpochCount() uint32 {
+	return p.PhaseOutEpochCount * 2
+}
+
+func (p *SlashingParams) ToString() string {
+	return fmt.Sprintf("SlashingParams(Pts: doubleSign=%v, leader=%v, proposer=%v, voter=%v, PhaseOutEpochs=%v, ObservationEpochs=%v, BailForExitJail=%v, DoubleSignSlashRatio=%v)",
+		p.DoubleSignPts, p.MissingLeaderPts, p.MissingProposerPts, p.MissingVoterPts,
+		p.PhaseOutEpochCount, p.NObservationEpochs, p.BailForExitJail, p.DoubleSignSlashRatio)
+}
+
+// GetLatestSlashingParams reads the params at the best block.
+func GetLatestSlashingParams() (*SlashingParams, error) {
+	staking := GetStakingGlobInst()
+	if staking == nil {
+		log.Warn("staking is not initialized...")
+		err := errors.New("staking is not initialized...")
+		return DefaultSlashingParams(), err
+	}
+
+	best := staking.chain.BestBlock()
+	state, err := staking.stateCreator.NewState(best.Header().StateRoot())
+	if err != nil {
+		return DefaultSlashingParams(), err
+	}
+
+	return GetSlashingParams(state), nil
+}
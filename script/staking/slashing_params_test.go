@@ -0,0 +1,79 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package staking_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dfinlab/meter/builtin"
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/script/staking"
+	"github.com/dfinlab/meter/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashingParams(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := state.New(meter.Bytes32{}, kv)
+
+	// unset keys fall back to the defaults
+	assert.Equal(t, staking.DefaultSlashingParams(), staking.GetSlashingParams(st))
+
+	native := builtin.Params.Native(st)
+	native.Set(meter.KeyMissingProposerPoints, big.NewInt(50))
+	native.Set(meter.KeyPhaseOutEpochCount, big.NewInt(8))
+	native.Set(meter.KeyBailForExitJail, big.NewInt(1e18))
+	native.Set(meter.KeyDoubleSignSlashRatio, new(big.Int).Mul(big.NewInt(2), big.NewInt(1e18)))
+
+	params := staking.GetSlashingParams(st)
+	assert.Equal(t, uint64(50), params.MissingProposerPts)
+	assert.Equal(t, uint32(8), params.PhaseOutEpochCount)
+	assert.Equal(t, uint32(16), params.WipeOutEpochCount())
+	assert.Equal(t, big.NewInt(1e18), params.BailForExitJail)
+	// ratio over 100% is ignored
+	assert.Equal(t, staking.DOUBLE_SIGN_SLASH_RATIO, params.DoubleSignSlashRatio)
+	assert.Equal(t, uint64(staking.DoubleSignPts), params.DoubleSignPts)
+
+	stats := staking.NewDelegateStatistics(meter.Address{}, []byte("test"), nil)
+	stats.Update(&staking.Infraction{
+		MissingProposers: staking.MissingProposer{
+			Counter: 2,
+			Info:    []*staking.MissingProposerInfo{{Epoch: 10, Height: 1}, {Epoch: 10, Height: 2}},
+		},
+	}, params)
+	assert.Equal(t, uint64(100), stats.TotalPts)
+
+	// still in full points with the longer phase out
+	stats.PhaseOut(17, params)
+	assert.Equal(t, uint64(100), stats.TotalPts)
+	stats.PhaseOut(19, params)
+	assert.Equal(t, uint64(50), stats.TotalPts)
+}
+
+func TestSlashingParamsZero(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := state.New(meter.Bytes32{}, kv)
+
+	// zero set explicitly is not unset
+	native := builtin.Params.Native(st)
+	native.SetKeepZero(meter.KeyDoubleSignSlashRatio, big.NewInt(0))
+	native.SetKeepZero(meter.KeyMissingVoterPoints, big.NewInt(0))
+	native.SetKeepZero(meter.KeyBailForExitJail, big.NewInt(0))
+
+	params := staking.GetSlashingParams(st)
+	assert.Equal(t, 0, params.DoubleSignSlashRatio.Sign())
+	assert.Equal(t, uint64(0), params.MissingVoterPts)
+	assert.Equal(t, 0, params.BailForExitJail.Sign())
+	// others still fall back to the defaults
+	assert.Equal(t, staking.DOUBLE_SIGN_REPORTER_RATIO, params.DoubleSignReporterRatio)
+	assert.Equal(t, uint64(staking.MissingProposerPts), params.MissingProposerPts)
+
+	// zero set with Set clears the param
+	native.Set(meter.KeyDoubleSignSlashRatio, big.NewInt(0))
+	assert.Equal(t, staking.DOUBLE_SIGN_SLASH_RATIO, staking.GetSlashingParams(st).DoubleSignSlashRatio)
+}
@@ -171,6 +171,16 @@ func (s *Staking) PrepareStakingHandler() (StakingHandler func([]byte, *meter.Ad
 			}
 			leftOverGas, err = sb.DelegateExitJailHandler(senv, gas)
 
+		// anyone can report the evidence, the reward goes to the holder address
+		case OP_DOUBLE_SIGN_EVIDENCE:
+			if !meter.BlockChainConfig.IsTeslaFork4(senv.GetTxCtx().BlockRef.Number()) {
+				return nil, gas, errors.New("double sign evidence is not enabled before tesla fork4")
+			}
+			if senv.GetTxCtx().Origin != sb.HolderAddr {
+				return nil, gas, errors.New("holder address is not the same from transaction")
+			}
+			leftOverGas, err = sb.DoubleSignEvidenceHandler(senv, gas)
+
 		// this API is only for executor
 		case OP_FLUSH_ALL_STATISTICS:
 			executor := meter.BytesToAddress(builtin.Params.Native(state).Get(meter.KeyExecutorAddress).Bytes())
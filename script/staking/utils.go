@@ -11,6 +11,7 @@ import (
 	"math/big"
 	"net"
 
+	"github.com/dfinlab/meter/block"
 	"github.com/dfinlab/meter/meter"
 	"github.com/dfinlab/meter/state"
 	"github.com/dfinlab/meter/types"
@@ -143,6 +144,11 @@ func convertDistList(dist []*Distributor) []*types.Distributor {
 
 //  consensus routine interface
 func GetInternalDelegateList() ([]*types.DelegateIntern, error) {
+	return GetInternalDelegateListByHeader(nil)
+}
+
+// GetInternalDelegateListByHeader returns the delegates at the given block, the best block if header is nil.
+func GetInternalDelegateListByHeader(header *block.Header) ([]*types.DelegateIntern, error) {
 	delegateList := []*types.DelegateIntern{}
 	staking := GetStakingGlobInst()
 	if staking == nil {
@@ -151,8 +157,11 @@ func GetInternalDelegateList() ([]*types.DelegateIntern, error) {
 		return delegateList, err
 	}
 
-	best := staking.chain.BestBlock()
-	state, err := staking.stateCreator.NewState(best.Header().StateRoot())
+	h := header
+	if header == nil {
+		h = staking.chain.BestBlock().Header()
+	}
+	state, err := staking.stateCreator.NewState(h.StateRoot())
 	if err != nil {
 		return delegateList, err
 	}
@@ -69,7 +69,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _compiledScriptengineeventAbi = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\xcf\xb1\x0a\xc2\x40\x10\x04\xd0\x7f\x99\xfa\x2a\x41\x8b\x2b\xfd\x07\xab\x90\xe2\xe4\x56\x08\x9a\xd9\x70\xb7\xab\x86\xe0\xbf\x8b\x12\x8c\x45\xb0\xb7\x1c\x98\x37\x30\xcd\x84\x44\xe5\xd8\xab\x57\xc4\x53\xba\x54\x09\xe8\x38\xb8\x55\xc4\x66\x42\xc7\x2c\x77\xc9\x88\x56\x5c\x02\x98\x7a\x41\x84\xde\x28\x05\x01\x36\x0e\xaf\x98\x72\x2e\x52\x2b\x1e\xe1\x0b\xcc\x5b\xb3\x48\xbd\x3a\x6d\x21\xde\xd1\x36\xdb\xdd\x2f\x62\x7a\x16\xae\x88\xf6\xd3\xd8\xab\x33\x2f\x0d\xb9\x0a\xed\xbd\xf8\xbf\x97\x0e\x3c\xae\x9e\x6a\x9f\x01\x00\x00\xff\xff\x45\x51\x86\xa3\xa9\x01\x00\x00")
+var _compiledScriptengineeventAbi = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x92\x31\x0f\x82\x30\x10\x85\xff\xcb\xcd\x4c\x26\x3a\xb0\xe9\xe8\xe4\x20\x13\x71\x38\xd2\xd3\x14\xcb\x95\xd0\x43\x25\xc4\xff\x6e\x21\x04\x18\x08\x09\x86\xc1\xc1\xa9\xbd\xf4\xbe\xbb\xbe\x97\x17\xd7\x80\x6c\xb9\xca\x6c\xe9\x20\xbc\xa2\x71\x14\x80\xe6\xbc\x14\x5f\xc6\xb5\xbf\x2a\x7a\x91\x82\x50\x8a\xd2\xbf\x30\x66\x04\x21\xd8\x27\x53\x01\x01\x48\x95\x37\x25\x2a\x55\x90\x73\xf0\x0e\x46\x40\x37\xab\x23\xd0\x2f\x60\x19\x90\x52\xb3\x6c\xb6\xbb\x39\x44\xec\x9d\x78\x82\xb8\xf4\x1d\x07\x3f\x53\x0d\x1d\xf4\x20\xbf\xa2\x99\xf8\x85\x24\x45\x86\x6e\x28\xb4\x48\x55\x7b\xf4\x40\x52\x09\xb9\x79\x45\x82\xe6\x64\xbd\x10\xb7\xc8\x89\x04\xb5\xd9\x2f\x37\x30\xf5\x18\xa9\xb3\x1e\xff\x71\xc2\xc5\x63\xdb\xb6\x92\x8d\x3f\x92\x8c\x88\x93\x7f\x36\x56\xc8\x46\xc4\xe9\x74\x3a\x2e\x1f\x85\xf8\x6f\x6b\x39\x04\x00\x00")
 
 func compiledScriptengineeventAbiBytes() ([]byte, error) {
 	return bindataRead(
@@ -8,4 +8,6 @@ pragma solidity 0.4.24;
 contract ScriptEngineEvent {
     event Bound(address indexed owner, uint256 amount, uint256 token);
     event Unbound(address indexed owner, uint256 amount, uint256 token);
+    event Jailed(address indexed delegate, bytes name, uint256 totalPoints, uint256 bailAmount, uint256 jailedTime);
+    event Unjailed(address indexed delegate, bytes name, uint256 totalPoints, uint256 bailAmount, uint256 jailedTime);
 }
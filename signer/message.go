@@ -0,0 +1,108 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dfinlab/meter/block"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errInvalidMessage = errors.New("invalid consensus message")
+	errMessageSender  = errors.New("consensus message not sent by the signer")
+	errMessageType    = errors.New("unknown consensus message type")
+	errVoteMessage    = errors.New("votes must be signed with SignVote")
+)
+
+// msgTypes are the types of the consensus messages signed with ECDSA key, see consensus/messages.go.
+var msgTypes = map[byte]bool{
+	0x01: true, // new committee
+	0x02: true, // announce committee
+	0x03: true, // commit committee
+	0x04: true, // notary announce
+	0x10: true, // pacemaker proposal
+	0x11: true, // pacemaker vote
+	0x12: true, // pacemaker new view
+	0x13: true, // pacemaker query proposal
+}
+
+// numHeaderFields is the number of fields of the common header of consensus messages.
+const numHeaderFields = 7
+
+// Proposal is a block proposed in a round of pacemaker.
+type Proposal struct {
+	Epoch  uint64
+	Round  uint32
+	Header *block.Header
+}
+
+// Message is a consensus message signed with ECDSA key, the common header followed by the other
+// fields of the message excluding the signature.
+type Message struct {
+	Height     uint32
+	Round      uint32
+	Sender     []byte // ecdsa public key of the signer
+	Timestamp  time.Time
+	MsgType    byte
+	MsgSubType byte
+	EpochID    uint64
+	Body       []rlp.RawValue // rlp encoded fields following the header
+}
+
+// Encode returns the rlp encoded message, of which the blake2b hash is signed.
+func (m *Message) Encode() ([]byte, error) {
+	fields := []interface{}{
+		m.Height, m.Round, m.Sender, m.Timestamp, m.MsgType, m.MsgSubType, m.EpochID,
+	}
+	for _, f := range m.Body {
+		fields = append(fields, f)
+	}
+	return rlp.EncodeToBytes(fields)
+}
+
+// decodeMessage decodes the rlp encoded message.
+func decodeMessage(data []byte) (*Message, error) {
+	var fields []rlp.RawValue
+	if err := rlp.DecodeBytes(data, &fields); err != nil || len(fields) < numHeaderFields {
+		return nil, errInvalidMessage
+	}
+	m := &Message{Body: fields[numHeaderFields:]}
+	header := []interface{}{&m.Height, &m.Round, &m.Sender, &m.Timestamp, &m.MsgType, &m.MsgSubType, &m.EpochID}
+	for i, ptr := range header {
+		if err := rlp.DecodeBytes(fields[i], ptr); err != nil {
+			return nil, errInvalidMessage
+		}
+	}
+	return m, nil
+}
+
+// checkMessage makes sure the message is a consensus message sent by the signer itself. Since the
+// encoded message has the 65 bytes public key as the 3rd field, its hash never collides with the
+// signing hash of a block header or a transaction.
+func checkMessage(m *Message, pubKey *ecdsa.PublicKey) error {
+	if !bytes.Equal(m.Sender, crypto.FromECDSAPub(pubKey)) {
+		return errMessageSender
+	}
+	if !msgTypes[m.MsgType] {
+		return errMessageType
+	}
+	return nil
+}
+
+// checkBlsMessage makes sure the BLS signed message is not a vote.
+func checkBlsMessage(msg []byte) error {
+	if strings.HasPrefix(string(msg), "BlockType ") {
+		return errVoteMessage
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/types"
+	"github.com/pkg/errors"
+)
+
+const protectionVersion = 1
+
+// kinds of consensus messages guarded by Protection.
+const (
+	KindProposal = "proposal"
+	KindVote     = "vote"
+)
+
+// ErrConflict is returned when signing a message conflicting with a signed one.
+var ErrConflict = errors.New("conflicts with signed message")
+
+// SignRecord is the last signed consensus message of a kind.
+type SignRecord struct {
+	types.SignSlot
+	Hash meter.Bytes32 `json:"hash"`
+}
+
+// before returns whether r is signed in an earlier slot than other.
+func (r *SignRecord) before(other *SignRecord) bool {
+	return r.SignSlot.Before(other.SignSlot)
+}
+
+type protectionData struct {
+	Version int                    `json:"version"`
+	Records map[string]*SignRecord `json:"records"`
+}
+
+// Protection persists the last signed consensus messages, and refuses to sign conflicting ones.
+// At most one message of each kind is allowed to be signed per slot, and slots never go back.
+type Protection struct {
+	path string
+	lock sync.Mutex
+	data protectionData
+}
+
+// OpenProtection opens the protection records at the path, creates an empty one if not exists.
+func OpenProtection(path string) (*Protection, error) {
+	p := &Protection{
+		path: path,
+		data: protectionData{
+			Version: protectionVersion,
+			Records: make(map[string]*SignRecord),
+		},
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return nil, err
+	}
+	if err := p.merge(content); err != nil {
+		return nil, errors.WithMessage(err, "load sign protection")
+	}
+	return p, nil
+}
+
+// Check checks whether the message is safe to sign, and records it before returning.
+// Signing the same message again is allowed.
+func (p *Protection) Check(kind string, rec *SignRecord) error {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if last := p.data.Records[kind]; last != nil {
+		if rec.before(last) {
+			return errors.WithMessage(ErrConflict, fmt.Sprintf("%v of %+v signed before %+v", kind, rec.SignSlot, last.SignSlot))
+		}
+		if rec.SignSlot == last.SignSlot {
+			if rec.Hash == last.Hash {
+				return nil
+			}
+			return errors.WithMessage(ErrConflict, fmt.Sprintf("%v of %+v already signed", kind, rec.SignSlot))
+		}
+	}
+	copied := *rec
+	p.data.Records[kind] = &copied
+	return p.save()
+}
+
+// Export returns the records in JSON, to migrate a validator to another machine.
+func (p *Protection) Export() ([]byte, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return json.MarshalIndent(&p.data, "", "  ")
+}
+
+// Import merges the exported records, the later one of each kind is kept.
+func (p *Protection) Import(data []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.merge(data); err != nil {
+		return err
+	}
+	return p.save()
+}
+
+func (p *Protection) merge(content []byte) error {
+	var data protectionData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return err
+	}
+	if data.Version != protectionVersion {
+		return fmt.Errorf("unsupported version %v", data.Version)
+	}
+	for kind, rec := range data.Records {
+		if rec == nil {
+			continue
+		}
+		if last := p.data.Records[kind]; last == nil || last.before(rec) {
+			p.data.Records[kind] = rec
+		}
+	}
+	return nil
+}
+
+// save writes the records to a temp file and renames it, so the file is never half written.
+func (p *Protection) save() error {
+	content, err := json.MarshalIndent(&p.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
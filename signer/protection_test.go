@@ -0,0 +1,65 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/signer"
+	"github.com/dfinlab/meter/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "protection")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sign-protection.json")
+
+	p, err := signer.OpenProtection(path)
+	assert.Nil(t, err)
+
+	hash1 := meter.BytesToBytes32([]byte("1"))
+	hash2 := meter.BytesToBytes32([]byte("2"))
+	record := func(epoch uint64, round, height uint32, hash meter.Bytes32) *signer.SignRecord {
+		return &signer.SignRecord{SignSlot: types.SignSlot{Epoch: epoch, Round: round, Height: height}, Hash: hash}
+	}
+	vote := record(1, 5, 10, hash1)
+	assert.Nil(t, p.Check(signer.KindVote, vote))
+	assert.Nil(t, p.Check(signer.KindVote, vote), "same message")
+	assert.Nil(t, p.Check(signer.KindProposal, record(1, 5, 10, hash2)), "other kind")
+
+	conflicts := []*signer.SignRecord{
+		record(1, 5, 10, hash2),
+		record(1, 5, 9, hash1),
+		record(1, 4, 11, hash2),
+		record(0, 9, 11, hash2),
+	}
+	for _, rec := range conflicts {
+		assert.Equal(t, signer.ErrConflict, errors.Cause(p.Check(signer.KindVote, rec)))
+	}
+
+	// survives restart
+	p, err = signer.OpenProtection(path)
+	assert.Nil(t, err)
+	assert.Equal(t, signer.ErrConflict, errors.Cause(p.Check(signer.KindVote, conflicts[0])))
+	assert.Nil(t, p.Check(signer.KindVote, record(1, 6, 10, hash2)), "next round")
+
+	// import keeps the later records
+	data, err := p.Export()
+	assert.Nil(t, err)
+	other, err := signer.OpenProtection(filepath.Join(dir, "other.json"))
+	assert.Nil(t, err)
+	assert.Nil(t, other.Check(signer.KindVote, record(2, 1, 20, hash1)))
+	assert.Nil(t, other.Import(data))
+	assert.Equal(t, signer.ErrConflict, errors.Cause(other.Check(signer.KindVote, record(1, 7, 21, hash1))))
+	assert.Equal(t, signer.ErrConflict, errors.Cause(other.Check(signer.KindProposal, record(1, 5, 10, hash1))), "imported")
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// The remote signer protocol is plain JSON over HTTP:
+//
+//   GET  /public-key  -> publicKeyResponse
+//   POST /sign        signRequest -> signResponse
+//
+// The sign request carries the proposal, vote or message itself rather than its hash, the signer
+// builds the digest to sign.
+//
+// Each request is authenticated by HMAC-SHA256 over the method, path, timestamp and body,
+// keyed with a secret shared by the node and the signer.
+const (
+	publicKeyPath = "/public-key"
+	signPath      = "/sign"
+
+	timestampHeader = "X-Signer-Timestamp"
+	authHeader      = "X-Signer-Auth"
+
+	// requests with timestamp out of the window are rejected, to limit replays
+	maxClockSkew = 30 * time.Second
+)
+
+const (
+	signTypeProposal   = "proposal"
+	signTypeVote       = "vote"
+	signTypeMessage    = "message"
+	signTypeBlsMessage = "blsMessage"
+)
+
+type publicKeyResponse struct {
+	PublicKey    hexutil.Bytes `json:"publicKey"`
+	BlsPublicKey hexutil.Bytes `json:"blsPublicKey"`
+}
+
+type proposalRequest struct {
+	Epoch  uint64        `json:"epoch"`
+	Round  uint32        `json:"round"`
+	Header hexutil.Bytes `json:"header"` // rlp encoded block header
+}
+
+type signRequest struct {
+	Type     string           `json:"type"`
+	Proposal *proposalRequest `json:"proposal,omitempty"`
+	Vote     *types.Vote      `json:"vote,omitempty"`
+	Message  hexutil.Bytes    `json:"message,omitempty"` // rlp encoded consensus message, or the BLS signed message
+}
+
+type signResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+var errUnauthorized = errors.New("unauthorized")
+
+func authCode(secret []byte, method, path string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method))
+	mac.Write([]byte(path))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// setAuth sets the auth headers of the request.
+func setAuth(req *http.Request, secret []byte, body []byte) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(authHeader, authCode(secret, req.Method, req.URL.Path, timestamp, body))
+}
+
+// checkAuth verifies the auth headers of the request.
+func checkAuth(req *http.Request, secret []byte, body []byte) error {
+	timestamp := req.Header.Get(timestampHeader)
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errUnauthorized
+	}
+	if skew := time.Since(time.Unix(sec, 0)); skew > maxClockSkew || skew < -maxClockSkew {
+		return errUnauthorized
+	}
+	want := authCode(secret, req.Method, req.URL.Path, timestamp, body)
+	if !hmac.Equal([]byte(want), []byte(req.Header.Get(authHeader))) {
+		return errUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+)
+
+const remoteTimeout = 5 * time.Second
+
+// Remote signs by requesting an external signer process.
+type Remote struct {
+	url    string
+	secret []byte
+	client *http.Client
+
+	pubKey    *ecdsa.PublicKey
+	blsPubKey []byte
+}
+
+var _ Signer = (*Remote)(nil)
+
+// NewRemote creates a remote signer, and fetches public keys from the signer.
+func NewRemote(url string, secret []byte) (*Remote, error) {
+	r := &Remote{
+		url:    strings.TrimSuffix(url, "/"),
+		secret: secret,
+		client: &http.Client{Timeout: remoteTimeout},
+	}
+
+	var res publicKeyResponse
+	if err := r.request("GET", publicKeyPath, nil, &res); err != nil {
+		return nil, errors.WithMessage(err, "fetch public key")
+	}
+	pubKey, err := crypto.UnmarshalPubkey(res.PublicKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unmarshal public key")
+	}
+	r.pubKey = pubKey
+	r.blsPubKey = res.BlsPublicKey
+	return r, nil
+}
+
+// PublicKey implements Signer.
+func (r *Remote) PublicKey() *ecdsa.PublicKey {
+	return r.pubKey
+}
+
+// BlsPublicKey implements Signer.
+func (r *Remote) BlsPublicKey() []byte {
+	return r.blsPubKey
+}
+
+// SignProposal implements Signer.
+func (r *Remote) SignProposal(p *Proposal) ([]byte, error) {
+	header, err := rlp.EncodeToBytes(p.Header)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := r.sign(&signRequest{
+		Type:     signTypeProposal,
+		Proposal: &proposalRequest{Epoch: p.Epoch, Round: p.Round, Header: header},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkSig(p.Header.SigningHash(), sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignVote implements Signer.
+func (r *Remote) SignVote(v *types.Vote) ([]byte, error) {
+	return r.sign(&signRequest{Type: signTypeVote, Vote: v})
+}
+
+// SignMessage implements Signer.
+func (r *Remote) SignMessage(m *Message) ([]byte, error) {
+	msg, err := m.Encode()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := r.sign(&signRequest{Type: signTypeMessage, Message: msg})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkSig(meter.Blake2b(msg), sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// BlsSignMessage implements Signer.
+func (r *Remote) BlsSignMessage(msg []byte) ([]byte, error) {
+	return r.sign(&signRequest{Type: signTypeBlsMessage, Message: msg})
+}
+
+// checkSig makes sure the signer holds the expected key.
+func (r *Remote) checkSig(hash meter.Bytes32, sig []byte) error {
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != crypto.PubkeyToAddress(*r.pubKey) {
+		return errors.New("signature from unexpected key")
+	}
+	return nil
+}
+
+func (r *Remote) sign(sr *signRequest) ([]byte, error) {
+	var res signResponse
+	if err := r.request("POST", signPath, sr, &res); err != nil {
+		return nil, errors.WithMessage(err, "remote sign")
+	}
+	return res.Signature, nil
+}
+
+func (r *Remote) request(method, path string, reqObj interface{}, resObj interface{}) error {
+	var body []byte
+	if reqObj != nil {
+		data, err := json.Marshal(reqObj)
+		if err != nil {
+			return err
+		}
+		body = data
+	}
+	req, err := http.NewRequest(method, r.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setAuth(req, r.secret, body)
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v %v", res.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, resObj)
+}
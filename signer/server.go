@@ -0,0 +1,96 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/dfinlab/meter/block"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/inconshreveable/log15"
+)
+
+var log = log15.New("pkg", "signer")
+
+// NewServer creates the http handler serving the remote signer protocol with the given signer.
+func NewServer(s Signer, secret []byte) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(publicKeyPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "GET" {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := checkAuth(req, secret, nil); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, &publicKeyResponse{
+			PublicKey:    crypto.FromECDSAPub(s.PublicKey()),
+			BlsPublicKey: s.BlsPublicKey(),
+		})
+	})
+	mux.HandleFunc(signPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := checkAuth(req, secret, body); err != nil {
+			log.Warn("unauthorized sign request", "remote", req.RemoteAddr)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		var sr signRequest
+		if err := json.Unmarshal(body, &sr); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var sig []byte
+		switch {
+		case sr.Type == signTypeProposal && sr.Proposal != nil:
+			var header block.Header
+			if err := rlp.DecodeBytes(sr.Proposal.Header, &header); err != nil {
+				http.Error(w, "invalid header", http.StatusBadRequest)
+				return
+			}
+			sig, err = s.SignProposal(&Proposal{Epoch: sr.Proposal.Epoch, Round: sr.Proposal.Round, Header: &header})
+		case sr.Type == signTypeVote && sr.Vote != nil:
+			sig, err = s.SignVote(sr.Vote)
+		case sr.Type == signTypeMessage:
+			var m *Message
+			if m, err = decodeMessage(sr.Message); err == nil {
+				sig, err = s.SignMessage(m)
+			}
+		case sr.Type == signTypeBlsMessage:
+			sig, err = s.BlsSignMessage(sr.Message)
+		default:
+			http.Error(w, "invalid sign request", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Warn("refused to sign", "type", sr.Type, "err", err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		writeJSON(w, &signResponse{Signature: sig})
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		log.Warn("failed to write response", "err", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package signer abstracts the validator keys, so that blocks and consensus messages can be
+// signed either in process or by an external signer process.
+//
+// The signer never signs a bare hash, it builds the digest from the proposal, vote or message
+// itself, so it always knows what it signs.
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs with the ECDSA and BLS keys of the node master.
+type Signer interface {
+	// PublicKey returns the ECDSA public key.
+	PublicKey() *ecdsa.PublicKey
+	// BlsPublicKey returns the BLS public key in bytes.
+	BlsPublicKey() []byte
+	// SignProposal signs the header of the proposed block with ECDSA key, the signature is in the [R || S || V] format.
+	SignProposal(p *Proposal) ([]byte, error)
+	// SignVote signs the vote with BLS key, and returns the signature in bytes.
+	SignVote(v *types.Vote) ([]byte, error)
+	// SignMessage signs the blake2b hash of the rlp encoded consensus message with ECDSA key, only
+	// the messages sent by the signer itself are signed.
+	SignMessage(m *Message) ([]byte, error)
+	// BlsSignMessage signs the sha256 hash of the consensus message with BLS key, votes are refused.
+	BlsSignMessage(msg []byte) ([]byte, error)
+}
+
+// Local signs with keys held in process memory, proposals and votes are checked against the
+// protection records before signing.
+type Local struct {
+	privKey    *ecdsa.PrivateKey
+	blsPrivKey bls.PrivateKey
+	blsPubKey  bls.PublicKey
+	system     bls.System
+	protection *Protection
+}
+
+var _ Signer = (*Local)(nil)
+
+// NewLocal creates a local signer, protection can be nil if nothing is signed for consensus.
+func NewLocal(privKey *ecdsa.PrivateKey, blsPrivKey bls.PrivateKey, blsPubKey bls.PublicKey, system bls.System, protection *Protection) *Local {
+	return &Local{
+		privKey:    privKey,
+		blsPrivKey: blsPrivKey,
+		blsPubKey:  blsPubKey,
+		system:     system,
+		protection: protection,
+	}
+}
+
+// PublicKey implements Signer.
+func (l *Local) PublicKey() *ecdsa.PublicKey {
+	return &l.privKey.PublicKey
+}
+
+// BlsPublicKey implements Signer.
+func (l *Local) BlsPublicKey() []byte {
+	return l.system.PubKeyToBytes(l.blsPubKey)
+}
+
+// SignProposal implements Signer.
+func (l *Local) SignProposal(p *Proposal) ([]byte, error) {
+	hash := p.Header.SigningHash()
+	rec := &SignRecord{
+		SignSlot: types.SignSlot{Epoch: p.Epoch, Round: p.Round, Height: p.Header.Number()},
+		Hash:     hash,
+	}
+	if err := l.protection.Check(KindProposal, rec); err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash.Bytes(), l.privKey)
+}
+
+// SignVote implements Signer.
+func (l *Local) SignVote(v *types.Vote) ([]byte, error) {
+	hash := v.SigningHash()
+	if err := l.protection.Check(KindVote, &SignRecord{SignSlot: v.Slot(), Hash: hash}); err != nil {
+		return nil, err
+	}
+	return l.blsSign(hash), nil
+}
+
+// SignMessage implements Signer.
+func (l *Local) SignMessage(m *Message) ([]byte, error) {
+	if err := checkMessage(m, &l.privKey.PublicKey); err != nil {
+		return nil, err
+	}
+	msg, err := m.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(meter.Blake2b(msg).Bytes(), l.privKey)
+}
+
+// BlsSignMessage implements Signer.
+func (l *Local) BlsSignMessage(msg []byte) ([]byte, error) {
+	if err := checkBlsMessage(msg); err != nil {
+		return nil, err
+	}
+	return l.blsSign(sha256.Sum256(msg)), nil
+}
+
+func (l *Local) blsSign(hash [32]byte) []byte {
+	sig := bls.Sign(hash, l.blsPrivKey)
+	defer sig.Free()
+	return l.system.SigToBytes(sig)
+}
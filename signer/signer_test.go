@@ -0,0 +1,170 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package signer_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dfinlab/meter/block"
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/signer"
+	"github.com/dfinlab/meter/tx"
+	"github.com/dfinlab/meter/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemote(t *testing.T) {
+	params := bls.GenParamsTypeA(160, 512)
+	pairing := bls.GenPairing(params)
+	system, err := bls.GenSystem(pairing)
+	assert.Nil(t, err)
+	blsPub, blsPriv, err := bls.GenKeys(system)
+	assert.Nil(t, err)
+	privKey, _ := crypto.GenerateKey()
+
+	dir, err := ioutil.TempDir("", "signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	protection, err := signer.OpenProtection(filepath.Join(dir, "sign-protection.json"))
+	assert.Nil(t, err)
+
+	local := signer.NewLocal(privKey, blsPriv, blsPub, system, protection)
+	secret := []byte("secret")
+	srv := httptest.NewServer(signer.NewServer(local, secret))
+	defer srv.Close()
+
+	_, err = signer.NewRemote(srv.URL, []byte("wrong"))
+	assert.NotNil(t, err, "unauthorized")
+
+	remote, err := signer.NewRemote(srv.URL, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, privKey.PublicKey, *remote.PublicKey())
+	assert.Equal(t, local.BlsPublicKey(), remote.BlsPublicKey())
+
+	// proposal
+	header := new(block.Builder).ParentID(meter.Bytes32{1}).Timestamp(10).Build().Header()
+	sig, err := remote.SignProposal(&signer.Proposal{Epoch: 1, Round: 2, Header: header})
+	assert.Nil(t, err)
+	pub, err := crypto.SigToPub(header.SigningHash().Bytes(), sig)
+	assert.Nil(t, err)
+	assert.Equal(t, privKey.PublicKey, *pub)
+
+	// vote
+	vote := &types.Vote{Epoch: 1, Round: 2, Height: 3, BlockType: 2, BlockID: meter.Bytes32{3}}
+	blsSig, err := remote.SignVote(vote)
+	assert.Nil(t, err)
+	s, err := system.SigFromBytes(blsSig)
+	assert.Nil(t, err)
+	assert.True(t, bls.Verify(s, vote.SigningHash(), blsPub))
+
+	// the signer refuses another vote or proposal in the same slot
+	_, err = remote.SignVote(vote)
+	assert.Nil(t, err, "same vote")
+	_, err = remote.SignVote(&types.Vote{Epoch: 1, Round: 2, Height: 3, BlockType: 2, BlockID: meter.Bytes32{4}})
+	assert.NotNil(t, err)
+	other := new(block.Builder).ParentID(meter.Bytes32{1}).Timestamp(11).Build().Header()
+	_, err = remote.SignProposal(&signer.Proposal{Epoch: 1, Round: 2, Header: other})
+	assert.NotNil(t, err)
+
+	// consensus message
+	body, _ := rlp.EncodeToBytes(uint32(1))
+	m := &signer.Message{Height: 3, Round: 2, Sender: crypto.FromECDSAPub(&privKey.PublicKey), MsgType: 0x11, Body: []rlp.RawValue{body}}
+	sig, err = remote.SignMessage(m)
+	assert.Nil(t, err)
+	msg, err := m.Encode()
+	assert.Nil(t, err)
+	pub, err = crypto.SigToPub(meter.Blake2b(msg).Bytes(), sig)
+	assert.Nil(t, err)
+	assert.Equal(t, privKey.PublicKey, *pub)
+
+	blsSig, err = remote.BlsSignMessage([]byte("New Committee Message"))
+	assert.Nil(t, err)
+	s, err = system.SigFromBytes(blsSig)
+	assert.Nil(t, err)
+	assert.True(t, bls.Verify(s, sha256.Sum256([]byte("New Committee Message")), blsPub))
+}
+
+func TestRefuseBlindSign(t *testing.T) {
+	params := bls.GenParamsTypeA(160, 512)
+	pairing := bls.GenPairing(params)
+	system, err := bls.GenSystem(pairing)
+	assert.Nil(t, err)
+	blsPub, blsPriv, err := bls.GenKeys(system)
+	assert.Nil(t, err)
+	privKey, _ := crypto.GenerateKey()
+
+	local := signer.NewLocal(privKey, blsPriv, blsPub, system, nil)
+
+	// only consensus messages sent by the signer itself are signed
+	other, _ := crypto.GenerateKey()
+	_, err = local.SignMessage(&signer.Message{Sender: crypto.FromECDSAPub(&other.PublicKey), MsgType: 0x11})
+	assert.NotNil(t, err)
+	_, err = local.SignMessage(&signer.Message{Sender: crypto.FromECDSAPub(&privKey.PublicKey), MsgType: 0x20})
+	assert.NotNil(t, err)
+
+	// a vote must be signed as a vote
+	vote := &types.Vote{Height: 3, BlockType: 2, BlockID: meter.Bytes32{3}}
+	_, err = local.BlsSignMessage([]byte(vote.SignMsg()))
+	assert.NotNil(t, err)
+}
+
+func TestRefuseTxSigningPayload(t *testing.T) {
+	params := bls.GenParamsTypeA(160, 512)
+	pairing := bls.GenPairing(params)
+	system, err := bls.GenSystem(pairing)
+	assert.Nil(t, err)
+	blsPub, blsPriv, err := bls.GenKeys(system)
+	assert.Nil(t, err)
+	privKey, _ := crypto.GenerateKey()
+
+	secret := []byte("secret")
+	srv := httptest.NewServer(signer.NewServer(signer.NewLocal(privKey, blsPriv, blsPub, system, nil), secret))
+	defer srv.Close()
+
+	// the signing payload of a tx which sends all of the master account to someone else
+	to := meter.BytesToAddress([]byte("to"))
+	trx := new(tx.Builder).ChainTag(1).Expiration(32).Gas(21000).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1e18))).Build()
+	payload, err := rlp.EncodeToBytes([]interface{}{
+		trx.ChainTag(), trx.BlockRef(), trx.Expiration(), trx.Clauses(),
+		trx.GasPriceCoef(), trx.Gas(), trx.DependsOn(), trx.Nonce(), []interface{}{},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, trx.SigningHash(), meter.Blake2b(payload))
+
+	for _, msg := range [][]byte{payload, []byte("not rlp")} {
+		body, _ := json.Marshal(map[string]interface{}{"type": "message", "message": hexutil.Bytes(msg)})
+		req, _ := http.NewRequest("POST", srv.URL+"/sign", bytes.NewReader(body))
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte("POST/sign" + timestamp))
+		mac.Write(body)
+		req.Header.Set("X-Signer-Timestamp", timestamp)
+		req.Header.Set("X-Signer-Auth", hex.EncodeToString(mac.Sum(nil)))
+
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	}
+}
@@ -79,6 +79,20 @@ func saveAccount(trie trieWriter, addr meter.Address, a *Account) error {
 	return trie.TryUpdate(addr[:], data)
 }
 
+// saveSnapshotAccount save account into snapshot diff, the same way as saveAccount.
+func saveSnapshotAccount(diff *snapshotDiff, addrHash meter.Bytes32, a *Account) error {
+	if a.IsEmpty() {
+		diff.accounts[addrHash] = nil
+		return nil
+	}
+	data, err := rlp.EncodeToBytes(a)
+	if err != nil {
+		return err
+	}
+	diff.accounts[addrHash] = data
+	return nil
+}
+
 // loadStorage load storage data for given key.
 func loadStorage(trie trieReader, key meter.Bytes32) (rlp.RawValue, error) {
 	return trie.TryGet(key[:])
@@ -16,6 +16,9 @@ type cachedObject struct {
 	kv   kv.GetPutter
 	data Account
 
+	layer    snapshotLayer // read storage from snapshot if not nil
+	addrHash meter.Bytes32
+
 	cache struct {
 		code        []byte
 		storageTrie trieReader
@@ -34,6 +37,11 @@ func (co *cachedObject) getOrCreateStorageTrie() (trieReader, error) {
 
 	root := meter.BytesToBytes32(co.data.StorageRoot)
 
+	if co.layer != nil {
+		co.cache.storageTrie = &snapshotStorageReader{co.layer, co.addrHash, root, co.kv}
+		return co.cache.storageTrie, nil
+	}
+
 	trie, err := trCache.Get(root, co.kv, false)
 	if err != nil {
 		return nil, err
@@ -12,15 +12,34 @@ import (
 
 // Creator state creator to cut-off kv dependency.
 type Creator struct {
-	kv kv.GetPutter
+	kv        kv.GetPutter
+	snaps     *Snapshots
+	preimages bool
 }
 
 // NewCreator create a new state creator.
 func NewCreator(kv kv.GetPutter) *Creator {
-	return &Creator{kv}
+	return &Creator{kv: kv}
+}
+
+// NewCreatorWithSnapshots create a new state creator, which reads states from
+// the snapshots if the state root is covered.
+func NewCreatorWithSnapshots(kv kv.GetPutter, snaps *Snapshots) *Creator {
+	return &Creator{kv: kv, snaps: snaps}
+}
+
+// EnablePreimages makes the created states record preimages of hashed keys on commit,
+// so that accounts and storage can be iterated with addresses and keys.
+func (c *Creator) EnablePreimages() {
+	c.preimages = true
 }
 
 // NewState create a new state object.
 func (c *Creator) NewState(root meter.Bytes32) (*State, error) {
-	return New(root, c.kv)
+	state, err := newState(root, c.kv, c.snaps)
+	if err != nil {
+		return nil, err
+	}
+	state.preimages = c.preimages
+	return state, nil
 }
@@ -0,0 +1,124 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+
+	"github.com/dfinlab/meter/kv"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/trie"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var preimagePrefix = []byte("preimage") // (prefix, hashed key) -> key
+
+func preimageKey(hash []byte) []byte {
+	return append(append([]byte(nil), preimagePrefix...), hash...)
+}
+
+// loadPreimage returns the preimage of a hashed key of accounts trie or storage tries,
+// or nil if not recorded.
+func loadPreimage(kv kv.Getter, hash meter.Bytes32) ([]byte, error) {
+	v, err := kv.Get(preimageKey(hash[:]))
+	if err == nil {
+		return v, nil
+	}
+	if !kv.IsNotFound(err) {
+		return nil, err
+	}
+	// secure tries of early versions saved preimages with unprefixed keys
+	v, err = kv.Get(hash[:])
+	if err != nil {
+		if kv.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if meter.Blake2b(v) != hash {
+		// it's a trie node
+		return nil, nil
+	}
+	return v, nil
+}
+
+// GetPreimage returns the preimage of a hashed key of accounts trie or storage tries,
+// or nil if not recorded.
+func (s *State) GetPreimage(hash meter.Bytes32) []byte {
+	v, err := loadPreimage(s.kv, hash)
+	if err != nil {
+		s.setError(err)
+		return nil
+	}
+	return v
+}
+
+// RangeAccounts iterates accounts of the initial state in the order of hashed address,
+// starting from the given hashed key, until cb returns false.
+// addr is nil if the preimage of the hashed address is not recorded.
+func (s *State) RangeAccounts(start []byte, cb func(hash meter.Bytes32, addr *meter.Address, acc *Account) bool) error {
+	accountTrie, err := trCache.Get(s.root, s.kv, false)
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(accountTrie.NodeIterator(start))
+	for it.Next() {
+		if bytes.Compare(it.Key, start) < 0 {
+			continue
+		}
+		var acc Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return err
+		}
+		hash := meter.BytesToBytes32(it.Key)
+		var addr *meter.Address
+		preimage, err := loadPreimage(s.kv, hash)
+		if err != nil {
+			return err
+		}
+		if len(preimage) == len(meter.Address{}) {
+			a := meter.BytesToAddress(preimage)
+			addr = &a
+		}
+		if !cb(hash, addr, &acc) {
+			return nil
+		}
+	}
+	return it.Err
+}
+
+// RangeStorage iterates storage of the account in the order of hashed key, starting
+// from the given hashed key, until cb returns false.
+// key is nil if the preimage of the hashed key is not recorded.
+func (s *State) RangeStorage(acc *Account, start []byte, cb func(hash meter.Bytes32, key *meter.Bytes32, value rlp.RawValue) bool) error {
+	if len(acc.StorageRoot) == 0 {
+		return nil
+	}
+	storageTrie, err := trCache.Get(meter.BytesToBytes32(acc.StorageRoot), s.kv, false)
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(storageTrie.NodeIterator(start))
+	for it.Next() {
+		if bytes.Compare(it.Key, start) < 0 {
+			continue
+		}
+		hash := meter.BytesToBytes32(it.Key)
+		var key *meter.Bytes32
+		preimage, err := loadPreimage(s.kv, hash)
+		if err != nil {
+			return err
+		}
+		if len(preimage) == len(meter.Bytes32{}) {
+			k := meter.BytesToBytes32(preimage)
+			key = &k
+		}
+		if !cb(hash, key, it.Value) {
+			return nil
+		}
+	}
+	return it.Err
+}
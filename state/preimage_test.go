@@ -0,0 +1,69 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeWithPreimages(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	addr1 := meter.BytesToAddress([]byte("acc1"))
+	addr2 := meter.BytesToAddress([]byte("acc2"))
+	key := meter.BytesToBytes32([]byte("key"))
+	value := meter.BytesToBytes32([]byte("value"))
+
+	// addr1 committed without preimages
+	st, _ := NewCreator(kv).NewState(meter.Bytes32{})
+	st.SetBalance(addr1, big.NewInt(1))
+	root, err := st.Stage().Commit()
+	assert.Nil(t, err)
+
+	creator := NewCreator(kv)
+	creator.EnablePreimages()
+	st, _ = creator.NewState(root)
+	st.SetBalance(addr2, big.NewInt(2))
+	st.SetStorage(addr2, key, value)
+	root, err = st.Stage().Commit()
+	assert.Nil(t, err)
+
+	st, _ = creator.NewState(root)
+	accounts := make(map[meter.Bytes32]*meter.Address)
+	var storage map[meter.Bytes32]*meter.Bytes32
+	assert.Nil(t, st.RangeAccounts(nil, func(hash meter.Bytes32, addr *meter.Address, acc *Account) bool {
+		accounts[hash] = addr
+		if addr != nil && *addr == addr2 {
+			storage = make(map[meter.Bytes32]*meter.Bytes32)
+			assert.Nil(t, st.RangeStorage(acc, nil, func(hash meter.Bytes32, key *meter.Bytes32, v rlp.RawValue) bool {
+				storage[hash] = key
+				return true
+			}))
+		}
+		return true
+	}))
+
+	assert.Equal(t, 2, len(accounts))
+	assert.Nil(t, accounts[meter.Blake2b(addr1[:])], "preimage not recorded")
+	assert.Equal(t, &addr2, accounts[meter.Blake2b(addr2[:])])
+	assert.Equal(t, map[meter.Bytes32]*meter.Bytes32{meter.Blake2b(key[:]): &key}, storage)
+	assert.Equal(t, addr2.Bytes(), st.GetPreimage(meter.Blake2b(addr2[:])))
+
+	// range from the hashed key of addr2
+	var hashes []meter.Bytes32
+	start := meter.Blake2b(addr2[:])
+	assert.Nil(t, st.RangeAccounts(start[:], func(hash meter.Bytes32, addr *meter.Address, acc *Account) bool {
+		hashes = append(hashes, hash)
+		return false
+	}))
+	assert.Equal(t, []meter.Bytes32{start}, hashes)
+}
@@ -0,0 +1,474 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/dfinlab/meter/kv"
+	"github.com/dfinlab/meter/meter"
+	"github.com/inconshreveable/log15"
+)
+
+var (
+	snapshotRootKey       = []byte("snapshot-root")      // -> root of the disk layer
+	snapshotGeneratorKey  = []byte("snapshot-generator") // -> generator marker, see diskLayer
+	snapshotAccountPrefix = []byte("snapa")              // (prefix, hash(address)) -> account
+	snapshotStoragePrefix = []byte("snaps")              // (prefix, hash(address), hash(key)) -> storage value
+
+	errSnapshotStale    = errors.New("snapshot stale")
+	errSnapshotNotReady = errors.New("snapshot not ready")
+
+	log = log15.New("pkg", "state")
+)
+
+// maxSnapshotDiffLayers is the max number of diff layers kept in memory. Older
+// diff layers are flattened into the disk layer.
+const maxSnapshotDiffLayers = 128
+
+func snapshotAccountKey(addrHash []byte) []byte {
+	return append(append([]byte(nil), snapshotAccountPrefix...), addrHash...)
+}
+
+func snapshotStorageKey(addrHash, keyHash []byte) []byte {
+	return append(append(append([]byte(nil), snapshotStoragePrefix...), addrHash...), keyHash...)
+}
+
+// snapshotLayer is a flat view of accounts trie and storage tries at a state root.
+// Keys are hashed the same way as the secure trie.
+type snapshotLayer interface {
+	Root() meter.Bytes32
+	// Account returns the rlp encoded account, or nil if not exists.
+	Account(addrHash meter.Bytes32) ([]byte, error)
+	// Storage returns the rlp encoded storage value, or nil if not exists.
+	Storage(addrHash, keyHash meter.Bytes32) ([]byte, error)
+}
+
+// diskLayer is the snapshot persisted in kv store.
+type diskLayer struct {
+	kv   kv.GetPutter
+	root meter.Bytes32
+
+	lock sync.RWMutex
+	// genMarker is the hash of the last generated account, nil if generation is done.
+	// While the storage of an account is partly generated, it is the hash of the account
+	// followed by the hash of the last generated storage key.
+	genMarker []byte
+	stale     bool
+}
+
+// splitGenMarker splits the generator marker into the account part and the storage part.
+func splitGenMarker(marker []byte) ([]byte, []byte) {
+	if len(marker) > 32 {
+		return marker[:32], marker[32:]
+	}
+	return marker, nil
+}
+
+// accountGenerated returns whether the account and its storage have been generated.
+func accountGenerated(marker []byte, addrHash meter.Bytes32) bool {
+	if marker == nil {
+		return true
+	}
+	accountMarker, storageMarker := splitGenMarker(marker)
+	if storageMarker != nil {
+		return bytes.Compare(addrHash[:], accountMarker) < 0
+	}
+	return bytes.Compare(addrHash[:], accountMarker) <= 0
+}
+
+// storageGenerated returns whether the storage key of the account has been generated.
+func storageGenerated(marker []byte, addrHash, keyHash meter.Bytes32) bool {
+	if accountGenerated(marker, addrHash) {
+		return true
+	}
+	accountMarker, storageMarker := splitGenMarker(marker)
+	return storageMarker != nil && bytes.Equal(addrHash[:], accountMarker) && bytes.Compare(keyHash[:], storageMarker) <= 0
+}
+
+func (dl *diskLayer) Root() meter.Bytes32 {
+	return dl.root
+}
+
+
+func (dl *diskLayer) get(key []byte) ([]byte, error) {
+	v, err := dl.kv.Get(key)
+	if err != nil {
+		if dl.kv.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func (dl *diskLayer) Account(addrHash meter.Bytes32) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	if dl.stale {
+		return nil, errSnapshotStale
+	}
+	if !accountGenerated(dl.genMarker, addrHash) {
+		return nil, errSnapshotNotReady
+	}
+	return dl.get(snapshotAccountKey(addrHash[:]))
+}
+
+func (dl *diskLayer) Storage(addrHash, keyHash meter.Bytes32) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	if dl.stale {
+		return nil, errSnapshotStale
+	}
+	if !storageGenerated(dl.genMarker, addrHash, keyHash) {
+		return nil, errSnapshotNotReady
+	}
+	return dl.get(snapshotStorageKey(addrHash[:], keyHash[:]))
+}
+
+// snapshotDiff is the changes made by a committed stage.
+type snapshotDiff struct {
+	accounts map[meter.Bytes32][]byte                   // nil value for deleted account
+	storage  map[meter.Bytes32]map[meter.Bytes32][]byte // nil value for deleted storage
+	wiped    map[meter.Bytes32]bool                     // accounts whose previous storage is discarded
+}
+
+func newSnapshotDiff() *snapshotDiff {
+	return &snapshotDiff{
+		accounts: make(map[meter.Bytes32][]byte),
+		storage:  make(map[meter.Bytes32]map[meter.Bytes32][]byte),
+		wiped:    make(map[meter.Bytes32]bool),
+	}
+}
+
+// diffLayer is the in-memory snapshot of a recent state, on top of its parent layer.
+type diffLayer struct {
+	*snapshotDiff
+	root meter.Bytes32
+
+	lock   sync.RWMutex
+	parent snapshotLayer
+	stale  bool
+}
+
+func (dl *diffLayer) Root() meter.Bytes32 {
+	return dl.root
+}
+
+func (dl *diffLayer) getParent() snapshotLayer {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	return dl.parent
+}
+
+func (dl *diffLayer) Account(addrHash meter.Bytes32) ([]byte, error) {
+	dl.lock.RLock()
+	if dl.stale {
+		dl.lock.RUnlock()
+		return nil, errSnapshotStale
+	}
+	if v, ok := dl.accounts[addrHash]; ok {
+		dl.lock.RUnlock()
+		return v, nil
+	}
+	parent := dl.parent
+	dl.lock.RUnlock()
+	return parent.Account(addrHash)
+}
+
+func (dl *diffLayer) Storage(addrHash, keyHash meter.Bytes32) ([]byte, error) {
+	dl.lock.RLock()
+	if dl.stale {
+		dl.lock.RUnlock()
+		return nil, errSnapshotStale
+	}
+	if v, ok := dl.storage[addrHash][keyHash]; ok {
+		dl.lock.RUnlock()
+		return v, nil
+	}
+	if dl.wiped[addrHash] {
+		dl.lock.RUnlock()
+		return nil, nil
+	}
+	parent := dl.parent
+	dl.lock.RUnlock()
+	return parent.Storage(addrHash, keyHash)
+}
+
+// Snapshots maintains the flat snapshot of states, which is made up of a disk layer
+// and diff layers of recent states on top of it.
+// The diff layers of recent blocks, which may be reverted, are kept in memory, and
+// flattened into the disk layer once there are too many of them.
+type Snapshots struct {
+	kv kv.GetPutter
+
+	lock   sync.RWMutex
+	disk   *diskLayer
+	layers map[meter.Bytes32]snapshotLayer
+
+	genStop chan struct{}
+	genDone chan struct{}
+}
+
+// NewSnapshots loads snapshots from kv store. If the persisted snapshot does not match
+// the given state root, the snapshot is regenerated in background.
+func NewSnapshots(kv kv.GetPutter, root meter.Bytes32) *Snapshots {
+	disk := &diskLayer{kv: kv, root: root}
+
+	stored, err := kv.Get(snapshotRootKey)
+	if err == nil && meter.BytesToBytes32(stored) == root {
+		if marker, err := kv.Get(snapshotGeneratorKey); err == nil {
+			disk.genMarker = append([]byte{}, marker...)
+		}
+	} else {
+		// start over
+		disk.genMarker = []byte{}
+	}
+
+	s := &Snapshots{
+		kv:      kv,
+		disk:    disk,
+		layers:  map[meter.Bytes32]snapshotLayer{root: disk},
+		genStop: make(chan struct{}),
+		genDone: make(chan struct{}),
+	}
+	if disk.genMarker == nil {
+		close(s.genDone)
+		return s
+	}
+	if err := s.saveGenerator(kv, root, disk.genMarker); err != nil {
+		log.Warn("failed to save snapshot generator", "err", err)
+	}
+	go s.generate()
+	return s
+}
+
+// layer returns the snapshot layer at the given root, or nil if not found.
+func (s *Snapshots) layer(root meter.Bytes32) snapshotLayer {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.layers[root]
+}
+
+// update puts a diff layer of the state root on top of its parent layer.
+func (s *Snapshots) update(root, parentRoot meter.Bytes32, diff *snapshotDiff) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.layers[root]; ok {
+		return
+	}
+	parent, ok := s.layers[parentRoot]
+	if !ok {
+		// parent is unknown or flattened
+		return
+	}
+	layer := &diffLayer{snapshotDiff: diff, root: root, parent: parent}
+	s.layers[root] = layer
+
+	if err := s.capLocked(layer, maxSnapshotDiffLayers); err != nil {
+		log.Warn("failed to flatten snapshot", "err", err)
+	}
+}
+
+// capLocked flattens the diff layers below the given layer into disk layer, until
+// there are no more than n diff layers.
+func (s *Snapshots) capLocked(top snapshotLayer, n int) error {
+	var chain []*diffLayer
+	for layer := top; ; {
+		diff, ok := layer.(*diffLayer)
+		if !ok {
+			break
+		}
+		chain = append(chain, diff)
+		layer = diff.getParent()
+	}
+	for len(chain) > n {
+		if err := s.flattenLocked(chain[len(chain)-1]); err != nil {
+			return err
+		}
+		chain = chain[:len(chain)-1]
+	}
+	return nil
+}
+
+// flattenLocked writes the bottom diff layer into disk, and drops diff layers not
+// based on it.
+func (s *Snapshots) flattenLocked(bottom *diffLayer) error {
+	old := s.disk
+	old.lock.RLock()
+	marker := old.genMarker
+	old.lock.RUnlock()
+
+	// skip the accounts and storage not generated yet, generator will pick them up
+	accountMarker, storageMarker := splitGenMarker(marker)
+	batch := s.kv.NewBatch()
+	for addrHash := range bottom.wiped {
+		// the partly generated storage is wiped as well
+		partly := storageMarker != nil && bytes.Equal(addrHash[:], accountMarker)
+		if !accountGenerated(marker, addrHash) && !partly {
+			continue
+		}
+		if err := s.deleteStorage(batch, addrHash); err != nil {
+			return err
+		}
+	}
+	for addrHash, v := range bottom.accounts {
+		if !accountGenerated(marker, addrHash) {
+			continue
+		}
+		if len(v) == 0 {
+			if err := batch.Delete(snapshotAccountKey(addrHash[:])); err != nil {
+				return err
+			}
+		} else if err := batch.Put(snapshotAccountKey(addrHash[:]), v); err != nil {
+			return err
+		}
+	}
+	for addrHash, storage := range bottom.storage {
+		for keyHash, v := range storage {
+			if !storageGenerated(marker, addrHash, keyHash) {
+				continue
+			}
+			if len(v) == 0 {
+				if err := batch.Delete(snapshotStorageKey(addrHash[:], keyHash[:])); err != nil {
+					return err
+				}
+			} else if err := batch.Put(snapshotStorageKey(addrHash[:], keyHash[:]), v); err != nil {
+				return err
+			}
+		}
+	}
+	if err := s.saveGenerator(batch, bottom.root, marker); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	disk := &diskLayer{kv: s.kv, root: bottom.root, genMarker: marker}
+	old.lock.Lock()
+	old.stale = true
+	old.lock.Unlock()
+
+	layers := map[meter.Bytes32]snapshotLayer{disk.root: disk}
+	for root, layer := range s.layers {
+		diff, ok := layer.(*diffLayer)
+		if !ok || diff == bottom {
+			continue
+		}
+		diff.lock.Lock()
+		if diff.parent == bottom {
+			diff.parent = disk
+		}
+		diff.lock.Unlock()
+
+		// keep layers on top of the new disk layer
+		base := snapshotLayer(diff)
+		for {
+			d, ok := base.(*diffLayer)
+			if !ok {
+				break
+			}
+			base = d.getParent()
+		}
+		if base == disk {
+			layers[root] = diff
+		} else {
+			diff.lock.Lock()
+			diff.stale = true
+			diff.lock.Unlock()
+		}
+	}
+	bottom.lock.Lock()
+	bottom.stale = true
+	bottom.lock.Unlock()
+
+	s.disk = disk
+	s.layers = layers
+	return nil
+}
+
+func (s *Snapshots) deleteStorage(w kv.Putter, addrHash meter.Bytes32) error {
+	prefix := snapshotStorageKey(addrHash[:], nil)
+	it := s.kv.NewIterator(*kv.NewRangeWithBytesPrefix(prefix))
+	defer it.Release()
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+32 {
+			continue
+		}
+		if err := w.Delete(append([]byte(nil), it.Key()...)); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (s *Snapshots) saveGenerator(w kv.Putter, root meter.Bytes32, marker []byte) error {
+	if err := w.Put(snapshotRootKey, root[:]); err != nil {
+		return err
+	}
+	if marker == nil {
+		return w.Delete(snapshotGeneratorKey)
+	}
+	return w.Put(snapshotGeneratorKey, marker)
+}
+
+// Close stops the generator, and flattens diff layers up to the given root into disk,
+// so that the snapshot can be reused after restart.
+func (s *Snapshots) Close(root meter.Bytes32) error {
+	close(s.genStop)
+	<-s.genDone
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if layer, ok := s.layers[root]; ok {
+		return s.capLocked(layer, 0)
+	}
+	return nil
+}
+
+// snapshotAccountReader reads accounts from snapshot layer, and falls back to the
+// accounts trie if the layer is unavailable.
+type snapshotAccountReader struct {
+	layer snapshotLayer
+	kv    kv.GetPutter
+}
+
+func (r *snapshotAccountReader) TryGet(key []byte) ([]byte, error) {
+	v, err := r.layer.Account(meter.Blake2b(key))
+	if err != errSnapshotStale && err != errSnapshotNotReady {
+		return v, err
+	}
+	trie, err := trCache.Get(r.layer.Root(), r.kv, false)
+	if err != nil {
+		return nil, err
+	}
+	return trie.TryGet(key)
+}
+
+// snapshotStorageReader reads storage of an account from snapshot layer, and falls
+// back to the storage trie if the layer is unavailable.
+type snapshotStorageReader struct {
+	layer    snapshotLayer
+	addrHash meter.Bytes32
+	root     meter.Bytes32 // storage root
+	kv       kv.GetPutter
+}
+
+func (r *snapshotStorageReader) TryGet(key []byte) ([]byte, error) {
+	v, err := r.layer.Storage(r.addrHash, meter.Blake2b(key))
+	if err != errSnapshotStale && err != errSnapshotNotReady {
+		return v, err
+	}
+	trie, err := trCache.Get(r.root, r.kv, false)
+	if err != nil {
+		return nil, err
+	}
+	return trie.TryGet(key)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/dfinlab/meter/kv"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// max number of kv writes per generating step.
+var snapshotGenBatchSize = 10000
+
+// generate builds the disk layer from tries in background.
+// Accounts are generated in the order of hashed address, along with their storage.
+// The progress is persisted, so the generation can be resumed after restart.
+func (s *Snapshots) generate() {
+	defer close(s.genDone)
+
+	start := time.Now()
+	s.lock.RLock()
+	marker := s.disk.genMarker
+	s.lock.RUnlock()
+	if len(marker) == 0 {
+		log.Info("wiping state snapshot")
+		if err := s.wipe(); err != nil {
+			log.Warn("failed to wipe state snapshot", "err", err)
+			return
+		}
+	}
+
+	log.Info("generating state snapshot")
+	for {
+		select {
+		case <-s.genStop:
+			log.Info("state snapshot generation aborted")
+			return
+		default:
+		}
+		done, err := s.generateStep()
+		if err != nil {
+			log.Warn("failed to generate state snapshot", "err", err)
+			return
+		}
+		if done {
+			log.Info("state snapshot generated", "elapsed", common.PrettyDuration(time.Since(start)))
+			return
+		}
+	}
+}
+
+// wipe deletes all snapshot entries.
+func (s *Snapshots) wipe() error {
+	for _, r := range []struct {
+		prefix []byte
+		keyLen int
+	}{
+		{snapshotAccountPrefix, len(snapshotAccountPrefix) + 32},
+		{snapshotStoragePrefix, len(snapshotStoragePrefix) + 64},
+	} {
+		for {
+			select {
+			case <-s.genStop:
+				return nil
+			default:
+			}
+			n, err := s.wipeRange(r.prefix, r.keyLen)
+			if err != nil {
+				return err
+			}
+			if n < snapshotGenBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Snapshots) wipeRange(prefix []byte, keyLen int) (int, error) {
+	batch := s.kv.NewBatch()
+	it := s.kv.NewIterator(*kv.NewRangeWithBytesPrefix(prefix))
+	defer it.Release()
+
+	n := 0
+	for n < snapshotGenBatchSize && it.Next() {
+		// other kinds of keys may share the prefix
+		if len(it.Key()) != keyLen {
+			continue
+		}
+		if err := batch.Delete(append([]byte(nil), it.Key()...)); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	return n, batch.Write()
+}
+
+// generateStep generates a range of accounts after the marker of disk layer, with no more than
+// snapshotGenBatchSize writes. A step may stop partway through the storage of an account, which
+// is resumed by the next step.
+// The snapshot lock is held during the step, so the disk layer stays unchanged.
+func (s *Snapshots) generateStep() (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	disk := s.disk
+	accountTrie, err := trie.NewSecure(disk.root, s.kv, 0)
+	if err != nil {
+		return false, err
+	}
+
+	accountMarker, storageMarker := splitGenMarker(disk.genMarker)
+	batch := s.kv.NewBatch()
+	var marker []byte
+	it := trie.NewIterator(accountTrie.NodeIterator(accountMarker))
+	for batch.Len() < snapshotGenBatchSize && it.Next() {
+		start := storageMarker
+		if !bytes.Equal(it.Key, accountMarker) {
+			// the partly generated account may be deleted since
+			start = nil
+		} else if start == nil {
+			continue
+		}
+		var acc Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return false, err
+		}
+		if len(acc.StorageRoot) > 0 {
+			last, err := s.generateStorage(batch, it.Key, meter.BytesToBytes32(acc.StorageRoot), start)
+			if err != nil {
+				return false, err
+			}
+			if last != nil {
+				marker = append(append([]byte(nil), it.Key...), last...)
+				break
+			}
+		}
+		if err := batch.Put(snapshotAccountKey(it.Key), it.Value); err != nil {
+			return false, err
+		}
+		marker = append([]byte(nil), it.Key...)
+	}
+	if it.Err != nil {
+		return false, it.Err
+	}
+
+	if err := s.saveGenerator(batch, disk.root, marker); err != nil {
+		return false, err
+	}
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+
+	disk.lock.Lock()
+	disk.genMarker = marker
+	disk.lock.Unlock()
+	return marker == nil, nil
+}
+
+// generateStorage puts the storage after start into the batch. If the batch gets full before
+// the end, it returns the hash of the last key put.
+func (s *Snapshots) generateStorage(batch kv.Batch, addrHash []byte, root meter.Bytes32, start []byte) ([]byte, error) {
+	storageTrie, err := trie.NewSecure(root, s.kv, 0)
+	if err != nil {
+		return nil, err
+	}
+	var last []byte
+	it := trie.NewIterator(storageTrie.NodeIterator(start))
+	for it.Next() {
+		if start != nil && bytes.Compare(it.Key, start) <= 0 {
+			continue
+		}
+		if batch.Len() >= snapshotGenBatchSize {
+			return last, nil
+		}
+		if err := batch.Put(snapshotStorageKey(addrHash, it.Key), it.Value); err != nil {
+			return nil, err
+		}
+		last = append([]byte(nil), it.Key...)
+	}
+	return nil, it.Err
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dfinlab/meter/kv"
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	snapAddr1 = meter.BytesToAddress([]byte("acc1"))
+	snapAddr2 = meter.BytesToAddress([]byte("acc2"))
+	snapKey1  = meter.BytesToBytes32([]byte("k1"))
+	snapKey2  = meter.BytesToBytes32([]byte("k2"))
+	snapValue = meter.BytesToBytes32([]byte("v"))
+)
+
+// newSnapshotTest commits the initial state, and generates the snapshot for it.
+func newSnapshotTest(t *testing.T) (kv.GetPutter, *Snapshots, *Creator, meter.Bytes32) {
+	kv, _ := lvldb.NewMem()
+
+	st, _ := New(meter.Bytes32{}, kv)
+	st.SetBalance(snapAddr1, big.NewInt(10))
+	st.SetStorage(snapAddr1, snapKey1, snapValue)
+	st.SetStorage(snapAddr1, snapKey2, snapValue)
+	st.SetBalance(snapAddr2, big.NewInt(20))
+	st.SetStorage(snapAddr2, snapKey1, snapValue)
+	root, err := st.Stage().Commit()
+	assert.Nil(t, err)
+
+	snaps := NewSnapshots(kv, root)
+	<-snaps.genDone
+	return kv, snaps, NewCreatorWithSnapshots(kv, snaps), root
+}
+
+// commitChanges updates balance and storage of acc1, and re-creates acc2.
+func commitChanges(t *testing.T, creator *Creator, root meter.Bytes32) meter.Bytes32 {
+	st, _ := creator.NewState(root)
+	st.SetBalance(snapAddr1, big.NewInt(11))
+	st.SetStorage(snapAddr1, snapKey1, meter.Bytes32{})
+	st.Delete(snapAddr2)
+	st.SetStorage(snapAddr2, snapKey2, snapValue)
+	newRoot, err := st.Stage().Commit()
+	assert.Nil(t, err)
+	return newRoot
+}
+
+func checkChanges(t *testing.T, st *State) {
+	assert.Equal(t, big.NewInt(11), st.GetBalance(snapAddr1))
+	assert.Equal(t, meter.Bytes32{}, st.GetStorage(snapAddr1, snapKey1))
+	assert.Equal(t, snapValue, st.GetStorage(snapAddr1, snapKey2))
+	assert.Equal(t, 0, st.GetBalance(snapAddr2).Sign())
+	assert.Equal(t, meter.Bytes32{}, st.GetStorage(snapAddr2, snapKey1), "wiped storage")
+	assert.Equal(t, snapValue, st.GetStorage(snapAddr2, snapKey2))
+}
+
+func TestSnapshotsGenerate(t *testing.T) {
+	_, snaps, creator, root := newSnapshotTest(t)
+	assert.Nil(t, snaps.disk.genMarker, "generation done")
+
+	st, _ := creator.NewState(root)
+	assert.NotNil(t, st.layer, "covered by disk layer")
+	assert.Equal(t, big.NewInt(10), st.GetBalance(snapAddr1))
+	assert.Equal(t, snapValue, st.GetStorage(snapAddr1, snapKey2))
+	assert.Equal(t, snapValue, st.GetStorage(snapAddr2, snapKey1))
+}
+
+func TestSnapshotsGenerateStorageInSteps(t *testing.T) {
+	batchSize := snapshotGenBatchSize
+	defer func() { snapshotGenBatchSize = batchSize }()
+	snapshotGenBatchSize = 3
+
+	kv, _ := lvldb.NewMem()
+	st, _ := New(meter.Bytes32{}, kv)
+	st.SetBalance(snapAddr1, big.NewInt(10))
+	keys := make([]meter.Bytes32, 0)
+	for i := 1; i <= 10; i++ {
+		key := meter.BytesToBytes32([]byte{byte(i)})
+		st.SetStorage(snapAddr1, key, snapValue)
+		keys = append(keys, key)
+	}
+	root, err := st.Stage().Commit()
+	assert.Nil(t, err)
+
+	snaps := NewSnapshots(kv, root)
+	<-snaps.genDone
+	assert.Nil(t, snaps.disk.genMarker, "generation done")
+	st, _ = NewCreatorWithSnapshots(kv, snaps).NewState(root)
+	assert.IsType(t, &diskLayer{}, st.layer)
+	addrHash := meter.Blake2b(snapAddr1[:])
+	for _, key := range keys {
+		v, err := snaps.disk.Storage(addrHash, meter.Blake2b(key[:]))
+		assert.Nil(t, err)
+		assert.NotNil(t, v)
+		assert.Equal(t, snapValue, st.GetStorage(snapAddr1, key))
+	}
+
+	// the first step stops partway through the storage of the account
+	snaps.disk.genMarker = []byte{}
+	done, err := snaps.generateStep()
+	assert.Nil(t, err)
+	assert.False(t, done)
+	marker := snaps.disk.genMarker
+	assert.Equal(t, 64, len(marker))
+	assert.Equal(t, addrHash[:], marker[:32])
+
+	_, err = snaps.disk.Account(addrHash)
+	assert.Equal(t, errSnapshotNotReady, err, "account not generated until its storage is done")
+	generated := 0
+	for _, key := range keys {
+		if _, err := snaps.disk.Storage(addrHash, meter.Blake2b(key[:])); err == nil {
+			generated++
+		}
+	}
+	assert.Equal(t, snapshotGenBatchSize, generated)
+
+	for !done {
+		done, err = snaps.generateStep()
+		assert.Nil(t, err)
+	}
+	_, err = snaps.disk.Account(addrHash)
+	assert.Nil(t, err)
+}
+
+func TestSnapshotsDiffLayer(t *testing.T) {
+	kv, snaps, creator, root1 := newSnapshotTest(t)
+
+	root2 := commitChanges(t, creator, root1)
+	assert.IsType(t, &diffLayer{}, snaps.layer(root2))
+
+	trieState, _ := New(root2, kv)
+	checkChanges(t, trieState)
+	st, _ := creator.NewState(root2)
+	checkChanges(t, st)
+}
+
+func TestSnapshotsStorageReplaced(t *testing.T) {
+	kv, snaps, creator, root1 := newSnapshotTest(t)
+
+	// acc2 takes over the storage trie of acc1
+	st, _ := creator.NewState(root1)
+	acc := *st.getAccount(snapAddr2)
+	acc.StorageRoot = st.getAccount(snapAddr1).StorageRoot
+	st.updateAccount(snapAddr2, &acc)
+	st.SetStorage(snapAddr2, snapKey1, meter.Bytes32{})
+	root2, err := st.Stage().Commit()
+	assert.Nil(t, err)
+	assert.IsType(t, &diffLayer{}, snaps.layer(root2), "layer created")
+
+	check := func(st *State) {
+		assert.Equal(t, big.NewInt(20), st.GetBalance(snapAddr2))
+		assert.Equal(t, meter.Bytes32{}, st.GetStorage(snapAddr2, snapKey1), "previous storage wiped")
+		assert.Equal(t, snapValue, st.GetStorage(snapAddr2, snapKey2), "replaced storage")
+		assert.Equal(t, snapValue, st.GetStorage(snapAddr1, snapKey1), "source untouched")
+	}
+	trieState, _ := New(root2, kv)
+	check(trieState)
+	st, _ = creator.NewState(root2)
+	check(st)
+
+	// flattened into disk layer
+	assert.Nil(t, snaps.Close(root2))
+	assert.Equal(t, root2, snaps.disk.root)
+	st, _ = creator.NewState(root2)
+	assert.IsType(t, &diskLayer{}, st.layer)
+	check(st)
+}
+
+func TestSnapshotsFork(t *testing.T) {
+	_, snaps, creator, root1 := newSnapshotTest(t)
+	root2 := commitChanges(t, creator, root1)
+
+	// fork on root1
+	st, _ := creator.NewState(root1)
+	st.SetBalance(snapAddr1, big.NewInt(12))
+	root3, err := st.Stage().Commit()
+	assert.Nil(t, err)
+	assert.IsType(t, &diffLayer{}, snaps.layer(root3))
+
+	// stale layers fall back to tries
+	st, _ = creator.NewState(root2)
+	forked, _ := creator.NewState(root3)
+	assert.Nil(t, snaps.Close(root2))
+	assert.Equal(t, root2, snaps.disk.root)
+	assert.Nil(t, snaps.layer(root3), "fork dropped")
+	checkChanges(t, st)
+	assert.Equal(t, big.NewInt(12), forked.GetBalance(snapAddr1))
+}
+
+func TestSnapshotsRestart(t *testing.T) {
+	kv, snaps, creator, root1 := newSnapshotTest(t)
+	root2 := commitChanges(t, creator, root1)
+	assert.Nil(t, snaps.Close(root2))
+
+	// reuse after restart
+	snaps = NewSnapshots(kv, root2)
+	<-snaps.genDone
+	st, _ := NewCreatorWithSnapshots(kv, snaps).NewState(root2)
+	assert.IsType(t, &diskLayer{}, st.layer)
+	checkChanges(t, st)
+
+	// regenerate if root mismatches
+	snaps = NewSnapshots(kv, root1)
+	<-snaps.genDone
+	st, _ = NewCreatorWithSnapshots(kv, snaps).NewState(root1)
+	assert.IsType(t, &diskLayer{}, st.layer)
+	assert.Equal(t, snapValue, st.GetStorage(snapAddr1, snapKey1))
+	assert.Equal(t, snapValue, st.GetStorage(snapAddr2, snapKey1))
+	assert.Equal(t, meter.Bytes32{}, st.GetStorage(snapAddr2, snapKey2))
+}
@@ -6,6 +6,7 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/dfinlab/meter/kv"
@@ -21,6 +22,11 @@ type Stage struct {
 	accountTrie  *trie.SecureTrie
 	storageTries []*trie.SecureTrie
 	codes        []codeWithHash
+
+	snaps      *Snapshots
+	parentRoot meter.Bytes32
+	snapDiff   *snapshotDiff // changes to be put into snapshots, nil if not available
+	preimages  map[meter.Bytes32][]byte
 }
 
 type codeWithHash struct {
@@ -28,7 +34,7 @@ type codeWithHash struct {
 	hash []byte
 }
 
-func newStage(root meter.Bytes32, kv kv.GetPutter, changes map[meter.Address]*changedObject) *Stage {
+func newStage(root meter.Bytes32, kv kv.GetPutter, changes map[meter.Address]*changedObject, snaps *Snapshots, recordPreimages bool) *Stage {
 
 	accountTrie, err := trCache.Get(root, kv, true)
 	if err != nil {
@@ -38,8 +44,37 @@ func newStage(root meter.Bytes32, kv kv.GetPutter, changes map[meter.Address]*ch
 	storageTries := make([]*trie.SecureTrie, 0, len(changes))
 	codes := make([]codeWithHash, 0, len(changes))
 
+	var snapDiff *snapshotDiff
+	if snaps != nil {
+		snapDiff = newSnapshotDiff()
+	}
+	var preimages map[meter.Bytes32][]byte
+	if recordPreimages {
+		preimages = make(map[meter.Bytes32][]byte)
+	}
+
 	for addr, obj := range changes {
 		dataCpy := obj.data
+		if preimages != nil {
+			preimages[meter.Blake2b(addr[:])] = addr.Bytes()
+		}
+
+		var addrHash meter.Bytes32
+		if snapDiff != nil {
+			addrHash = meter.Blake2b(addr[:])
+			if !bytes.Equal(dataCpy.StorageRoot, obj.baseStorageRoot) {
+				// previous storage discarded, e.g. account deleted
+				snapDiff.wiped[addrHash] = true
+				if len(dataCpy.StorageRoot) > 0 {
+					// storage replaced by another trie, which is written in full
+					storageDiff, err := loadStorageDiff(meter.BytesToBytes32(dataCpy.StorageRoot), kv)
+					if err != nil {
+						return &Stage{err: err}
+					}
+					snapDiff.storage[addrHash] = storageDiff
+				}
+			}
+		}
 
 		if len(obj.code) > 0 {
 			codes = append(codes, codeWithHash{
@@ -57,10 +92,24 @@ func newStage(root meter.Bytes32, kv kv.GetPutter, changes map[meter.Address]*ch
 					return &Stage{err: err}
 				}
 				storageTries = append(storageTries, strie)
+				var storageDiff map[meter.Bytes32][]byte
+				if snapDiff != nil {
+					storageDiff = snapDiff.storage[addrHash]
+					if storageDiff == nil {
+						storageDiff = make(map[meter.Bytes32][]byte, len(obj.storage))
+						snapDiff.storage[addrHash] = storageDiff
+					}
+				}
 				for k, v := range obj.storage {
 					if err := saveStorage(strie, k, v); err != nil {
 						return &Stage{err: err}
 					}
+					if storageDiff != nil {
+						storageDiff[meter.Blake2b(k[:])] = v
+					}
+					if preimages != nil {
+						preimages[meter.Blake2b(k[:])] = k.Bytes()
+					}
 				}
 				dataCpy.StorageRoot = strie.Hash().Bytes()
 			}
@@ -70,13 +119,39 @@ func newStage(root meter.Bytes32, kv kv.GetPutter, changes map[meter.Address]*ch
 			fmt.Println("newStage, saveaccount failed", err.Error())
 			return &Stage{err: err}
 		}
+		if snapDiff != nil {
+			if err := saveSnapshotAccount(snapDiff, addrHash, &dataCpy); err != nil {
+				return &Stage{err: err}
+			}
+		}
 	}
 	return &Stage{
 		kv:           kv,
 		accountTrie:  accountTrie,
 		storageTries: storageTries,
 		codes:        codes,
+		snaps:        snaps,
+		parentRoot:   root,
+		snapDiff:     snapDiff,
+		preimages:    preimages,
+	}
+}
+
+// loadStorageDiff reads all entries of the storage trie, keyed by hashed key.
+func loadStorageDiff(root meter.Bytes32, kv kv.GetPutter) (map[meter.Bytes32][]byte, error) {
+	strie, err := trie.NewSecure(root, kv, 0)
+	if err != nil {
+		return nil, err
 	}
+	diff := make(map[meter.Bytes32][]byte)
+	it := trie.NewIterator(strie.NodeIterator(nil))
+	for it.Next() {
+		diff[meter.BytesToBytes32(it.Key)] = append([]byte(nil), it.Value...)
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	return diff, nil
 }
 
 // Hash computes hash of the main accounts trie.
@@ -100,6 +175,13 @@ func (s *Stage) Commit() (meter.Bytes32, error) {
 		}
 	}
 
+	// write preimages
+	for hash, key := range s.preimages {
+		if err := batch.Put(preimageKey(hash[:]), key); err != nil {
+			return meter.Bytes32{}, err
+		}
+	}
+
 	// commit storage tries
 	for _, strie := range s.storageTries {
 		root, err := strie.CommitTo(batch)
@@ -121,5 +203,8 @@ func (s *Stage) Commit() (meter.Bytes32, error) {
 
 	trCache.Add(root, s.accountTrie, s.kv)
 
+	if s.snaps != nil && s.snapDiff != nil {
+		s.snaps.update(root, s.parentRoot, s.snapDiff)
+	}
 	return root, nil
 }
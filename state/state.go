@@ -21,13 +21,16 @@ import (
 
 // State manages the main accounts trie.
 type State struct {
-	root     meter.Bytes32 // root of initial accounts trie
-	kv       kv.GetPutter
-	trie     trieReader                      // the accounts trie reader
-	cache    map[meter.Address]*cachedObject // cache of accounts trie
-	sm       *stackedmap.StackedMap          // keeps revisions of accounts state
-	err      error
-	setError func(err error)
+	root      meter.Bytes32 // root of initial accounts trie
+	kv        kv.GetPutter
+	snaps     *Snapshots
+	preimages bool                            // whether to record preimages on commit
+	layer     snapshotLayer                   // the snapshot layer at root, nil if not covered
+	trie      trieReader                      // the accounts trie reader
+	cache     map[meter.Address]*cachedObject // cache of accounts trie
+	sm        *stackedmap.StackedMap          // keeps revisions of accounts state
+	err       error
+	setError  func(err error)
 }
 
 // to constrain ability of trie
@@ -43,17 +46,28 @@ type trieWriter interface {
 
 // New create an state object.
 func New(root meter.Bytes32, kv kv.GetPutter) (*State, error) {
-	trie, err := trCache.Get(root, kv, false)
-	if err != nil {
-		return nil, err
-	}
+	return newState(root, kv, nil)
+}
 
+func newState(root meter.Bytes32, kv kv.GetPutter, snaps *Snapshots) (*State, error) {
 	state := State{
 		root:  root,
 		kv:    kv,
-		trie:  trie,
+		snaps: snaps,
 		cache: make(map[meter.Address]*cachedObject),
 	}
+	if snaps != nil {
+		state.layer = snaps.layer(root)
+	}
+	if state.layer != nil {
+		state.trie = &snapshotAccountReader{state.layer, kv}
+	} else {
+		trie, err := trCache.Get(root, kv, false)
+		if err != nil {
+			return nil, err
+		}
+		state.trie = trie
+	}
 	state.setError = func(err error) {
 		if state.err == nil {
 			state.err = err
@@ -68,7 +82,7 @@ func New(root meter.Bytes32, kv kv.GetPutter) (*State, error) {
 // Spawn create a new state object shares current state's underlying db.
 // Also errors will be reported to current state.
 func (s *State) Spawn(root meter.Bytes32) *State {
-	newState, err := New(root, s.kv)
+	newState, err := newState(root, s.kv, s.snaps)
 	if err != nil {
 		s.setError(err)
 		newState, err = New(meter.Bytes32{}, s.kv)
@@ -77,6 +91,7 @@ func (s *State) Spawn(root meter.Bytes32) *State {
 		}
 	}
 	newState.setError = s.setError
+	newState.preimages = s.preimages
 	return newState
 }
 
@@ -113,7 +128,8 @@ func (s *State) changes() map[meter.Address]*changedObject {
 		if obj, ok := changes[addr]; ok {
 			return obj
 		}
-		obj := &changedObject{data: s.getCachedObject(addr).data}
+		data := s.getCachedObject(addr).data
+		obj := &changedObject{data: data, baseStorageRoot: data.StorageRoot}
 		changes[addr] = obj
 		return obj
 	}
@@ -148,6 +164,10 @@ func (s *State) getCachedObject(addr meter.Address) *cachedObject {
 		return newCachedObject(s.kv, emptyAccount())
 	}
 	co := newCachedObject(s.kv, a)
+	if s.layer != nil {
+		co.layer = s.layer
+		co.addrHash = meter.Blake2b(addr[:])
+	}
 	s.cache[addr] = co
 	return co
 }
@@ -530,7 +550,7 @@ func (s *State) Stage() *Stage {
 		return &Stage{err: s.err}
 	}
 
-	return newStage(s.root, s.kv, changes)
+	return newStage(s.root, s.kv, changes, s.snaps, s.preimages)
 }
 
 func (s *State) IsExclusiveAccount(addr meter.Address) bool {
@@ -581,8 +601,9 @@ type (
 	}
 	codeKey       meter.Address
 	changedObject struct {
-		data    Account
-		storage map[meter.Bytes32]rlp.RawValue
-		code    []byte
+		data            Account
+		storage         map[meter.Bytes32]rlp.RawValue
+		code            []byte
+		baseStorageRoot []byte // storage root before changes
 	}
 )
@@ -0,0 +1,283 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tracers
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/dfinlab/meter/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// callFrame is a call reported by the call tracer, fields are ordered the same as
+// the result of call_tracer.js.
+type callFrame struct {
+	Type    string       `json:"type,omitempty"`
+	From    string       `json:"from,omitempty"`
+	To      string       `json:"to,omitempty"`
+	Value   string       `json:"value,omitempty"`
+	Gas     string       `json:"gas,omitempty"`
+	GasUsed string       `json:"gasUsed,omitempty"`
+	Input   string       `json:"input,omitempty"`
+	Output  string       `json:"output,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Time    string       `json:"time,omitempty"`
+	Calls   []*callFrame `json:"calls,omitempty"`
+
+	gasIn   uint64
+	gasCost uint64
+	gas     *uint64 // true allowance retrieved inside the call
+	outOff  *big.Int
+	outLen  *big.Int
+}
+
+// CallTracer is the native implementation of call_tracer.js, which extracts and
+// reports all the internal calls made by a transaction.
+type CallTracer struct {
+	callstack []*callFrame
+	// descended tracks whether we've just descended from an outer transaction into
+	// an inner call.
+	descended bool
+
+	from    common.Address
+	to      common.Address
+	create  bool
+	input   []byte
+	gas     uint64
+	value   *big.Int
+	output  []byte
+	gasUsed uint64
+	time    time.Duration
+	err     error
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+// NewCallTracer creates a native call tracer.
+func NewCallTracer() *CallTracer {
+	return &CallTracer{
+		callstack: []*callFrame{{}},
+	}
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *CallTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *CallTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.from, t.to, t.create, t.input, t.gas, t.value = from, to, create, input, gas, value
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *CallTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	// If tracing was interrupted, skip the remaining steps
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return nil
+	}
+	// Capture any errors immediately
+	if err != nil {
+		return t.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+	}
+	// If a new contract is being created, add to the call stack
+	switch op {
+	case vm.CREATE:
+		inOff := peekStack(stack, 1)
+		t.callstack = append(t.callstack, &callFrame{
+			Type:    op.String(),
+			From:    hexutil.Encode(contract.Address().Bytes()),
+			Input:   hexutil.Encode(sliceMemory(memory, inOff, new(big.Int).Add(inOff, peekStack(stack, 2)))),
+			Value:   hexBig(peekStack(stack, 0)),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return nil
+	case vm.SELFDESTRUCT:
+		// If a contract is being self destructed, gather that as a subcall too
+		top := t.callstack[len(t.callstack)-1]
+		top.Calls = append(top.Calls, &callFrame{Type: op.String()})
+		return nil
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		// Skip any pre-compile invocations, those are just fancy opcodes
+		to := common.BigToAddress(peekStack(stack, 1))
+		if env.IsPrecompile(to) {
+			return nil
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		inOff := peekStack(stack, 2+off)
+		call := &callFrame{
+			Type:    op.String(),
+			From:    hexutil.Encode(contract.Address().Bytes()),
+			To:      hexutil.Encode(to.Bytes()),
+			Input:   hexutil.Encode(sliceMemory(memory, inOff, new(big.Int).Add(inOff, peekStack(stack, 3+off)))),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  new(big.Int).Set(peekStack(stack, 4+off)),
+			outLen:  new(big.Int).Set(peekStack(stack, 5+off)),
+		}
+		if off == 1 {
+			call.Value = hexBig(peekStack(stack, 2))
+		}
+		t.callstack = append(t.callstack, call)
+		t.descended = true
+		return nil
+	}
+	// If we've just descended into an inner call, retrieve it's true allowance. We
+	// need to extract if from within the call as there may be funky gas dynamics
+	// with regard to requested and actually given gas (2300 stipend, 63/64 rule).
+	if t.descended {
+		if depth >= len(t.callstack) {
+			g := gas
+			t.callstack[len(t.callstack)-1].gas = &g
+		}
+		t.descended = false
+	}
+	// If an existing call is returning, pop off the call stack
+	if op == vm.REVERT {
+		t.callstack[len(t.callstack)-1].Error = "execution reverted"
+		return nil
+	}
+	if depth == len(t.callstack)-1 {
+		// Pop off the last call and get the execution results
+		call := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+
+		ret := peekStack(stack, 0)
+		if call.Type == vm.CREATE.String() {
+			// If the call was a CREATE, retrieve the contract address and output code
+			call.GasUsed = hexInt(int64(call.gasIn) - int64(call.gasCost) - int64(gas))
+			if ret.Sign() != 0 {
+				addr := common.BigToAddress(ret)
+				call.To = hexutil.Encode(addr.Bytes())
+				call.Output = hexutil.Encode(env.StateDB.GetCode(addr))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		} else if call.gas != nil {
+			// If the call was a contract call, retrieve the gas usage and output
+			call.GasUsed = hexInt(int64(call.gasIn) - int64(call.gasCost) + int64(*call.gas) - int64(gas))
+			if ret.Sign() != 0 {
+				call.Output = hexutil.Encode(sliceMemory(memory, call.outOff, new(big.Int).Add(call.outOff, call.outLen)))
+			} else if call.Error == "" {
+				call.Error = "internal failure"
+			}
+		}
+		if call.gas != nil {
+			call.Gas = hexInt(int64(*call.gas))
+		}
+		// Inject the call into the previous one
+		top := t.callstack[len(t.callstack)-1]
+		top.Calls = append(top.Calls, call)
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *CallTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	// If the topmost call already reverted, don't handle the additional fault again
+	if t.callstack[len(t.callstack)-1].Error != "" {
+		return nil
+	}
+	// Pop off the just failed call
+	call := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	call.Error = err.Error()
+
+	// Consume all available gas and clean any leftovers
+	if call.gas != nil {
+		call.Gas = hexInt(int64(*call.gas))
+		call.GasUsed = call.Gas
+	}
+	// Flatten the failed call into its parent
+	if len(t.callstack) > 0 {
+		top := t.callstack[len(t.callstack)-1]
+		top.Calls = append(top.Calls, call)
+		return nil
+	}
+	// Last call failed too, leave it in the stack
+	t.callstack = append(t.callstack, call)
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	t.output, t.gasUsed, t.time, t.err = output, gasUsed, d, err
+	return nil
+}
+
+// GetResult returns the json encoded top level call.
+func (t *CallTracer) GetResult() (json.RawMessage, error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return nil, t.reason
+	}
+	typ := "CALL"
+	if t.create {
+		typ = "CREATE"
+	}
+	result := &callFrame{
+		Type:    typ,
+		From:    hexutil.Encode(t.from.Bytes()),
+		To:      hexutil.Encode(t.to.Bytes()),
+		Value:   hexBig(t.value),
+		Gas:     hexInt(int64(t.gas)),
+		GasUsed: hexInt(int64(t.gasUsed)),
+		Input:   hexutil.Encode(t.input),
+		Output:  hexutil.Encode(t.output),
+		Time:    t.time.String(),
+		Calls:   t.callstack[0].Calls,
+	}
+	if t.callstack[0].Error != "" {
+		result.Error = t.callstack[0].Error
+	} else if t.err != nil {
+		result.Error = t.err.Error()
+	}
+	if result.Error != "" {
+		result.Output = ""
+	}
+	return json.Marshal(result)
+}
+
+// peekStack returns the nth-from-the-top element of the stack, or zero if out of bound.
+func peekStack(stack *vm.Stack, n int) *big.Int {
+	data := stack.Data()
+	if len(data) <= n {
+		return new(big.Int)
+	}
+	return data[len(data)-n-1]
+}
+
+// sliceMemory returns the memory in range [begin, end), or nil if out of bound.
+func sliceMemory(memory *vm.Memory, begin, end *big.Int) []byte {
+	if !end.IsInt64() || int64(memory.Len()) < end.Int64() {
+		return nil
+	}
+	return memory.Get(begin.Int64(), end.Int64()-begin.Int64())
+}
+
+// hexBig formats the big integer as the 0x prefixed hex string without leading zeros.
+func hexBig(n *big.Int) string {
+	if n == nil {
+		return "0x0"
+	}
+	return "0x" + n.Text(16)
+}
+
+func hexInt(n int64) string {
+	return hexBig(big.NewInt(n))
+}
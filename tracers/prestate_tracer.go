@@ -0,0 +1,231 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/dfinlab/meter/vm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type prestateAccount struct {
+	Balance string           `json:"balance"`
+	Nonce   int64            `json:"nonce"`
+	Code    string           `json:"code"`
+	Storage *prestateStorage `json:"storage"`
+}
+
+// prestateStorage keeps storage entries in the order of lookup, as the js object does.
+type prestateStorage struct {
+	keys   []common.Hash
+	values map[common.Hash]common.Hash
+}
+
+func (s *prestateStorage) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range s.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%q:%q", hexutil.Encode(key[:]), hexutil.Encode(s.values[key][:]))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// prestate keeps accounts in the order of lookup, as the js object does.
+type prestate struct {
+	addrs    []common.Address
+	accounts map[common.Address]*prestateAccount
+}
+
+func (p *prestate) delete(addr common.Address) {
+	if _, ok := p.accounts[addr]; !ok {
+		return
+	}
+	delete(p.accounts, addr)
+	for i, a := range p.addrs {
+		if a == addr {
+			p.addrs = append(p.addrs[:i], p.addrs[i+1:]...)
+			break
+		}
+	}
+}
+
+func (p *prestate) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, addr := range p.addrs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		acc, err := json.Marshal(p.accounts[addr])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%q:", hexutil.Encode(addr[:]))
+		buf.Write(acc)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// PrestateTracer is the native implementation of prestate_tracer.js, which outputs
+// sufficient information to create a local execution of the transaction from a
+// custom assembled genesis block.
+type PrestateTracer struct {
+	prestate *prestate
+	db       vm.StateDB
+
+	from   common.Address
+	to     common.Address
+	create bool
+	value  *big.Int
+	err    error // Error, if one has occurred
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+// NewPrestateTracer creates a native prestate tracer.
+func NewPrestateTracer() *PrestateTracer {
+	return &PrestateTracer{}
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *PrestateTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// lookupAccount injects the specified account into the prestate.
+func (t *PrestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.prestate.accounts[addr]; ok {
+		return
+	}
+	t.prestate.addrs = append(t.prestate.addrs, addr)
+	t.prestate.accounts[addr] = &prestateAccount{
+		Balance: hexBig(t.db.GetBalance(addr)),
+		Nonce:   int64(t.db.GetNonce(addr)),
+		Code:    hexutil.Encode(t.db.GetCode(addr)),
+		Storage: &prestateStorage{values: make(map[common.Hash]common.Hash)},
+	}
+}
+
+// lookupStorage injects the specified storage entry of the given account into
+// the prestate.
+func (t *PrestateTracer) lookupStorage(addr common.Address, key common.Hash) error {
+	acc, ok := t.prestate.accounts[addr]
+	if !ok {
+		return fmt.Errorf("account %v not found in prestate", hexutil.Encode(addr[:]))
+	}
+	if _, ok := acc.Storage.values[key]; !ok {
+		if val := t.db.GetState(addr, key); val != (common.Hash{}) {
+			acc.Storage.keys = append(acc.Storage.keys, key)
+			acc.Storage.values[key] = val
+		}
+	}
+	return nil
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *PrestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.from, t.to, t.create, t.value = from, to, create, value
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *PrestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.err != nil {
+		return nil
+	}
+	// If tracing was interrupted, set the error and stop
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		t.err = t.reason
+		return nil
+	}
+	t.db = env.StateDB
+
+	// Add the current account if we just started tracing
+	if t.prestate == nil {
+		t.prestate = &prestate{accounts: make(map[common.Address]*prestateAccount)}
+		// Balance will potentially be wrong here, since this will include the value
+		// sent along with the message. We fix that in GetResult.
+		t.lookupAccount(contract.Address())
+	}
+	// Whenever new state is accessed, add it to the prestate
+	switch op {
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.BALANCE:
+		t.lookupAccount(common.BigToAddress(peekStack(stack, 0)))
+	case vm.CREATE:
+		from := contract.Address()
+		t.lookupAccount(crypto.CreateAddress(from, t.db.GetNonce(from)))
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.lookupAccount(common.BigToAddress(peekStack(stack, 1)))
+	case vm.SSTORE, vm.SLOAD:
+		if err := t.lookupStorage(contract.Address(), common.BigToHash(peekStack(stack, 0))); err != nil {
+			t.err = wrapError("step", err)
+		}
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *PrestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *PrestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the json encoded prestate, or any accumulated error.
+func (t *PrestateTracer) GetResult() (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.prestate == nil {
+		return nil, wrapError("result", fmt.Errorf("no state accessed"))
+	}
+	// At this point, we need to deduct the 'value' from the
+	// outer transaction, and move it back to the origin
+	t.lookupAccount(t.from)
+
+	fromAcc := t.prestate.accounts[t.from]
+	toAcc, ok := t.prestate.accounts[t.to]
+	if !ok {
+		return nil, wrapError("result", fmt.Errorf("account %v not found in prestate", hexutil.Encode(t.to[:])))
+	}
+	value := t.value
+	if value == nil {
+		value = new(big.Int)
+	}
+	toBal, _ := new(big.Int).SetString(toAcc.Balance[2:], 16)
+	toAcc.Balance = hexBig(toBal.Sub(toBal, value))
+	fromBal, _ := new(big.Int).SetString(fromAcc.Balance[2:], 16)
+	fromAcc.Balance = hexBig(fromBal.Add(fromBal, value))
+
+	// Decrement the caller's nonce, and remove empty create targets
+	fromAcc.Nonce--
+	if t.create {
+		// We can blindly delete the contract prestate, as any existing state would
+		// have caused the transaction to be rejected as invalid in the first place.
+		t.prestate.delete(t.to)
+	}
+	// Return the assembled allocations (prestate)
+	return json.Marshal(t.prestate)
+}
@@ -18,15 +18,36 @@
 package tracers
 
 import (
+	"encoding/json"
+	"errors"
 	"strings"
 	"unicode"
 
 	"github.com/dfinlab/meter/tracers/internal/tracers"
+	"github.com/dfinlab/meter/vm"
 )
 
+// ErrTracerNotFound is returned if no tracer with the given name exists.
+var ErrTracerNotFound = errors.New("tracer not found")
+
+// ResultTracer is a vm.Tracer which reports the json encoded result once the
+// tracing is done.
+type ResultTracer interface {
+	vm.Tracer
+	GetResult() (json.RawMessage, error)
+	Stop(err error)
+}
+
 // all contains all the built in JavaScript tracers by name.
 var all = make(map[string]string)
 
+// natives contains the Go implementations of built in tracers by name, which
+// produce the same result as the JavaScript ones, but much faster.
+var natives = map[string]func() ResultTracer{
+	"callTracer":     func() ResultTracer { return NewCallTracer() },
+	"prestateTracer": func() ResultTracer { return NewPrestateTracer() },
+}
+
 // camel converts a snake cased input string into a camel cased output.
 func camel(str string) string {
 	pieces := strings.Split(str, "_")
@@ -51,3 +72,20 @@ func tracer(name string) (string, bool) {
 	}
 	return "", false
 }
+
+// NewByName creates a built in tracer by name, the native implementation is
+// preferred over the JavaScript one if available.
+func NewByName(name string) (ResultTracer, error) {
+	if newTracer, ok := natives[name]; ok {
+		return newTracer(), nil
+	}
+	code, ok := tracer(name)
+	if !ok {
+		return nil, ErrTracerNotFound
+	}
+	tracer, err := New(code)
+	if err != nil {
+		return nil, err
+	}
+	return tracer, nil
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tracers_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dfinlab/meter/chain"
+	"github.com/dfinlab/meter/genesis"
+	"github.com/dfinlab/meter/lvldb"
+	"github.com/dfinlab/meter/meter"
+	"github.com/dfinlab/meter/runtime"
+	"github.com/dfinlab/meter/state"
+	"github.com/dfinlab/meter/tracers"
+	"github.com/dfinlab/meter/tx"
+	"github.com/dfinlab/meter/vm"
+	"github.com/dfinlab/meter/xenv"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	caller   = meter.BytesToAddress([]byte("caller"))
+	callee   = meter.BytesToAddress([]byte("callee"))
+	reverter = meter.BytesToAddress([]byte("reverter"))
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+type testEnv struct {
+	rt    *runtime.Runtime
+	state *state.State
+}
+
+// newTestEnv deploys three contracts:
+// callee stores 42 at slot 0, then loads and returns it;
+// reverter always reverts;
+// caller queries the balance of callee, then calls callee and reverter.
+func newTestEnv(tb testing.TB) *testEnv {
+	kv, _ := lvldb.NewMem()
+	g := genesis.NewDevnet()
+	stateCreator := state.NewCreator(kv)
+	b0, _, err := g.Build(stateCreator)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := stateCreator.NewState(b0.Header().StateRoot())
+
+	st.SetCode(callee, mustDecodeHex("602a60005560005460005260206000f3"))
+	st.SetCode(reverter, mustDecodeHex("60006000fd"))
+	st.SetCode(caller, mustDecodeHex(
+		"73"+hex.EncodeToString(callee.Bytes())+"3150"+
+			"60206000600060006000"+"73"+hex.EncodeToString(callee.Bytes())+"5af150"+
+			"60006000600060006000"+"73"+hex.EncodeToString(reverter.Bytes())+"5af150"+
+			"60206000f3"))
+
+	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Time: b0.Header().Timestamp()})
+	return &testEnv{rt, st}
+}
+
+func (env *testEnv) trace(tracer tracers.ResultTracer) (json.RawMessage, error) {
+	checkpoint := env.state.NewCheckpoint()
+	defer env.state.RevertTo(checkpoint)
+
+	env.rt.SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+	env.rt.ExecuteClause(tx.NewClause(&caller), 0, 1000000, &xenv.TransactionContext{Origin: genesis.DevAccounts()[0].Address})
+	return tracer.GetResult()
+}
+
+func newJSTracer(tb testing.TB, name string) tracers.ResultTracer {
+	code, ok := tracers.CodeByName(name)
+	if !ok {
+		tb.Fatalf("tracer %v not found", name)
+	}
+	tracer, err := tracers.New(code)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return tracer
+}
+
+func TestNewByName(t *testing.T) {
+	tracer, err := tracers.NewByName("callTracer")
+	assert.Nil(t, err)
+	assert.IsType(t, &tracers.CallTracer{}, tracer)
+
+	tracer, err = tracers.NewByName("prestateTracer")
+	assert.Nil(t, err)
+	assert.IsType(t, &tracers.PrestateTracer{}, tracer)
+
+	tracer, err = tracers.NewByName("4byteTracer")
+	assert.Nil(t, err)
+	assert.IsType(t, &tracers.Tracer{}, tracer)
+
+	_, err = tracers.NewByName("unknownTracer")
+	assert.Equal(t, tracers.ErrTracerNotFound, err)
+}
+
+func TestCallTracer(t *testing.T) {
+	env := newTestEnv(t)
+
+	jsResult, err := env.trace(newJSTracer(t, "callTracer"))
+	assert.Nil(t, err)
+	nativeResult, err := env.trace(tracers.NewCallTracer())
+	assert.Nil(t, err)
+
+	var js, native map[string]interface{}
+	assert.Nil(t, json.Unmarshal(jsResult, &js))
+	assert.Nil(t, json.Unmarshal(nativeResult, &native))
+	// the execution time differs between runs
+	delete(js, "time")
+	delete(native, "time")
+	assert.Equal(t, js, native)
+
+	calls := native["calls"].([]interface{})
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "0x"+strings.Repeat("0", 62)+"2a", calls[0].(map[string]interface{})["output"])
+	assert.Equal(t, "execution reverted", calls[1].(map[string]interface{})["error"])
+}
+
+func TestPrestateTracer(t *testing.T) {
+	env := newTestEnv(t)
+
+	jsResult, err := env.trace(newJSTracer(t, "prestateTracer"))
+	assert.Nil(t, err)
+	nativeResult, err := env.trace(tracers.NewPrestateTracer())
+	assert.Nil(t, err)
+
+	assert.Equal(t, string(jsResult), string(nativeResult))
+
+	var native map[string]interface{}
+	assert.Nil(t, json.Unmarshal(nativeResult, &native))
+	assert.Contains(t, native, caller.String())
+	assert.Contains(t, native, callee.String())
+	assert.Contains(t, native, reverter.String())
+}
+
+func benchmarkTracer(b *testing.B, newTracer func() tracers.ResultTracer) {
+	env := newTestEnv(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := env.trace(newTracer()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCallTracerJS(b *testing.B) {
+	benchmarkTracer(b, func() tracers.ResultTracer { return newJSTracer(b, "callTracer") })
+}
+
+func BenchmarkCallTracerNative(b *testing.B) {
+	benchmarkTracer(b, func() tracers.ResultTracer { return tracers.NewCallTracer() })
+}
+
+func BenchmarkPrestateTracerJS(b *testing.B) {
+	benchmarkTracer(b, func() tracers.ResultTracer { return newJSTracer(b, "prestateTracer") })
+}
+
+func BenchmarkPrestateTracerNative(b *testing.B) {
+	benchmarkTracer(b, func() tracers.ResultTracer { return tracers.NewPrestateTracer() })
+}
+
+func TestTracerLimit(t *testing.T) {
+	env := newTestEnv(t)
+	code := `{count: 0, step: function() { this.count++ }, fault: function() {}, result: function() { return this.count }}`
+
+	tracer, err := tracers.New(code)
+	assert.Nil(t, err)
+	result, err := env.trace(tracer)
+	assert.Nil(t, err)
+
+	var steps int
+	assert.Nil(t, json.Unmarshal(result, &steps))
+	assert.True(t, steps > 5)
+
+	tracer, err = tracers.NewWithLimit(code, uint64(steps), 0)
+	assert.Nil(t, err)
+	_, err = env.trace(tracer)
+	assert.Nil(t, err)
+
+	tracer, err = tracers.NewWithLimit(code, 5, 0)
+	assert.Nil(t, err)
+	_, err = env.trace(tracer)
+	assert.Equal(t, tracers.ErrStepLimitExceeded, err)
+}
+
+func TestTracerTimeout(t *testing.T) {
+	env := newTestEnv(t)
+	timeout := 100 * time.Millisecond
+
+	// endless code is interrupted wherever it runs
+	_, err := tracers.NewWithLimit(`{step: function() {}, fault: function() {}, result: function() {}, x: (function() { for (;;) {} })()}`, 0, timeout)
+	assert.Equal(t, tracers.ErrTimeout, err)
+
+	tracer, err := tracers.NewWithLimit(`{step: function() { for (;;) {} }, fault: function() {}, result: function() { for (;;) {} }}`, 0, timeout)
+	assert.Nil(t, err)
+	_, err = env.trace(tracer)
+	assert.Equal(t, tracers.ErrTimeout, err)
+
+	tracer, err = tracers.NewWithLimit(`{step: function() {}, fault: function() {}, result: function() { for (;;) {} }}`, 0, timeout)
+	assert.Nil(t, err)
+	_, err = env.trace(tracer)
+	assert.Equal(t, tracers.ErrTimeout, err)
+
+	tracer, err = tracers.NewWithLimit(`{step: function() {}, fault: function() {}, result: function() { return 1 }}`, 0, timeout)
+	assert.Nil(t, err)
+	result, err := env.trace(tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", string(result))
+}
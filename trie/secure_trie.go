@@ -140,7 +140,7 @@ func (t *SecureTrie) GetKey(shaKey []byte) []byte {
 	return key
 }
 
-// Commit writes all nodes and the secure hash pre-images to the trie's database.
+// Commit writes all nodes to the trie's database.
 // Nodes are stored with their blake2b hash as the key.
 //
 // Committing flushes nodes from memory. Subsequent Get calls will load nodes
@@ -168,20 +168,15 @@ func (t *SecureTrie) NodeIterator(start []byte) NodeIterator {
 	return t.trie.NodeIterator(start)
 }
 
-// CommitTo writes all nodes and the secure hash pre-images to the given database.
-// Nodes are stored with their blake2b hash as the key.
+// CommitTo writes all nodes to the given database.
+// Nodes are stored with their blake2b hash as the key. The secure hash pre-images
+// are not written, callers record them on their own if needed.
 //
 // Committing flushes nodes from memory. Subsequent Get calls will load nodes from
 // the trie's database. Calling code must ensure that the changes made to db are
 // written back to the trie's attached database before using the trie.
 func (t *SecureTrie) CommitTo(db DatabaseWriter) (root meter.Bytes32, err error) {
-	// Write all the pre-images to the actual disk database
 	if len(t.getSecKeyCache()) > 0 {
-		for hk, key := range t.secKeyCache {
-			if err := db.Put([]byte(hk), key); err != nil {
-				continue
-			}
-		}
 		t.secKeyCache = make(map[string][]byte)
 	}
 	return t.trie.CommitTo(db)
@@ -0,0 +1,52 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package types
+
+import (
+	"encoding/hex"
+	"sync"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+)
+
+// BLS params and system generator shared by all nodes, in hex.
+const (
+	BlsParamsHex = "7479706520610a7120393838353834383131343738353339323431393933323931313633343633383233393237323539333630313734353437303331303937363333343133333530303632303839383839373036333235323836313831303431393231303532353631343638393937373833313833373239383735313336373034373032383734373731313033383234383836323436333937353435373032373639310a682031333532383333373038363039373437363036393233353830313130323833353636323231393236323833343938313336333130333037363536313036373633383333353631353531343531363531393734363630363036363434333134333539303831373330323933320a72203733303735313136373131343539353138363134323832393030323835333733393531393935383631343830323433310a65787032203135390a65787031203133380a7369676e3120310a7369676e30202d310a"
+	BlsSystemHex = "2db8cb49c44a1c7ba19fdaf6947425a7c0191c710b64fd89cdc8b573881d98d814e377bb5a158c90a93e077b6ec1c3c92ae51f53fb22ef42d117b95f84c2dfec00"
+)
+
+var (
+	blsSystemOnce sync.Once
+	blsSystem     bls.System
+	blsSystemErr  error
+)
+
+// DefaultBlsSystem returns the BLS system shared by all nodes, for verifying
+// signatures outside of the consensus reactor. It is built once and never freed.
+func DefaultBlsSystem() (*bls.System, error) {
+	blsSystemOnce.Do(func() {
+		paramsBytes, err := hex.DecodeString(BlsParamsHex)
+		if err != nil {
+			blsSystemErr = err
+			return
+		}
+		params, err := bls.ParamsFromBytes(paramsBytes)
+		if err != nil {
+			blsSystemErr = err
+			return
+		}
+		systemBytes, err := hex.DecodeString(BlsSystemHex)
+		if err != nil {
+			blsSystemErr = err
+			return
+		}
+		blsSystem, blsSystemErr = bls.SystemFromBytes(bls.GenPairing(params), systemBytes)
+	})
+	if blsSystemErr != nil {
+		return nil, blsSystemErr
+	}
+	return &blsSystem, nil
+}
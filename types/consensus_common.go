@@ -12,9 +12,14 @@ import (
 	bls "github.com/dfinlab/meter/crypto/multi_sig"
 )
 
+// BlsSigner signs consensus messages with the BLS private key, which may be held by a remote signer.
+type BlsSigner interface {
+	BlsSignMessage(msg []byte) ([]byte, error)
+}
+
 type ConsensusCommon struct {
-	PrivKey bls.PrivateKey //my private key
-	PubKey  bls.PublicKey  //my public key
+	Signer BlsSigner     //signs with my private key
+	PubKey bls.PublicKey //my public key
 
 	//global params of BLS
 	System      bls.System
@@ -32,7 +37,6 @@ func (cc *ConsensusCommon) Destroy() bool {
 	}
 
 	cc.PubKey.Free()
-	cc.PrivKey.Free()
 	cc.System.Free()
 	cc.Pairing.Free()
 	cc.Params.Free()
@@ -67,17 +71,21 @@ func (cc *ConsensusCommon) Hash256Msg(msg []byte) [32]byte {
 }
 
 // sign the part of msg
-func (cc *ConsensusCommon) SignMessage(msg []byte) (bls.Signature, [32]byte) {
+func (cc *ConsensusCommon) SignMessage(msg []byte) (bls.Signature, [32]byte, error) {
 	hash := sha256.Sum256(msg)
-	sig := bls.Sign(hash, cc.PrivKey)
-	return sig, hash
+	sigBytes, err := cc.Signer.BlsSignMessage(msg)
+	if err != nil {
+		return bls.Signature{}, hash, err
+	}
+	sig, err := cc.System.SigFromBytes(sigBytes)
+	return sig, hash, err
 }
 
 // the return with slice byte
-func (cc *ConsensusCommon) SignMessage2(msg []byte) ([]byte, [32]byte) {
+func (cc *ConsensusCommon) SignMessage2(msg []byte) ([]byte, [32]byte, error) {
 	hash := sha256.Sum256(msg)
-	sig := bls.Sign(hash, cc.PrivKey)
-	return cc.System.SigToBytes(sig), hash
+	sig, err := cc.Signer.BlsSignMessage(msg)
+	return sig, hash, err
 }
 
 func (cc *ConsensusCommon) VerifySignature(signature, msgHash, blsPK []byte) bool {
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package types
+
+import (
+	sha256 "crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+)
+
+var (
+	errVoteBeforeFork     = errors.New("votes are signed without epoch and round")
+	errVoteSlotMismatch   = errors.New("votes are not in the same epoch, round and height")
+	errVoteNotConflicting = errors.New("votes are for the same block")
+	errVoteBadSignature   = errors.New("vote signature is invalid")
+)
+
+// BuildProposalBlockSignMsg builds the message signed with BLS when voting for a proposed block.
+// "BlockType <8 bytes> Height <16 (8x2) bytes> BlockID <32 bytes> TxRoot <32 bytes> StateRoot <32 bytes>"
+func BuildProposalBlockSignMsg(blockType uint32, height uint64, id, txsRoot, stateRoot *meter.Bytes32) string {
+	c := make([]byte, binary.MaxVarintLen32)
+	binary.BigEndian.PutUint32(c, blockType)
+
+	h := make([]byte, binary.MaxVarintLen64)
+	binary.BigEndian.PutUint64(h, height)
+
+	return fmt.Sprintf("%s %s %s %s %s %s %s %s %s %s",
+		"BlockType", hex.EncodeToString(c),
+		"Height", hex.EncodeToString(h),
+		"BlockID", id.String(),
+		"TxRoot", txsRoot.String(),
+		"StateRoot", stateRoot.String())
+}
+
+// SignSlot is where a proposal or vote is signed in pacemaker. A validator signs at most one proposal
+// and one vote per slot, sign protection refuses a second one, and two different votes signed in the
+// same slot are slashed as double-signing.
+type SignSlot struct {
+	Epoch  uint64 `json:"epoch"`
+	Round  uint32 `json:"round"`
+	Height uint32 `json:"height"`
+}
+
+// Before returns whether s comes earlier than other.
+func (s SignSlot) Before(other SignSlot) bool {
+	if s.Epoch != other.Epoch {
+		return s.Epoch < other.Epoch
+	}
+	if s.Round != other.Round {
+		return s.Round < other.Round
+	}
+	return s.Height < other.Height
+}
+
+// Vote is a vote for the block proposed in a round of pacemaker, it's what the signer signs with BLS key.
+type Vote struct {
+	Epoch     uint64        `json:"epoch"`
+	Round     uint32        `json:"round"`
+	Height    uint64        `json:"height"`
+	BlockType uint32        `json:"blockType"`
+	BlockID   meter.Bytes32 `json:"blockID"`
+	TxsRoot   meter.Bytes32 `json:"txsRoot"`
+	StateRoot meter.Bytes32 `json:"stateRoot"`
+}
+
+// SignMsg builds the message signed with BLS key. Since Tesla Fork4 the epoch and round are appended,
+// so that votes in different rounds at the same height never collide.
+// "... Epoch <16 (8x2) bytes> Round <8 (4x2) bytes>"
+func (v *Vote) SignMsg() string {
+	msg := BuildProposalBlockSignMsg(v.BlockType, v.Height, &v.BlockID, &v.TxsRoot, &v.StateRoot)
+	if !meter.BlockChainConfig.IsTeslaFork4(uint32(v.Height)) {
+		return msg
+	}
+
+	e := make([]byte, 8)
+	binary.BigEndian.PutUint64(e, v.Epoch)
+
+	r := make([]byte, 4)
+	binary.BigEndian.PutUint32(r, v.Round)
+
+	return fmt.Sprintf("%s %s %s %s %s", msg, "Epoch", hex.EncodeToString(e), "Round", hex.EncodeToString(r))
+}
+
+// SigningHash returns the digest of the vote message.
+func (v *Vote) SigningHash() [32]byte {
+	return sha256.Sum256([]byte(v.SignMsg()))
+}
+
+// Slot returns the slot the vote is signed in.
+func (v *Vote) Slot() SignSlot {
+	return SignSlot{Epoch: v.Epoch, Round: v.Round, Height: uint32(v.Height)}
+}
+
+// SignedVote is a vote for a proposed block with the BLS signature of the voter.
+type SignedVote struct {
+	Vote
+	Signature []byte
+}
+
+func (v *SignedVote) String() string {
+	return fmt.Sprintf("SignedVote(Epoch=%v, Round=%v, BlockType=%v, Height=%v, BlockID=%v)", v.Epoch, v.Round, v.BlockType, v.Height, v.BlockID)
+}
+
+// Verify checks the signature of the vote against the BLS public key.
+func (v *SignedVote) Verify(system *bls.System, pubKey bls.PublicKey) error {
+	sig, err := system.SigFromBytes(v.Signature)
+	if err != nil {
+		return errVoteBadSignature
+	}
+	defer sig.Free()
+
+	if !bls.Verify(sig, v.SigningHash(), pubKey) {
+		return errVoteBadSignature
+	}
+	return nil
+}
+
+// DoubleSignEvidence are two votes signed by the same key for different blocks in the same epoch, round and height.
+type DoubleSignEvidence struct {
+	Vote1 SignedVote
+	Vote2 SignedVote
+}
+
+func (e *DoubleSignEvidence) String() string {
+	return fmt.Sprintf("DoubleSignEvidence(Vote1=%v, Vote2=%v)", e.Vote1.String(), e.Vote2.String())
+}
+
+// Height is the block height the evidence is for.
+func (e *DoubleSignEvidence) Height() uint64 {
+	return e.Vote1.Height
+}
+
+// Verify checks that the votes conflict, and both are signed by the BLS public key. Votes signed
+// before Tesla Fork4 carry no epoch and round, re-voting after a timeout is legit, so they are no evidence.
+func (e *DoubleSignEvidence) Verify(system *bls.System, pubKey bls.PublicKey) error {
+	if !meter.BlockChainConfig.IsTeslaFork4(uint32(e.Vote1.Height)) {
+		return errVoteBeforeFork
+	}
+	if e.Vote1.Slot() != e.Vote2.Slot() {
+		return errVoteSlotMismatch
+	}
+	if e.Vote1.SigningHash() == e.Vote2.SigningHash() {
+		return errVoteNotConflicting
+	}
+	if err := e.Vote1.Verify(system, pubKey); err != nil {
+		return err
+	}
+	return e.Vote2.Verify(system, pubKey)
+}
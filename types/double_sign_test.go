@@ -0,0 +1,81 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	bls "github.com/dfinlab/meter/crypto/multi_sig"
+	"github.com/dfinlab/meter/meter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleSignEvidence(t *testing.T) {
+	system, err := DefaultBlsSystem()
+	require.Nil(t, err)
+	pubKey, privKey, err := bls.GenKeys(*system)
+	require.Nil(t, err)
+
+	forkNum := meter.TeslaFork4StartNum
+	defer func() { meter.TeslaFork4StartNum = forkNum }()
+	meter.TeslaFork4StartNum = 0
+
+	sign := func(v *SignedVote) {
+		v.Signature = system.SigToBytes(bls.Sign(v.SigningHash(), privKey))
+	}
+	vote1 := SignedVote{Vote: Vote{Epoch: 2, Round: 5, BlockType: 1, Height: 10, BlockID: meter.Bytes32{1}}}
+	vote2 := SignedVote{Vote: Vote{Epoch: 2, Round: 5, BlockType: 1, Height: 10, BlockID: meter.Bytes32{2}}}
+	sign(&vote1)
+	sign(&vote2)
+
+	assert.Nil(t, (&DoubleSignEvidence{vote1, vote2}).Verify(system, pubKey))
+	assert.Equal(t, errVoteNotConflicting, (&DoubleSignEvidence{vote1, vote1}).Verify(system, pubKey))
+
+	vote3 := vote2
+	vote3.Height = 11
+	sign(&vote3)
+	assert.Equal(t, errVoteSlotMismatch, (&DoubleSignEvidence{vote1, vote3}).Verify(system, pubKey))
+
+	// re-voting at the same height in a later round after timeout
+	vote3 = vote2
+	vote3.Round = 6
+	sign(&vote3)
+	assert.Equal(t, errVoteSlotMismatch, (&DoubleSignEvidence{vote1, vote3}).Verify(system, pubKey))
+
+	vote3 = vote2
+	vote3.Epoch = 3
+	sign(&vote3)
+	assert.Equal(t, errVoteSlotMismatch, (&DoubleSignEvidence{vote1, vote3}).Verify(system, pubKey))
+
+	// signed by another key
+	_, otherKey, err := bls.GenKeys(*system)
+	require.Nil(t, err)
+	vote3 = vote2
+	vote3.Signature = system.SigToBytes(bls.Sign(vote3.SigningHash(), otherKey))
+	assert.Equal(t, errVoteBadSignature, (&DoubleSignEvidence{vote1, vote3}).Verify(system, pubKey))
+
+	// votes signed before the fork have no epoch and round
+	meter.TeslaFork4StartNum = 11
+	assert.Equal(t, errVoteBeforeFork, (&DoubleSignEvidence{vote1, vote2}).Verify(system, pubKey))
+}
+
+func TestVoteSignMsg(t *testing.T) {
+	forkNum := meter.TeslaFork4StartNum
+	defer func() { meter.TeslaFork4StartNum = forkNum }()
+
+	vote1 := Vote{Epoch: 2, Round: 5, BlockType: 1, Height: 10, BlockID: meter.Bytes32{1}}
+	vote2 := vote1
+	vote2.Round = 6
+
+	meter.TeslaFork4StartNum = 11
+	assert.Equal(t, vote1.SignMsg(), vote2.SignMsg())
+
+	meter.TeslaFork4StartNum = 10
+	assert.NotEqual(t, vote1.SignMsg(), vote2.SignMsg())
+	assert.True(t, strings.HasSuffix(vote1.SignMsg(), "Epoch 0000000000000002 Round 00000005"))
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ChainConfig extends params.ChainConfig with the forks after Constantinople.
+// A nil fork block means the fork is not activated.
+type ChainConfig struct {
+	params.ChainConfig
+
+	IstanbulBlock *big.Int // Istanbul switch block (nil = no fork)
+	BerlinBlock   *big.Int // Berlin switch block (nil = no fork)
+	LondonBlock   *big.Int // London switch block (nil = no fork)
+}
+
+// NewChainConfig creates a ChainConfig without forks after Constantinople.
+func NewChainConfig(config *params.ChainConfig) *ChainConfig {
+	return &ChainConfig{ChainConfig: *config}
+}
+
+// IsIstanbul returns whether num is either equal to the Istanbul fork block or greater.
+func (c *ChainConfig) IsIstanbul(num *big.Int) bool {
+	return isForked(c.IstanbulBlock, num)
+}
+
+// IsBerlin returns whether num is either equal to the Berlin fork block or greater.
+func (c *ChainConfig) IsBerlin(num *big.Int) bool {
+	return isForked(c.BerlinBlock, num)
+}
+
+// IsLondon returns whether num is either equal to the London fork block or greater.
+func (c *ChainConfig) IsLondon(num *big.Int) bool {
+	return isForked(c.LondonBlock, num)
+}
+
+// Rules wraps params.Rules with the forks after Constantinople.
+type Rules struct {
+	params.Rules
+	IsConstantinople               bool
+	IsIstanbul, IsBerlin, IsLondon bool
+}
+
+// Rules returns the rules of the forks activated at num.
+func (c *ChainConfig) Rules(num *big.Int) Rules {
+	return Rules{
+		Rules:            c.ChainConfig.Rules(num),
+		IsConstantinople: c.IsConstantinople(num),
+		IsIstanbul:       c.IsIstanbul(num),
+		IsBerlin:         c.IsBerlin(num),
+		IsLondon:         c.IsLondon(num),
+	}
+}
+
+func isForked(s, head *big.Int) bool {
+	if s == nil || head == nil {
+		return false
+	}
+	return s.Cmp(head) <= 0
+}
@@ -336,12 +336,44 @@ var bn256PairingTests = []precompiledTest{
 	},
 }
 
+// blake2FTests are the test data from EIP 152, for the blake2F precompiled contract.
+var blake2FTests = []precompiledTest{
+	{
+		input:    "0000000048c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b",
+		gas:      0,
+		name:     "vector 4",
+	}, {
+		input:    "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923",
+		gas:      12,
+		name:     "vector 5",
+	}, {
+		input:    "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000",
+		expected: "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735",
+		gas:      12,
+		name:     "vector 6",
+	}, {
+		input:    "0000000148c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "b63a380cb2897d521994a85234ee2c181b5f844d2c624c002677e9703449d2fba551b3a8333bcdf5f2f7e08993d53923de3d64fcc68c034e717b9293fed7a421",
+		gas:      1,
+		name:     "vector 7",
+	},
+}
+
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsByzantium[common.HexToAddress(addr)]
+	testPrecompiledWith(PrecompiledContractsByzantium, addr, test, t)
+}
+
+func testPrecompiledWith(precompiles map[common.Address]PrecompiledContract, addr string, test precompiledTest, t *testing.T) {
+	p := precompiles[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in))
 	t.Run(fmt.Sprintf("%s-Gas=%d", test.name, contract.Gas), func(t *testing.T) {
+		if test.gas != 0 && contract.Gas != test.gas {
+			t.Errorf("Expected gas %v, got %v", test.gas, contract.Gas)
+		}
 		if res, err := RunPrecompiledContract(p, in, contract); err != nil {
 			t.Error(err)
 		} else if common.Bytes2Hex(res) != test.expected {
@@ -481,3 +513,59 @@ func BenchmarkPrecompiledBn256Pairing(bench *testing.B) {
 		benchmarkPrecompiled("08", test, bench)
 	}
 }
+
+// Tests the gas of the sample inputs from the ModExp EIP 198, repriced by EIP 2565.
+func TestPrecompiledModExpEIP2565(t *testing.T) {
+	gas := map[string]uint64{
+		"eip_example1":          1360,
+		"eip_example2":          1360,
+		"nagydani-1-square":     200,
+		"nagydani-1-qube":       200,
+		"nagydani-1-pow0x10001": 341,
+		"nagydani-2-square":     200,
+		"nagydani-2-qube":       200,
+		"nagydani-2-pow0x10001": 1365,
+		"nagydani-3-square":     341,
+		"nagydani-3-qube":       341,
+		"nagydani-3-pow0x10001": 5461,
+	}
+	for _, test := range modexpTests {
+		if g, ok := gas[test.name]; ok {
+			test.gas = g
+			testPrecompiledWith(PrecompiledContractsBerlin, "05", test, t)
+		}
+	}
+}
+
+// Tests the gas of the elliptic curve precompiles repriced by EIP 1108.
+func TestPrecompiledBn256Istanbul(t *testing.T) {
+	for _, test := range bn256AddTests {
+		test.gas = Bn256AddGasIstanbul
+		testPrecompiledWith(PrecompiledContractsIstanbul, "06", test, t)
+	}
+	for _, test := range bn256ScalarMulTests {
+		test.gas = Bn256ScalarMulGasIstanbul
+		testPrecompiledWith(PrecompiledContractsIstanbul, "07", test, t)
+	}
+}
+
+// Tests the sample inputs from the BLAKE2 F compression function EIP 152.
+func TestPrecompiledBlake2F(t *testing.T) {
+	for _, test := range blake2FTests {
+		testPrecompiledWith(PrecompiledContractsIstanbul, "09", test, t)
+	}
+}
+
+func TestPrecompiledBlake2FMalformedInput(t *testing.T) {
+	p := PrecompiledContractsIstanbul[common.HexToAddress("09")]
+	valid := common.Hex2Bytes(blake2FTests[1].input)
+
+	if _, err := p.Run(valid[:blake2FInputLength-1]); err != errBlake2FInvalidInputLength {
+		t.Errorf("Expected error %v, got %v", errBlake2FInvalidInputLength, err)
+	}
+	invalidFlag := append([]byte{}, valid...)
+	invalidFlag[blake2FInputLength-1] = 2
+	if _, err := p.Run(invalidFlag); err != errBlake2FInvalidFinalFlag {
+		t.Errorf("Expected error %v, got %v", errBlake2FInvalidFinalFlag, err)
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	SloadGasEIP1884       uint64 = 800 // Cost of SLOAD after EIP 1884
+	BalanceGasEIP1884     uint64 = 700 // Cost of BALANCE after EIP 1884
+	ExtcodeHashGasEIP1884 uint64 = 700 // Cost of EXTCODEHASH after EIP 1884
+	SelfBalanceGas        uint64 = 5   // Cost of SELFBALANCE
+
+	SstoreSentryGasEIP2200            uint64 = 2300  // Minimum gas required to be present for an SSTORE call, not consumed
+	SstoreSetGasEIP2200               uint64 = 20000 // Once per SSTORE operation from clean zero to non-zero
+	SstoreResetGasEIP2200             uint64 = 5000  // Once per SSTORE operation from clean non-zero to something else
+	SstoreClearsScheduleRefundEIP2200 uint64 = 15000 // Once per SSTORE operation for clearing an originally existing storage slot
+
+	ColdAccountAccessCostEIP2929 uint64 = 2600 // Cost of cold account access
+	ColdSloadCostEIP2929         uint64 = 2100 // Cost of cold SLOAD
+	WarmStorageReadCostEIP2929   uint64 = 100  // Cost of reading warm storage
+
+	// SstoreClearsScheduleRefundEIP3529 is the refund of clearing a slot after EIP 3529,
+	// SSTORE_RESET_GAS (5000 - 2100) + ACCESS_LIST_STORAGE_KEY_COST (1900).
+	SstoreClearsScheduleRefundEIP3529 uint64 = 2900 + 1900
+)
+
+var errSstoreSentry = errors.New("not enough gas for reentrancy sentry")
+
+// repriceGasTable applies the repricing of EIP 1884 and EIP 2929 to the gas table.
+func repriceGasTable(gt params.GasTable, rules Rules) params.GasTable {
+	if rules.IsIstanbul {
+		gt.SLoad = SloadGasEIP1884
+		gt.Balance = BalanceGasEIP1884
+		gt.ExtcodeHash = ExtcodeHashGasEIP1884
+	}
+	if rules.IsBerlin {
+		// charge the warm cost by default, the cold surcharge is added by the gas functions
+		gt.SLoad = WarmStorageReadCostEIP2929
+		gt.Balance = WarmStorageReadCostEIP2929
+		gt.ExtcodeSize = WarmStorageReadCostEIP2929
+		gt.ExtcodeCopy = WarmStorageReadCostEIP2929
+		gt.ExtcodeHash = WarmStorageReadCostEIP2929
+		gt.Calls = WarmStorageReadCostEIP2929
+	}
+	return gt
+}
+
+// enable1344 applies EIP-1344 (ChainID Opcode)
+// - Adds an opcode that returns the current chain’s EIP-155 unique identifier
+func enable1344(jt *[256]operation) {
+	jt[CHAINID] = operation{
+		execute:       opChainID,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+// enable1884 applies EIP-1884 to the given jump table:
+// - Increase cost of BALANCE, EXTCODEHASH and SLOAD (through the gas table)
+// - Define SELFBALANCE, with cost GasFastStep (5)
+func enable1884(jt *[256]operation) {
+	jt[SELFBALANCE] = operation{
+		execute:       opSelfBalance,
+		gasCost:       constGasFunc(SelfBalanceGas),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+// enable2200 applies EIP-2200 (Rebalance net-metered SSTORE)
+func enable2200(jt *[256]operation) {
+	jt[SSTORE].gasCost = makeGasSStoreFunc(SloadGasEIP1884, SstoreResetGasEIP2200, SstoreClearsScheduleRefundEIP2200, false)
+}
+
+// enable2929 applies EIP-2929 (Gas cost increases for state access opcodes)
+func enable2929(jt *[256]operation) {
+	jt[SLOAD].gasCost = gasSLoadEIP2929
+	jt[SSTORE].gasCost = makeGasSStoreFunc(WarmStorageReadCostEIP2929, SstoreResetGasEIP2200-ColdSloadCostEIP2929, SstoreClearsScheduleRefundEIP2200, true)
+
+	jt[BALANCE].gasCost = makeGasAccountAccessEIP2929(jt[BALANCE].gasCost)
+	jt[EXTCODESIZE].gasCost = makeGasAccountAccessEIP2929(jt[EXTCODESIZE].gasCost)
+	jt[EXTCODECOPY].gasCost = makeGasAccountAccessEIP2929(jt[EXTCODECOPY].gasCost)
+	jt[EXTCODEHASH].gasCost = makeGasAccountAccessEIP2929(jt[EXTCODEHASH].gasCost)
+
+	jt[CALL].gasCost = makeGasCallEIP2929(jt[CALL].gasCost)
+	jt[CALLCODE].gasCost = makeGasCallEIP2929(jt[CALLCODE].gasCost)
+	jt[DELEGATECALL].gasCost = makeGasCallEIP2929(jt[DELEGATECALL].gasCost)
+	jt[STATICCALL].gasCost = makeGasCallEIP2929(jt[STATICCALL].gasCost)
+
+	jt[SELFDESTRUCT].gasCost = makeGasSelfdestructEIP2929(true)
+}
+
+// enable3529 applies EIP-3529 (Reduction in refunds):
+// - Removes refunds for selfdestructs
+// - Reduces refunds for SSTORE
+// - The max refund quotient is applied by the runtime
+func enable3529(jt *[256]operation) {
+	jt[SSTORE].gasCost = makeGasSStoreFunc(WarmStorageReadCostEIP2929, SstoreResetGasEIP2200-ColdSloadCostEIP2929, SstoreClearsScheduleRefundEIP3529, true)
+	jt[SELFDESTRUCT].gasCost = makeGasSelfdestructEIP2929(false)
+}
+
+// enable3198 applies EIP-3198 (BASEFEE Opcode)
+func enable3198(jt *[256]operation) {
+	jt[BASEFEE] = operation{
+		execute:       opBaseFee,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+func opChainID(pc *uint64, evm *EVM, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	stack.push(evm.interpreter.intPool.get().Set(evm.chainConfig.ChainID))
+	return nil, nil
+}
+
+func opSelfBalance(pc *uint64, evm *EVM, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	// same as opBalance, points to meter
+	stack.push(evm.interpreter.intPool.get().Set(evm.StateDB.GetEnergy(contract.Address())))
+	return nil, nil
+}
+
+func opBaseFee(pc *uint64, evm *EVM, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	baseFee := evm.interpreter.intPool.get()
+	if evm.BaseFee != nil {
+		baseFee.Set(evm.BaseFee)
+	}
+	stack.push(baseFee)
+	return nil, nil
+}
+
+// makeGasSStoreFunc creates the net gas metering SSTORE gas function of EIP 2200,
+// warmRead is the cost of a no-op or dirty update, and with coldAccess the EIP 2929
+// cold slot surcharge is applied.
+func makeGasSStoreFunc(warmRead, resetGas, clearRefund uint64, coldAccess bool) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		// If we fail the minimum gas availability invariant, fail (0)
+		if contract.Gas <= SstoreSentryGasEIP2200 {
+			return 0, errSstoreSentry
+		}
+		var (
+			addr = contract.Address()
+			slot = common.BigToHash(stack.Back(0))
+			cost uint64
+		)
+		if coldAccess && !evm.StateDB.SlotInAccessList(addr, slot) {
+			evm.StateDB.AddSlotToAccessList(addr, slot)
+			cost = ColdSloadCostEIP2929
+		}
+		var (
+			value   = common.BigToHash(stack.Back(1))
+			current = evm.StateDB.GetState(addr, slot)
+		)
+		if current == value { // noop (1)
+			return cost + warmRead, nil
+		}
+		original := evm.StateDB.GetCommittedState(addr, slot)
+		if original == current {
+			if original == (common.Hash{}) { // create slot (2.1.1)
+				return cost + SstoreSetGasEIP2200, nil
+			}
+			if value == (common.Hash{}) { // delete slot (2.1.2b)
+				evm.StateDB.AddRefund(clearRefund)
+			}
+			return cost + resetGas, nil // write existing slot (2.1.2)
+		}
+		if original != (common.Hash{}) {
+			if current == (common.Hash{}) { // recreate slot (2.2.1.1)
+				evm.StateDB.SubRefund(clearRefund)
+			} else if value == (common.Hash{}) { // delete slot (2.2.1.2)
+				evm.StateDB.AddRefund(clearRefund)
+			}
+		}
+		if original == value {
+			if original == (common.Hash{}) { // reset to original inexistent slot (2.2.2.1)
+				evm.StateDB.AddRefund(SstoreSetGasEIP2200 - warmRead)
+			} else { // reset to original existing slot (2.2.2.2)
+				evm.StateDB.AddRefund(resetGas - warmRead)
+			}
+		}
+		return cost + warmRead, nil // dirty update (2.2)
+	}
+}
+
+func gasSLoadEIP2929(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	var (
+		addr = contract.Address()
+		slot = common.BigToHash(stack.Back(0))
+	)
+	if !evm.StateDB.SlotInAccessList(addr, slot) {
+		evm.StateDB.AddSlotToAccessList(addr, slot)
+		return ColdSloadCostEIP2929, nil
+	}
+	return WarmStorageReadCostEIP2929, nil
+}
+
+// makeGasAccountAccessEIP2929 adds the cold account surcharge to the gas function
+// of BALANCE, EXTCODESIZE, EXTCODECOPY and EXTCODEHASH, which takes the address
+// from the top of the stack.
+func makeGasAccountAccessEIP2929(oldCalculator gasFunc) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		addr := common.BigToAddress(stack.Back(0))
+		gas, err := oldCalculator(gt, evm, contract, stack, mem, memorySize)
+		if err != nil {
+			return 0, err
+		}
+		if evm.StateDB.AddressInAccessList(addr) {
+			return gas, nil
+		}
+		evm.StateDB.AddAddressToAccessList(addr)
+		var overflow bool
+		if gas, overflow = math.SafeAdd(gas, ColdAccountAccessCostEIP2929-WarmStorageReadCostEIP2929); overflow {
+			return 0, errGasUintOverflow
+		}
+		return gas, nil
+	}
+}
+
+// makeGasCallEIP2929 adds the cold account surcharge to the gas function of the call variants.
+func makeGasCallEIP2929(oldCalculator gasFunc) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		var (
+			addr       = common.BigToAddress(stack.Back(1))
+			warmAccess = evm.StateDB.AddressInAccessList(addr)
+			coldCost   = ColdAccountAccessCostEIP2929 - WarmStorageReadCostEIP2929
+		)
+		if !warmAccess {
+			evm.StateDB.AddAddressToAccessList(addr)
+			// Charge the cold cost before the call gas is calculated, so the
+			// 63/64 rule is applied to the remaining gas.
+			if !contract.UseGas(coldCost) {
+				return 0, ErrOutOfGas
+			}
+		}
+		gas, err := oldCalculator(gt, evm, contract, stack, mem, memorySize)
+		if warmAccess || err != nil {
+			return gas, err
+		}
+		// The cold cost was charged above, give it back since the
+		// interpreter charges the returned gas as a whole.
+		contract.Gas += coldCost
+
+		var overflow bool
+		if gas, overflow = math.SafeAdd(gas, coldCost); overflow {
+			return 0, errGasUintOverflow
+		}
+		return gas, nil
+	}
+}
+
+// makeGasSelfdestructEIP2929 creates the SELFDESTRUCT gas function with the cold
+// account surcharge, the refund is removed since EIP 3529.
+func makeGasSelfdestructEIP2929(refundsEnabled bool) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		var (
+			gas     = gt.Suicide
+			address = common.BigToAddress(stack.Back(0))
+		)
+		if !evm.StateDB.AddressInAccessList(address) {
+			// If the caller cannot afford the cost, this change will be rolled back
+			evm.StateDB.AddAddressToAccessList(address)
+			gas += ColdAccountAccessCostEIP2929
+		}
+		// if empty and transfers value
+		if evm.StateDB.Empty(address) && evm.StateDB.GetBalance(contract.Address()).Sign() != 0 {
+			gas += gt.CreateBySuicide
+		}
+		if refundsEnabled && !evm.StateDB.HasSuicided(contract.Address()) {
+			evm.StateDB.AddRefund(params.SuicideRefundGas)
+		}
+		return gas, nil
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) 2020 The Meter.io developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package vm
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// memStateDB is a minimal in-memory StateDB for testing gas rules.
+type memStateDB struct {
+	code      map[common.Address][]byte
+	committed map[common.Address]map[common.Hash]common.Hash
+	storage   map[common.Address]map[common.Hash]common.Hash
+	energy    map[common.Address]*big.Int
+	refund    uint64
+	addrs     map[common.Address]bool
+	slots     map[common.Address]map[common.Hash]bool
+}
+
+func newMemStateDB() *memStateDB {
+	return &memStateDB{
+		code:      make(map[common.Address][]byte),
+		committed: make(map[common.Address]map[common.Hash]common.Hash),
+		storage:   make(map[common.Address]map[common.Hash]common.Hash),
+		energy:    make(map[common.Address]*big.Int),
+		addrs:     make(map[common.Address]bool),
+		slots:     make(map[common.Address]map[common.Hash]bool),
+	}
+}
+
+// commit sets the committed value of the slot.
+func (s *memStateDB) commit(addr common.Address, key, value common.Hash) {
+	if s.committed[addr] == nil {
+		s.committed[addr] = make(map[common.Hash]common.Hash)
+	}
+	s.committed[addr][key] = value
+	s.SetState(addr, key, value)
+}
+
+func (s *memStateDB) CreateAccount(common.Address)                 {}
+func (s *memStateDB) SubBalance(common.Address, *big.Int) bool     { return true }
+func (s *memStateDB) AddBalance(common.Address, *big.Int)          {}
+func (s *memStateDB) GetBalance(common.Address) *big.Int           { return new(big.Int) }
+func (s *memStateDB) SubEnergy(common.Address, *big.Int) bool      { return true }
+func (s *memStateDB) AddEnergy(common.Address, *big.Int)           {}
+func (s *memStateDB) GetNonce(common.Address) uint64               { return 0 }
+func (s *memStateDB) SetNonce(common.Address, uint64)              {}
+func (s *memStateDB) SetCode(addr common.Address, code []byte)     { s.code[addr] = code }
+func (s *memStateDB) GetCode(addr common.Address) []byte           { return s.code[addr] }
+func (s *memStateDB) GetCodeSize(addr common.Address) int          { return len(s.code[addr]) }
+func (s *memStateDB) AddRefund(gas uint64)                         { s.refund += gas }
+func (s *memStateDB) SubRefund(gas uint64)                         { s.refund -= gas }
+func (s *memStateDB) GetRefund() uint64                            { return s.refund }
+func (s *memStateDB) Suicide(common.Address) bool                  { return false }
+func (s *memStateDB) HasSuicided(common.Address) bool              { return false }
+func (s *memStateDB) Exist(addr common.Address) bool               { return len(s.code[addr]) > 0 }
+func (s *memStateDB) Empty(addr common.Address) bool               { return len(s.code[addr]) == 0 }
+func (s *memStateDB) RevertToSnapshot(int)                         {}
+func (s *memStateDB) Snapshot() int                                { return 0 }
+func (s *memStateDB) AddLog(*types.Log)                            {}
+func (s *memStateDB) AddPreimage(common.Hash, []byte)              {}
+func (s *memStateDB) AddressInAccessList(addr common.Address) bool { return s.addrs[addr] }
+func (s *memStateDB) AddAddressToAccessList(addr common.Address)   { s.addrs[addr] = true }
+
+func (s *memStateDB) GetEnergy(addr common.Address) *big.Int {
+	if v := s.energy[addr]; v != nil {
+		return v
+	}
+	return new(big.Int)
+}
+
+func (s *memStateDB) GetCodeHash(addr common.Address) common.Hash {
+	if code := s.code[addr]; len(code) > 0 {
+		return crypto.Keccak256Hash(code)
+	}
+	return common.Hash{}
+}
+
+func (s *memStateDB) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	return s.committed[addr][key]
+}
+
+func (s *memStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	return s.storage[addr][key]
+}
+
+func (s *memStateDB) SetState(addr common.Address, key, value common.Hash) {
+	if s.storage[addr] == nil {
+		s.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	s.storage[addr][key] = value
+}
+
+func (s *memStateDB) SlotInAccessList(addr common.Address, slot common.Hash) bool {
+	return s.slots[addr][slot]
+}
+
+func (s *memStateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	s.addrs[addr] = true
+	if s.slots[addr] == nil {
+		s.slots[addr] = make(map[common.Hash]bool)
+	}
+	s.slots[addr][slot] = true
+}
+
+func newForkedEVM(statedb StateDB, istanbul, berlin, london bool) *EVM {
+	config := NewChainConfig(params.TestChainConfig)
+	if istanbul {
+		config.IstanbulBlock = big.NewInt(0)
+	}
+	if berlin {
+		config.BerlinBlock = big.NewInt(0)
+	}
+	if london {
+		config.LondonBlock = big.NewInt(0)
+	}
+	return NewEVM(Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int, byte) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int, byte) {},
+		BlockNumber: big.NewInt(0),
+		BaseFee:     big.NewInt(500),
+	}, statedb, config, Config{})
+}
+
+var eip2200Tests = []struct {
+	original byte
+	gaspool  uint64
+	input    string
+	used     uint64
+	refund   uint64
+	failure  error
+}{
+	{0, math.MaxUint64, "0x60006000556000600055", 1612, 0, nil},                // 0 -> 0 -> 0
+	{0, math.MaxUint64, "0x60006000556001600055", 20812, 0, nil},               // 0 -> 0 -> 1
+	{0, math.MaxUint64, "0x60016000556000600055", 20812, 19200, nil},           // 0 -> 1 -> 0
+	{0, math.MaxUint64, "0x60016000556002600055", 20812, 0, nil},               // 0 -> 1 -> 2
+	{0, math.MaxUint64, "0x60016000556001600055", 20812, 0, nil},               // 0 -> 1 -> 1
+	{1, math.MaxUint64, "0x60006000556000600055", 5812, 15000, nil},            // 1 -> 0 -> 0
+	{1, math.MaxUint64, "0x60006000556001600055", 5812, 4200, nil},             // 1 -> 0 -> 1
+	{1, math.MaxUint64, "0x60006000556002600055", 5812, 0, nil},                // 1 -> 0 -> 2
+	{1, math.MaxUint64, "0x60026000556000600055", 5812, 15000, nil},            // 1 -> 2 -> 0
+	{1, math.MaxUint64, "0x60026000556003600055", 5812, 0, nil},                // 1 -> 2 -> 3
+	{1, math.MaxUint64, "0x60026000556001600055", 5812, 4200, nil},             // 1 -> 2 -> 1
+	{1, math.MaxUint64, "0x60026000556002600055", 5812, 0, nil},                // 1 -> 2 -> 2
+	{1, math.MaxUint64, "0x60016000556000600055", 5812, 15000, nil},            // 1 -> 1 -> 0
+	{1, math.MaxUint64, "0x60016000556002600055", 5812, 0, nil},                // 1 -> 1 -> 2
+	{1, math.MaxUint64, "0x60016000556001600055", 1612, 0, nil},                // 1 -> 1 -> 1
+	{0, math.MaxUint64, "0x600160005560006000556001600055", 40818, 19200, nil}, // 0 -> 1 -> 0 -> 1
+	{1, math.MaxUint64, "0x600060005560016000556000600055", 10818, 19200, nil}, // 1 -> 0 -> 1 -> 0
+	{1, 2306, "0x6001600055", 2306, 0, ErrOutOfGas},                            // 1 -> 1 (2300 sentry + 2xPUSH)
+	{1, 2307, "0x6001600055", 806, 0, nil},                                     // 1 -> 1 (2301 sentry + 2xPUSH)
+}
+
+func TestEIP2200(t *testing.T) {
+	for i, tt := range eip2200Tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb := newMemStateDB()
+		statedb.SetCode(address, hexutil.MustDecode(tt.input))
+		statedb.commit(address, common.Hash{}, common.BytesToHash([]byte{tt.original}))
+
+		evm := newForkedEVM(statedb, true, false, false)
+		_, gas, err := evm.Call(AccountRef(common.Address{}), address, nil, tt.gaspool, new(big.Int), 0)
+		if err != tt.failure {
+			t.Errorf("test %d: failure mismatch: have %v, want %v", i, err, tt.failure)
+		}
+		if used := tt.gaspool - gas; used != tt.used {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+		if refund := statedb.GetRefund(); refund != tt.refund {
+			t.Errorf("test %d: gas refund mismatch: have %v, want %v", i, refund, tt.refund)
+		}
+	}
+}
+
+func TestEIP2929(t *testing.T) {
+	tests := []struct {
+		input  string
+		used   uint64
+		london bool
+	}{
+		{"0x600154600154", 3 + 2100 + 3 + 100, false},             // cold then warm SLOAD
+		{"0x60ff3160ff31", 3 + 2600 + 3 + 100, false},             // cold then warm BALANCE
+		{"0x60ff3b60ff3b", 3 + 2600 + 3 + 100, false},             // cold then warm EXTCODESIZE
+		{"0x60ff3f60ff3f", 3 + 2600 + 3 + 100, false},             // cold then warm EXTCODEHASH
+		{"0x6001600155", 3 + 3 + 2100 + 20000, false},             // cold SSTORE 0 -> 1
+		{"0x600160015460015500", 3 + 3 + 2100 + 3 + 100, false},   // warm SSTORE 0 -> 0
+		{"0x6000600060006000600060ff5af1", 6*3 + 2 + 2600, false}, // cold CALL
+		{"0x464748", 2 + 5 + 2, true},                             // CHAINID, SELFBALANCE, BASEFEE
+	}
+	for i, tt := range tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb := newMemStateDB()
+		statedb.SetCode(address, hexutil.MustDecode(tt.input))
+
+		evm := newForkedEVM(statedb, true, true, tt.london)
+		_, gas, err := evm.Call(AccountRef(common.Address{}), address, nil, 100000, new(big.Int), 0)
+		if err != nil {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+		if used := 100000 - gas; used != tt.used {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+	}
+}
+
+func TestInstructionsBeforeFork(t *testing.T) {
+	for _, op := range []OpCode{CHAINID, SELFBALANCE, BASEFEE} {
+		if constantinopleInstructionSet[op].valid {
+			t.Errorf("%v should be invalid before istanbul", op)
+		}
+	}
+	if istanbulInstructionSet[BASEFEE].valid {
+		t.Errorf("%v should be invalid before london", BASEFEE)
+	}
+	if !londonInstructionSet[BASEFEE].valid {
+		t.Errorf("%v should be valid since london", BASEFEE)
+	}
+}
+
+func TestChainIDAndBaseFee(t *testing.T) {
+	var (
+		config = NewChainConfig(params.TestChainConfig)
+		env    = NewEVM(Context{BaseFee: big.NewInt(500)}, nil, config, Config{})
+		stack  = newstack()
+		pc     = uint64(0)
+	)
+	opChainID(&pc, env, nil, nil, stack)
+	if v := stack.pop(); v.Cmp(config.ChainID) != 0 {
+		t.Errorf("expected chain id %v, got %v", config.ChainID, v)
+	}
+	opBaseFee(&pc, env, nil, nil, stack)
+	if v := stack.pop(); v.Int64() != 500 {
+		t.Errorf("expected base fee 500, got %v", v)
+	}
+}
+
+func TestIsPrecompile(t *testing.T) {
+	ecrecover := common.BytesToAddress([]byte{1})
+	blake2f := common.BytesToAddress([]byte{9})
+
+	byzantium := newForkedEVM(nil, false, false, false)
+	if !byzantium.IsPrecompile(ecrecover) || byzantium.IsPrecompile(blake2f) {
+		t.Errorf("blake2f should be a precompile since istanbul only")
+	}
+	istanbul := newForkedEVM(nil, true, false, false)
+	if !istanbul.IsPrecompile(ecrecover) || !istanbul.IsPrecompile(blake2f) {
+		t.Errorf("blake2f should be a precompile since istanbul")
+	}
+	if len(istanbul.ActivePrecompiles()) != len(PrecompiledContractsIstanbul) {
+		t.Errorf("expected %v active precompiles, got %v", len(PrecompiledContractsIstanbul), len(istanbul.ActivePrecompiles()))
+	}
+}
+
+func TestRejectCodeStartingWithEF(t *testing.T) {
+	// returns one byte 0xef as the code of the new contract
+	initCode := hexutil.MustDecode("0x60ef60005360016000f3")
+
+	for _, london := range []bool{false, true} {
+		statedb := newMemStateDB()
+		evm := newForkedEVM(statedb, true, true, london)
+		evm.NewContractAddress = func(*EVM, uint32) common.Address {
+			return common.BytesToAddress([]byte("created"))
+		}
+		_, _, _, err := evm.Create(AccountRef(common.Address{}), initCode, 100000, new(big.Int), 0)
+		if london && err != errInvalidCode {
+			t.Errorf("expected error %v, got %v", errInvalidCode, err)
+		}
+		if !london && err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+}
@@ -44,8 +44,10 @@ type StateDB interface {
 	GetCodeSize(common.Address) int
 
 	AddRefund(uint64)
+	SubRefund(uint64)
 	GetRefund() uint64
 
+	GetCommittedState(common.Address, common.Hash) common.Hash
 	GetState(common.Address, common.Hash) common.Hash
 	SetState(common.Address, common.Hash, common.Hash)
 
@@ -65,6 +67,17 @@ type StateDB interface {
 	AddLog(*types.Log)
 	AddPreimage(common.Hash, []byte)
 
+	// AddressInAccessList returns true if the address is in the access list (EIP-2929).
+	AddressInAccessList(addr common.Address) bool
+	// SlotInAccessList returns true if the (address, slot)-tuple is in the access list.
+	SlotInAccessList(addr common.Address, slot common.Hash) bool
+	// AddAddressToAccessList adds the given address to the access list. This operation is safe to perform
+	// even if the feature/fork is not active yet
+	AddAddressToAccessList(addr common.Address)
+	// AddSlotToAccessList adds the given (address,slot) to the access list. This operation is safe to perform
+	// even if the feature/fork is not active yet
+	AddSlotToAccessList(addr common.Address, slot common.Hash)
+
 	// ForEachStorage(common.Address, func(common.Hash, common.Hash) bool)
 }
 
@@ -62,7 +62,13 @@ func NewInterpreter(evm *EVM, cfg Config) *Interpreter {
 	// we'll set the default jump table.
 	if !cfg.JumpTable[STOP].valid {
 		switch {
-		case evm.ChainConfig().IsConstantinople(evm.BlockNumber):
+		case evm.chainRules.IsLondon:
+			cfg.JumpTable = londonInstructionSet
+		case evm.chainRules.IsBerlin:
+			cfg.JumpTable = berlinInstructionSet
+		case evm.chainRules.IsIstanbul:
+			cfg.JumpTable = istanbulInstructionSet
+		case evm.chainRules.IsConstantinople:
 			cfg.JumpTable = constantinopleInstructionSet
 		case evm.ChainConfig().IsByzantium(evm.BlockNumber):
 			cfg.JumpTable = byzantiumInstructionSet
@@ -76,7 +82,7 @@ func NewInterpreter(evm *EVM, cfg Config) *Interpreter {
 	return &Interpreter{
 		evm:      evm,
 		cfg:      cfg,
-		gasTable: evm.ChainConfig().GasTable(evm.BlockNumber),
+		gasTable: repriceGasTable(evm.ChainConfig().GasTable(evm.BlockNumber), evm.chainRules),
 		intPool:  newIntPool(),
 	}
 }
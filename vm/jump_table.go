@@ -55,8 +55,38 @@ var (
 	homesteadInstructionSet      = NewHomesteadInstructionSet()
 	byzantiumInstructionSet      = NewByzantiumInstructionSet()
 	constantinopleInstructionSet = NewConstantinopleInstructionSet()
+	istanbulInstructionSet       = NewIstanbulInstructionSet()
+	berlinInstructionSet         = NewBerlinInstructionSet()
+	londonInstructionSet         = NewLondonInstructionSet()
 )
 
+// NewLondonInstructionSet returns the frontier, homestead, byzantium,
+// contantinople, istanbul, berlin and london instructions.
+func NewLondonInstructionSet() [256]operation {
+	instructionSet := NewBerlinInstructionSet()
+	enable3529(&instructionSet) // EIP-3529: Reduction in refunds
+	enable3198(&instructionSet) // EIP-3198: BASEFEE opcode
+	return instructionSet
+}
+
+// NewBerlinInstructionSet returns the frontier, homestead, byzantium,
+// contantinople, istanbul and berlin instructions.
+func NewBerlinInstructionSet() [256]operation {
+	instructionSet := NewIstanbulInstructionSet()
+	enable2929(&instructionSet) // EIP-2929: Gas cost increases for state access opcodes
+	return instructionSet
+}
+
+// NewIstanbulInstructionSet returns the frontier, homestead, byzantium,
+// contantinople and istanbul instructions.
+func NewIstanbulInstructionSet() [256]operation {
+	instructionSet := NewConstantinopleInstructionSet()
+	enable1344(&instructionSet) // EIP-1344: CHAINID opcode
+	enable1884(&instructionSet) // EIP-1884: Repricing for trie-size-dependent opcodes
+	enable2200(&instructionSet) // EIP-2200: Net gas metering for SSTORE
+	return instructionSet
+}
+
 // NewConstantinopleInstructionSet returns the frontier, homestead
 // byzantium and contantinople instructions.
 func NewConstantinopleInstructionSet() [256]operation {
@@ -57,6 +57,8 @@ func (NoopStateDB) SetCode(common.Address, []byte)
 func (NoopStateDB) GetCodeSize(common.Address) int                                     { return 0 }
 func (NoopStateDB) AddRefund(uint64)                                                   {}
 func (NoopStateDB) GetRefund() uint64                                                  { return 0 }
+func (NoopStateDB) SubRefund(uint64)                                                   {}
+func (NoopStateDB) GetCommittedState(common.Address, common.Hash) common.Hash          { return common.Hash{} }
 func (NoopStateDB) GetState(common.Address, common.Hash) common.Hash                   { return common.Hash{} }
 func (NoopStateDB) SetState(common.Address, common.Hash, common.Hash)                  {}
 func (NoopStateDB) Suicide(common.Address) bool                                        { return false }
@@ -68,3 +70,7 @@ func (NoopStateDB) Snapshot() int
 func (NoopStateDB) AddLog(*types.Log)                                                  {}
 func (NoopStateDB) AddPreimage(common.Hash, []byte)                                    {}
 func (NoopStateDB) ForEachStorage(common.Address, func(common.Hash, common.Hash) bool) {}
+func (NoopStateDB) AddressInAccessList(common.Address) bool                            { return false }
+func (NoopStateDB) SlotInAccessList(common.Address, common.Hash) bool                  { return false }
+func (NoopStateDB) AddAddressToAccessList(common.Address)                              {}
+func (NoopStateDB) AddSlotToAccessList(common.Address, common.Hash)                    {}